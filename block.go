@@ -13,12 +13,11 @@ func blocking(ctx context.Context, c *client, userID string, opt ...*BlockOption
 	if userID == "" {
 		return nil, errors.New("blocking: userID parameter is required")
 	}
-	ep := fmt.Sprintf(blockingURL, userID)
+	ep := c.baseURL + fmt.Sprintf(blockingURL, userID)
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ep, nil)
 	if err != nil {
 		return nil, fmt.Errorf("blocking new request with ctx: %w", err)
 	}
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.bearerToken))
 	var ropt BlockOption
 	switch len(opt) {
 	case 0:
@@ -29,31 +28,14 @@ func blocking(ctx context.Context, c *client, userID string, opt ...*BlockOption
 		return nil, errors.New("blocking: only one option is allowed")
 	}
 	ropt.addQuery(req)
-	resp, err := c.client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("blocking response: %w", err)
-	}
-	defer func() { _ = resp.Body.Close() }()
-	var blocking BlockingResponse
-	if err := json.NewDecoder(resp.Body).Decode(&blocking); err != nil {
-		return nil, fmt.Errorf("blocking decode: %w", err)
-	}
-	if resp.StatusCode != http.StatusOK {
-		return &blocking, &HTTPError{
-			APIName: "blocking",
-			Status:  resp.Status,
-			URL:     req.URL.String(),
-		}
-	}
-
-	return &blocking, nil
+	return doJSON[BlockingResponse](c, req, "blocking", http.StatusOK)
 }
 
 func postBlocking(ctx context.Context, c *client, userID string, targetUserID string) (*PostBlockingResponse, error) {
 	if userID == "" {
 		return nil, errors.New("post blocking: userID parameter is required")
 	}
-	ep := fmt.Sprintf(postBlockingURL, userID)
+	ep := c.baseURL + fmt.Sprintf(postBlockingURL, userID)
 
 	if targetUserID == "" {
 		return nil, errors.New("post blocking: targetUserID parameter is required")
@@ -70,28 +52,9 @@ func postBlocking(ctx context.Context, c *client, userID string, targetUserID st
 	if err != nil {
 		return nil, fmt.Errorf("post blocking new request with ctx: %w", err)
 	}
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.bearerToken))
 	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := c.client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("post blocking response: %w", err)
-	}
-	defer func() { _ = resp.Body.Close() }()
-
-	var postBlocking PostBlockingResponse
-	if err := json.NewDecoder(resp.Body).Decode(&postBlocking); err != nil {
-		return nil, fmt.Errorf("post blocking decode: %w", err)
-	}
-	if resp.StatusCode != http.StatusOK {
-		return &postBlocking, &HTTPError{
-			APIName: "post blocking",
-			Status:  resp.Status,
-			URL:     req.URL.String(),
-		}
-	}
-
-	return &postBlocking, nil
+	return doJSON[PostBlockingResponse](c, req, "post blocking", http.StatusOK)
 }
 
 func undoBlocking(ctx context.Context, c *client, sourceUserID string, targetUserID string) (*UndoBlockingResponse, error) {
@@ -101,31 +64,12 @@ func undoBlocking(ctx context.Context, c *client, sourceUserID string, targetUse
 	if targetUserID == "" {
 		return nil, errors.New("undo blocking: targetUserID parameter is required")
 	}
-	ep := fmt.Sprintf(undoBlockingURL, sourceUserID, targetUserID)
+	ep := c.baseURL + fmt.Sprintf(undoBlockingURL, sourceUserID, targetUserID)
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, ep, nil)
 	if err != nil {
 		return nil, fmt.Errorf("undo blocking new request with ctx: %w", err)
 	}
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.bearerToken))
-
-	resp, err := c.client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("undo blocking response: %w", err)
-	}
-	defer func() { _ = resp.Body.Close() }()
-
-	var undoBlocking UndoBlockingResponse
-	if err := json.NewDecoder(resp.Body).Decode(&undoBlocking); err != nil {
-		return nil, fmt.Errorf("undo blocking decode: %w", err)
-	}
-	if resp.StatusCode != http.StatusOK {
-		return &undoBlocking, &HTTPError{
-			APIName: "undo blocking",
-			Status:  resp.Status,
-			URL:     req.URL.String(),
-		}
-	}
 
-	return &undoBlocking, nil
+	return doJSON[UndoBlockingResponse](c, req, "undo blocking", http.StatusOK)
 }
@@ -13,13 +13,12 @@ func lookupUserBookmarks(ctx context.Context, c *client, userID string, opt ...*
 	if userID == "" {
 		return nil, errors.New("lookup user bookmarks: user id parameter is required")
 	}
-	ep := fmt.Sprintf(lookupUserBookmarksURL, userID)
+	ep := c.baseURL + fmt.Sprintf(lookupUserBookmarksURL, userID)
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ep, nil)
 	if err != nil {
 		return nil, fmt.Errorf("lookup user bookmarks new request with ctx: %w", err)
 	}
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.bearerToken))
 
 	var lopt LookupUserBookmarksOption
 	switch len(opt) {
@@ -32,25 +31,7 @@ func lookupUserBookmarks(ctx context.Context, c *client, userID string, opt ...*
 	}
 	lopt.addQuery(req)
 
-	resp, err := c.client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("lookup user bookmarks response: %w", err)
-	}
-	defer func() { _ = resp.Body.Close() }()
-
-	var lookupUserBookmarks LookupUserBookmarksResponse
-	if err := json.NewDecoder(resp.Body).Decode(&lookupUserBookmarks); err != nil {
-		return nil, fmt.Errorf("lookup user bookmarks decode: %w", err)
-	}
-	if resp.StatusCode != http.StatusOK {
-		return &lookupUserBookmarks, &HTTPError{
-			APIName: "lookup user bookmarks",
-			Status:  resp.Status,
-			URL:     req.URL.String(),
-		}
-	}
-
-	return &lookupUserBookmarks, nil
+	return doJSON[LookupUserBookmarksResponse](c, req, "lookup user bookmarks", http.StatusOK)
 }
 
 func bookmarkTweet(ctx context.Context, c *client, userID string, body *BookmarkTweetBody) (*BookmarkTweetResponse, error) {
@@ -60,7 +41,7 @@ func bookmarkTweet(ctx context.Context, c *client, userID string, body *Bookmark
 	if body.TweetID == "" {
 		return nil, errors.New("bookmark tweet: tweet id parameter is required")
 	}
-	ep := fmt.Sprintf(bookmarkTweetURL, userID)
+	ep := c.baseURL + fmt.Sprintf(bookmarkTweetURL, userID)
 
 	j, err := json.Marshal(body)
 	if err != nil {
@@ -71,28 +52,9 @@ func bookmarkTweet(ctx context.Context, c *client, userID string, body *Bookmark
 	if err != nil {
 		return nil, fmt.Errorf("bookmark tweet new request with ctx: %w", err)
 	}
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.bearerToken))
 	req.Header.Set("Content-type", "application/json")
 
-	resp, err := c.client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("bookmark tweet response: %w", err)
-	}
-	defer func() { _ = resp.Body.Close() }()
-
-	var bookmarkTweet BookmarkTweetResponse
-	if err := json.NewDecoder(resp.Body).Decode(&bookmarkTweet); err != nil {
-		return nil, fmt.Errorf("bookmark tweet decode: %w", err)
-	}
-	if resp.StatusCode != http.StatusOK {
-		return &bookmarkTweet, &HTTPError{
-			APIName: "bookmark tweet",
-			Status:  resp.Status,
-			URL:     req.URL.String(),
-		}
-	}
-
-	return &bookmarkTweet, nil
+	return doJSON[BookmarkTweetResponse](c, req, "bookmark tweet", http.StatusOK)
 }
 
 func removeBookmarkOfTweet(ctx context.Context, c *client, userID string, tweetID string) (*RemoveBookmarkOfTweetResponse, error) {
@@ -102,31 +64,12 @@ func removeBookmarkOfTweet(ctx context.Context, c *client, userID string, tweetI
 	if tweetID == "" {
 		return nil, errors.New("remove bookmark of tweet: tweet id parameter is required")
 	}
-	ep := fmt.Sprintf(removeBookmarkOfTweetURL, userID, tweetID)
+	ep := c.baseURL + fmt.Sprintf(removeBookmarkOfTweetURL, userID, tweetID)
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, ep, nil)
 	if err != nil {
 		return nil, fmt.Errorf("remove bookmark of tweet new request with ctx: %w", err)
 	}
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.bearerToken))
-
-	resp, err := c.client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("remove bookmark of tweet response: %w", err)
-	}
-	defer func() { _ = resp.Body.Close() }()
-
-	var removeBookmarkOfTweet RemoveBookmarkOfTweetResponse
-	if err := json.NewDecoder(resp.Body).Decode(&removeBookmarkOfTweet); err != nil {
-		return nil, fmt.Errorf("remove bookmark of tweet decode: %w", err)
-	}
-	if resp.StatusCode != http.StatusOK {
-		return &removeBookmarkOfTweet, &HTTPError{
-			APIName: "remove bookmark of tweet",
-			Status:  resp.Status,
-			URL:     req.URL.String(),
-		}
-	}
 
-	return &removeBookmarkOfTweet, nil
+	return doJSON[RemoveBookmarkOfTweetResponse](c, req, "remove bookmark of tweet", http.StatusOK)
 }
@@ -0,0 +1,267 @@
+package gotwtr
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const defaultBulkConcurrency = 4
+
+// bulk splits the ids into batches of size, fetches them with at most concurrency batches in flight,
+// and returns the response of each batch in order.
+// Duplicate ids, as identified by key, are requested once. The first error cancels the batches not started yet,
+// while the batches in flight run to completion, and is returned along with the responses fetched so far.
+func bulk[R any](ctx context.Context, ids []string, key func(string) string, size, concurrency int, fetch func(ctx context.Context, batch []string) (*R, error)) ([]*R, error) {
+	ids = dedupe(ids, key)
+	if concurrency <= 0 {
+		concurrency = defaultBulkConcurrency
+	}
+	var batches [][]string
+	for len(ids) > 0 {
+		n := min(size, len(ids))
+		batches = append(batches, ids[:n])
+		ids = ids[n:]
+	}
+
+	// stop only stops starting batches; the batches in flight use ctx.
+	stop, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+		sem      = make(chan struct{}, concurrency)
+		res      = make([]*R, len(batches))
+	)
+	for i, batch := range batches {
+		select {
+		case sem <- struct{}{}:
+		case <-stop.Done():
+		}
+		if err := stop.Err(); err != nil {
+			mu.Lock()
+			if firstErr == nil {
+				firstErr = err
+			}
+			mu.Unlock()
+			break
+		}
+		wg.Add(1)
+		go func(i int, batch []string) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			r, err := fetch(ctx, batch)
+			mu.Lock()
+			defer mu.Unlock()
+			res[i] = r
+			if err != nil && firstErr == nil {
+				firstErr = err
+				cancel()
+			}
+		}(i, batch)
+	}
+	wg.Wait()
+	return res, firstErr
+}
+
+func dedupe(ids []string, key func(string) string) []string {
+	seen := make(map[string]struct{}, len(ids))
+	out := make([]string, 0, len(ids))
+	for _, id := range ids {
+		k := key(id)
+		if _, ok := seen[k]; ok {
+			continue
+		}
+		seen[k] = struct{}{}
+		out = append(out, id)
+	}
+	return out
+}
+
+// sortByInput orders the items by the position of their key in ids. Items with unknown keys go last.
+func sortByInput[T any](items []T, ids []string, key func(T) string) {
+	pos := make(map[string]int, len(ids))
+	for i, id := range ids {
+		if _, ok := pos[id]; !ok {
+			pos[id] = i
+		}
+	}
+	at := func(item T) int {
+		if p, ok := pos[key(item)]; ok {
+			return p
+		}
+		return len(ids)
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		return at(items[i]) < at(items[j])
+	})
+}
+
+// appendUnique appends the items of src not yet in dst, identified by key.
+func appendUnique[T any](dst, src []T, key func(T) string) []T {
+	seen := make(map[string]struct{}, len(dst))
+	for _, item := range dst {
+		seen[key(item)] = struct{}{}
+	}
+	for _, item := range src {
+		k := key(item)
+		if _, ok := seen[k]; ok {
+			continue
+		}
+		seen[k] = struct{}{}
+		dst = append(dst, item)
+	}
+	return dst
+}
+
+func sameID(id string) string   { return id }
+func tweetID(t *Tweet) string   { return t.ID }
+func userID(u *User) string     { return u.ID }
+func spaceID(s *Space) string   { return s.ID }
+func mediaKey(m *Media) string  { return m.MediaKey }
+func placeID(p *Place) string   { return p.ID }
+func pollID(p *Poll) string     { return p.ID }
+func topicID(t *Topic) string   { return t.ID }
+func userName(u *User) string   { return strings.ToLower(u.UserName) }
+func creatorID(s *Space) string { return s.CreatorID }
+
+func mergeTweetIncludes(dst, src *TweetIncludes) *TweetIncludes {
+	if src == nil {
+		return dst
+	}
+	if dst == nil {
+		dst = &TweetIncludes{}
+	}
+	dst.Media = appendUnique(dst.Media, src.Media, mediaKey)
+	dst.Places = appendUnique(dst.Places, src.Places, placeID)
+	dst.Polls = appendUnique(dst.Polls, src.Polls, pollID)
+	dst.Tweets = appendUnique(dst.Tweets, src.Tweets, tweetID)
+	dst.Users = appendUnique(dst.Users, src.Users, userID)
+	return dst
+}
+
+func mergeUserIncludes(dst, src *UserIncludes) *UserIncludes {
+	if src == nil {
+		return dst
+	}
+	if dst == nil {
+		dst = &UserIncludes{}
+	}
+	dst.Users = appendUnique(dst.Users, src.Users, userID)
+	dst.Tweets = appendUnique(dst.Tweets, src.Tweets, tweetID)
+	return dst
+}
+
+func mergeSpaceIncludes(dst, src *SpaceIncludes) *SpaceIncludes {
+	if src == nil {
+		return dst
+	}
+	if dst == nil {
+		dst = &SpaceIncludes{}
+	}
+	dst.Topics = appendUnique(dst.Topics, src.Topics, topicID)
+	dst.Users = appendUnique(dst.Users, src.Users, userID)
+	return dst
+}
+
+// BulkRetrieveMultipleTweets looks up any number of Tweets by ID, in batches of 100 with at most concurrency
+// batches in flight, 4 if concurrency is 0 or less. The data, includes and errors of the batches are merged,
+// and the data are in the order of tweetIDs.
+// If a batch fails, the batches not started yet are canceled, the batches in flight complete,
+// and the merged response is returned along with the error.
+func BulkRetrieveMultipleTweets(ctx context.Context, c Tweets, tweetIDs []string, concurrency int, opt ...*RetriveTweetOption) (*TweetsResponse, error) {
+	res, err := bulk(ctx, tweetIDs, sameID, tweetLookUpMaxIDs, concurrency, func(ctx context.Context, batch []string) (*TweetsResponse, error) {
+		return c.RetrieveMultipleTweets(ctx, batch, opt...)
+	})
+	merged := &TweetsResponse{}
+	for _, r := range res {
+		if r == nil {
+			continue
+		}
+		merged.Tweets = append(merged.Tweets, r.Tweets...)
+		merged.Includes = mergeTweetIncludes(merged.Includes, r.Includes)
+		merged.Errors = append(merged.Errors, r.Errors...)
+	}
+	sortByInput(merged.Tweets, tweetIDs, tweetID)
+	return merged, err
+}
+
+// BulkRetrieveMultipleUsersWithIDs looks up any number of users by ID like BulkRetrieveMultipleTweets.
+func BulkRetrieveMultipleUsersWithIDs(ctx context.Context, c Users, userIDs []string, concurrency int, opt ...*RetrieveUserOption) (*UsersResponse, error) {
+	res, err := bulk(ctx, userIDs, sameID, userLookUpMaxIDs, concurrency, func(ctx context.Context, batch []string) (*UsersResponse, error) {
+		return c.RetrieveMultipleUsersWithIDs(ctx, batch, opt...)
+	})
+	merged := mergeUsers(res)
+	sortByInput(merged.Users, userIDs, userID)
+	return merged, err
+}
+
+// BulkRetrieveMultipleUsersWithUserNames looks up any number of users by user name like BulkRetrieveMultipleTweets.
+// User names are matched and deduplicated case-insensitively.
+func BulkRetrieveMultipleUsersWithUserNames(ctx context.Context, c Users, userNames []string, concurrency int, opt ...*RetrieveUserOption) (*UsersResponse, error) {
+	res, err := bulk(ctx, userNames, strings.ToLower, userLookUpMaxIDs, concurrency, func(ctx context.Context, batch []string) (*UsersResponse, error) {
+		return c.RetrieveMultipleUsersWithUserNames(ctx, batch, opt...)
+	})
+	merged := mergeUsers(res)
+	lower := make([]string, len(userNames))
+	for i, n := range userNames {
+		lower[i] = strings.ToLower(n)
+	}
+	sortByInput(merged.Users, lower, userName)
+	return merged, err
+}
+
+func mergeUsers(res []*UsersResponse) *UsersResponse {
+	merged := &UsersResponse{}
+	for _, r := range res {
+		if r == nil {
+			continue
+		}
+		merged.Users = append(merged.Users, r.Users...)
+		merged.Includes = mergeUserIncludes(merged.Includes, r.Includes)
+		merged.Errors = append(merged.Errors, r.Errors...)
+	}
+	return merged
+}
+
+// BulkLookUpSpaces looks up any number of Spaces by ID like BulkRetrieveMultipleTweets.
+func BulkLookUpSpaces(ctx context.Context, c Spaces, spaceIDs []string, concurrency int, opt ...*SpaceOption) (*SpacesResponse, error) {
+	res, err := bulk(ctx, spaceIDs, sameID, spaceLookUpMaxIDs, concurrency, func(ctx context.Context, batch []string) (*SpacesResponse, error) {
+		return c.LookUpSpaces(ctx, batch, opt...)
+	})
+	merged := &SpacesResponse{}
+	for _, r := range res {
+		if r == nil {
+			continue
+		}
+		merged.Spaces = append(merged.Spaces, r.Spaces...)
+		merged.Includes = mergeSpaceIncludes(merged.Includes, r.Includes)
+		merged.Errors = append(merged.Errors, r.Errors...)
+	}
+	sortByInput(merged.Spaces, spaceIDs, spaceID)
+	return merged, err
+}
+
+// BulkDiscoverSpaces looks up the Spaces created by any number of users like BulkRetrieveMultipleTweets.
+// The data are in the order of the creators in userIDs.
+func BulkDiscoverSpaces(ctx context.Context, c Spaces, userIDs []string, concurrency int, opt ...*DiscoverSpacesOption) (*DiscoverSpacesResponse, error) {
+	res, err := bulk(ctx, userIDs, sameID, discoverSpacesMaxIDs, concurrency, func(ctx context.Context, batch []string) (*DiscoverSpacesResponse, error) {
+		return c.DiscoverSpaces(ctx, batch, opt...)
+	})
+	merged := &DiscoverSpacesResponse{}
+	for _, r := range res {
+		if r == nil {
+			continue
+		}
+		merged.Spaces = append(merged.Spaces, r.Spaces...)
+		merged.Includes = mergeSpaceIncludes(merged.Includes, r.Includes)
+		merged.Errors = append(merged.Errors, r.Errors...)
+	}
+	sortByInput(merged.Spaces, userIDs, creatorID)
+	merged.Meta = &DiscoverSpacesMeta{ResultCount: len(merged.Spaces)}
+	return merged, err
+}
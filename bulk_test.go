@@ -0,0 +1,184 @@
+package gotwtr_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sivchari/gotwtr"
+)
+
+func Test_BulkRetrieveMultipleTweets(t *testing.T) {
+	t.Parallel()
+	var (
+		requests, inFlight, maxInFlight int32
+	)
+	client := mockHTTPClient(func(req *http.Request) *http.Response {
+		atomic.AddInt32(&requests, 1)
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			m := atomic.LoadInt32(&maxInFlight)
+			if n <= m || atomic.CompareAndSwapInt32(&maxInFlight, m, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+
+		ids := strings.Split(req.URL.Query().Get("ids"), ",")
+		if len(ids) > 100 {
+			t.Errorf("batch of %d ids", len(ids))
+		}
+		var res struct {
+			Data     []map[string]string            `json:"data"`
+			Includes map[string][]map[string]string `json:"includes"`
+			Errors   []map[string]string            `json:"errors,omitempty"`
+		}
+		// Answer in reverse order to check the merged data follow the input order.
+		for i := len(ids) - 1; i >= 0; i-- {
+			if ids[i] == "missing" {
+				res.Errors = append(res.Errors, map[string]string{"value": "missing", "resource_id": "missing", "type": "https://api.twitter.com/2/problems/resource-not-found"})
+				continue
+			}
+			res.Data = append(res.Data, map[string]string{"id": ids[i], "text": "tweet " + ids[i], "author_id": "author"})
+		}
+		res.Includes = map[string][]map[string]string{"users": {{"id": "author", "username": "author"}}}
+		b, _ := json.Marshal(res)
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(strings.NewReader(string(b))),
+		}
+	})
+	c := gotwtr.New("key", gotwtr.WithHTTPClient(client))
+
+	var ids []string
+	for i := 0; i < 250; i++ {
+		ids = append(ids, fmt.Sprint(i))
+	}
+	ids = append(ids, "missing", "0")
+
+	got, err := gotwtr.BulkRetrieveMultipleTweets(context.Background(), c, ids, 2)
+	if err != nil {
+		t.Fatalf("BulkRetrieveMultipleTweets() error = %v", err)
+	}
+	if requests != 3 {
+		t.Errorf("requests = %v, want 3", requests)
+	}
+	if maxInFlight > 2 {
+		t.Errorf("max in flight = %v, want at most 2", maxInFlight)
+	}
+	if len(got.Tweets) != 250 {
+		t.Fatalf("len(Tweets) = %v, want 250", len(got.Tweets))
+	}
+	for i, tw := range got.Tweets {
+		if tw.ID != fmt.Sprint(i) {
+			t.Fatalf("Tweets[%d].ID = %v, want %v", i, tw.ID, i)
+		}
+	}
+	if got.Includes == nil || len(got.Includes.Users) != 1 {
+		t.Errorf("Includes = %+v, want the author once", got.Includes)
+	}
+	if got.MissingIDs()["missing"] != gotwtr.ResourceDeleted {
+		t.Errorf("MissingIDs() = %v", got.MissingIDs())
+	}
+}
+
+func Test_BulkRetrieveMultipleUsersWithUserNames_error(t *testing.T) {
+	t.Parallel()
+	var requests int32
+	client := mockHTTPClient(func(req *http.Request) *http.Response {
+		if atomic.AddInt32(&requests, 1) == 1 {
+			return &http.Response{
+				StatusCode: http.StatusServiceUnavailable,
+				Body:       io.NopCloser(strings.NewReader(`{"title":"Service Unavailable","type":"about:blank","status":503}`)),
+			}
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(strings.NewReader(`{"data":[{"id":"1","name":"gotwtr","username":"GoTwtr"}]}`)),
+		}
+	})
+	c := gotwtr.New("key", gotwtr.WithHTTPClient(client))
+	names := make([]string, 0, 1000)
+	for i := 0; i < 1000; i++ {
+		names = append(names, fmt.Sprintf("user%d", i))
+	}
+	got, err := gotwtr.BulkRetrieveMultipleUsersWithUserNames(context.Background(), c, names, 1)
+	if !errors.Is(err, gotwtr.ErrServerError) {
+		t.Errorf("BulkRetrieveMultipleUsersWithUserNames() error = %v, want ErrServerError", err)
+	}
+	if got == nil {
+		t.Fatal("BulkRetrieveMultipleUsersWithUserNames() must return the merged response along with the error")
+	}
+	// The failed batch cancels the batches not started yet.
+	if requests != 1 {
+		t.Errorf("requests = %v, want 1", requests)
+	}
+}
+
+func Test_BulkRetrieveMultipleUsersWithUserNames_inFlight(t *testing.T) {
+	t.Parallel()
+	failed := make(chan struct{})
+	client := mockHTTPClient(func(req *http.Request) *http.Response {
+		names := strings.Split(req.URL.Query().Get("usernames"), ",")
+		if names[0] == "user0" {
+			defer close(failed)
+			return &http.Response{
+				StatusCode: http.StatusServiceUnavailable,
+				Body:       io.NopCloser(strings.NewReader(`{"title":"Service Unavailable","type":"about:blank","status":503}`)),
+			}
+		}
+		// The second batch is in flight when the first one fails, and completes.
+		<-failed
+		time.Sleep(10 * time.Millisecond)
+		if err := req.Context().Err(); err != nil {
+			t.Errorf("in-flight batch context error = %v", err)
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(strings.NewReader(`{"data":[{"id":"100","name":"gotwtr","username":"user100"}]}`)),
+		}
+	})
+	c := gotwtr.New("key", gotwtr.WithHTTPClient(client))
+	names := make([]string, 0, 300)
+	for i := 0; i < 300; i++ {
+		names = append(names, fmt.Sprintf("user%d", i))
+	}
+	got, err := gotwtr.BulkRetrieveMultipleUsersWithUserNames(context.Background(), c, names, 2)
+	if !errors.Is(err, gotwtr.ErrServerError) {
+		t.Errorf("BulkRetrieveMultipleUsersWithUserNames() error = %v, want ErrServerError", err)
+	}
+	if got == nil || len(got.Users) != 1 || got.Users[0].ID != "100" {
+		t.Errorf("BulkRetrieveMultipleUsersWithUserNames() = %+v, want the user of the in-flight batch", got)
+	}
+}
+
+func Test_BulkRetrieveMultipleUsersWithUserNames_dedupe(t *testing.T) {
+	t.Parallel()
+	var requested []string
+	client := mockHTTPClient(func(req *http.Request) *http.Response {
+		requested = append(requested, req.URL.Query().Get("usernames"))
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(strings.NewReader(`{"data":[{"id":"1","name":"gotwtr","username":"GoTwtr"},{"id":"2","name":"sivchari","username":"sivchari"}]}`)),
+		}
+	})
+	c := gotwtr.New("key", gotwtr.WithHTTPClient(client))
+	got, err := gotwtr.BulkRetrieveMultipleUsersWithUserNames(context.Background(), c, []string{"sivchari", "gotwtr", "GoTwtr", "GOTWTR"}, 1)
+	if err != nil {
+		t.Fatalf("BulkRetrieveMultipleUsersWithUserNames() error = %v", err)
+	}
+	if len(requested) != 1 || requested[0] != "sivchari,gotwtr" {
+		t.Errorf("requested usernames = %v, want [sivchari,gotwtr]", requested)
+	}
+	if len(got.Users) != 2 || got.Users[0].ID != "2" || got.Users[1].ID != "1" {
+		t.Errorf("Users = %+v, want sivchari and gotwtr in input order", got.Users)
+	}
+}
@@ -0,0 +1,296 @@
+package gotwtr
+
+import (
+	"bytes"
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Cache stores the responses of GET requests. Implementations must be safe for concurrent use.
+// Entries are tagged with the resources they contain, so that writes can invalidate them.
+type Cache interface {
+	// Get returns the entry of the key, if it is present and not expired.
+	Get(key string) ([]byte, bool)
+	// Set stores the entry of the key with the tags for ttl.
+	Set(key string, value []byte, tags []string, ttl time.Duration)
+	// Invalidate removes every entry with one of the tags.
+	Invalidate(tags ...string)
+}
+
+// WithCache makes the client cache the successful responses of GET requests for ttl.
+// Entries are keyed by the method, URL and credentials of the request, and streams are never cached.
+// A successful write such as DeleteTweet invalidates the cached responses about the resources it changed.
+func WithCache(cache Cache, ttl time.Duration) ClientOption {
+	return func(c *client) {
+		c.cache = cache
+		c.cacheTTL = ttl
+	}
+}
+
+// CacheStatus is how the cache of the client served an operation.
+type CacheStatus int
+
+const (
+	// CacheBypass means the operation was not cacheable, or the client has no cache.
+	CacheBypass CacheStatus = iota
+	// CacheMiss means the response was fetched from the API.
+	CacheMiss
+	// CacheHit means the response was served from the cache.
+	CacheHit
+)
+
+func (s CacheStatus) String() string {
+	switch s {
+	case CacheMiss:
+		return "miss"
+	case CacheHit:
+		return "hit"
+	default:
+		return "bypass"
+	}
+}
+
+type cacheStatusKey struct{}
+
+func withCacheStatus(ctx context.Context) context.Context {
+	var s CacheStatus
+	return context.WithValue(ctx, cacheStatusKey{}, &s)
+}
+
+func setCacheStatus(ctx context.Context, s CacheStatus) {
+	if p, ok := ctx.Value(cacheStatusKey{}).(*CacheStatus); ok {
+		*p = s
+	}
+}
+
+// CacheStatusFromContext returns how the cache served the operation of ctx.
+// A middleware calls it with its ctx after invoking the operation.
+func CacheStatusFromContext(ctx context.Context) CacheStatus {
+	if p, ok := ctx.Value(cacheStatusKey{}).(*CacheStatus); ok {
+		return *p
+	}
+	return CacheBypass
+}
+
+// cachedResponse is the encoding of a response stored in the cache.
+type cachedResponse struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header"`
+	Body       []byte      `json:"body"`
+}
+
+// cached performs the operation apiName through the cache of the client.
+func (c *client) cached(req *http.Request, apiName string) (*http.Response, error) {
+	if c.cache == nil {
+		return c.do(req, apiName)
+	}
+	if req.Method != http.MethodGet {
+		resp, err := c.do(req, apiName)
+		if err == nil && successful(resp.StatusCode) {
+			c.cache.Invalidate(cacheTags(req.URL)...)
+		}
+		return resp, err
+	}
+
+	ctx := req.Context()
+	key, err := c.cacheKey(req)
+	if err != nil {
+		return c.do(req, apiName)
+	}
+	if b, ok := c.cache.Get(key); ok {
+		var cr cachedResponse
+		if err := json.Unmarshal(b, &cr); err == nil {
+			setCacheStatus(ctx, CacheHit)
+			return &http.Response{
+				Status:     http.StatusText(cr.StatusCode),
+				StatusCode: cr.StatusCode,
+				Header:     cr.Header,
+				Body:       io.NopCloser(bytes.NewReader(cr.Body)),
+				Request:    req,
+			}, nil
+		}
+	}
+	setCacheStatus(ctx, CacheMiss)
+	resp, err := c.do(req, apiName)
+	if err != nil || resp.StatusCode != http.StatusOK {
+		return resp, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	b, err := json.Marshal(cachedResponse{
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header,
+		Body:       body,
+	})
+	if err == nil {
+		c.cache.Set(key, b, cacheTags(req.URL), c.cacheTTL)
+	}
+	return resp, nil
+}
+
+// cacheKey returns the key of req, which includes a hash of its credentials so that
+// responses fetched with one token are never served to another.
+func (c *client) cacheKey(req *http.Request) (string, error) {
+	var identity string
+	switch {
+	case c.oauth1 != nil:
+		identity = "oauth1:" + c.consumerKey + ":" + c.oauth1.token
+	default:
+		tok, err := c.tokenSource.Token(req.Context())
+		if err != nil {
+			return "", err
+		}
+		if tok != nil {
+			identity = "bearer:" + tok.AccessToken
+		}
+	}
+	sum := sha256.Sum256([]byte(identity))
+	return req.Method + " " + req.URL.String() + " " + hex.EncodeToString(sum[:8]), nil
+}
+
+// cacheTags returns the resources a request is about: its path, and every ID in its path or ids query.
+// A GET response is tagged with them, and a write invalidates them.
+func cacheTags(u *url.URL) []string {
+	tags := []string{"path:" + u.Path}
+	for _, seg := range strings.Split(u.Path, "/") {
+		if isID(seg) {
+			tags = append(tags, "id:"+seg)
+		}
+	}
+	for _, id := range strings.Split(u.Query().Get("ids"), ",") {
+		if isID(id) {
+			tags = append(tags, "id:"+id)
+		}
+	}
+	return tags
+}
+
+// isID reports whether the path segment s is a resource ID, such as a Tweet ID or a Space ID,
+// rather than a word of the endpoint or its version.
+func isID(s string) bool {
+	if s == "" || s == "2" || !strings.ContainsAny(s, "0123456789") {
+		return false
+	}
+	for _, r := range s {
+		if !('0' <= r && r <= '9') && !('a' <= r && r <= 'z') && !('A' <= r && r <= 'Z') {
+			return false
+		}
+	}
+	return true
+}
+
+// LRUCache is an in-memory Cache evicting the least recently used entry beyond its capacity.
+type LRUCache struct {
+	mu       sync.Mutex
+	capacity int
+	ll       *list.List
+	entries  map[string]*list.Element
+	tags     map[string]map[string]struct{}
+}
+
+type lruEntry struct {
+	key     string
+	value   []byte
+	tags    []string
+	expires time.Time
+}
+
+// NewLRUCache returns an LRUCache holding at most capacity entries.
+func NewLRUCache(capacity int) *LRUCache {
+	return &LRUCache{
+		capacity: capacity,
+		ll:       list.New(),
+		entries:  make(map[string]*list.Element),
+		tags:     make(map[string]map[string]struct{}),
+	}
+}
+
+// Get returns the entry of the key.
+func (l *LRUCache) Get(key string) ([]byte, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	el, ok := l.entries[key]
+	if !ok {
+		return nil, false
+	}
+	e, _ := el.Value.(*lruEntry)
+	if time.Now().After(e.expires) {
+		l.remove(el)
+		return nil, false
+	}
+	l.ll.MoveToFront(el)
+	return e.value, true
+}
+
+// Set stores the entry of the key.
+func (l *LRUCache) Set(key string, value []byte, tags []string, ttl time.Duration) {
+	if ttl <= 0 || l.capacity <= 0 {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if el, ok := l.entries[key]; ok {
+		l.remove(el)
+	}
+	e := &lruEntry{
+		key:     key,
+		value:   value,
+		tags:    tags,
+		expires: time.Now().Add(ttl),
+	}
+	l.entries[key] = l.ll.PushFront(e)
+	for _, t := range tags {
+		if l.tags[t] == nil {
+			l.tags[t] = make(map[string]struct{})
+		}
+		l.tags[t][key] = struct{}{}
+	}
+	for l.ll.Len() > l.capacity {
+		l.remove(l.ll.Back())
+	}
+}
+
+// Invalidate removes every entry with one of the tags.
+func (l *LRUCache) Invalidate(tags ...string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, t := range tags {
+		for key := range l.tags[t] {
+			if el, ok := l.entries[key]; ok {
+				l.remove(el)
+			}
+		}
+	}
+}
+
+// Len returns the number of entries, including expired ones not evicted yet.
+func (l *LRUCache) Len() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.ll.Len()
+}
+
+func (l *LRUCache) remove(el *list.Element) {
+	e, _ := el.Value.(*lruEntry)
+	l.ll.Remove(el)
+	delete(l.entries, e.key)
+	for _, t := range e.tags {
+		delete(l.tags[t], e.key)
+		if len(l.tags[t]) == 0 {
+			delete(l.tags, t)
+		}
+	}
+}
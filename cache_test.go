@@ -0,0 +1,191 @@
+package gotwtr_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sivchari/gotwtr"
+)
+
+func Test_WithCache(t *testing.T) {
+	t.Parallel()
+	type call struct {
+		token string
+		op    func(ctx context.Context, c *gotwtr.Client) error
+	}
+	retrieve := func(id string) func(ctx context.Context, c *gotwtr.Client) error {
+		return func(ctx context.Context, c *gotwtr.Client) error {
+			_, err := c.RetrieveSingleTweet(ctx, id)
+			return err
+		}
+	}
+	lookup := func(ids ...string) func(ctx context.Context, c *gotwtr.Client) error {
+		return func(ctx context.Context, c *gotwtr.Client) error {
+			_, err := c.RetrieveMultipleTweets(ctx, ids)
+			return err
+		}
+	}
+	del := func(id string) func(ctx context.Context, c *gotwtr.Client) error {
+		return func(ctx context.Context, c *gotwtr.Client) error {
+			_, err := c.DeleteTweet(ctx, id)
+			return err
+		}
+	}
+	tests := []struct {
+		name         string
+		calls        []call
+		wantStatuses []string
+		wantRequests int32
+	}{
+		{
+			name:         "repeated read is served from the cache",
+			calls:        []call{{"a", retrieve("1")}, {"a", retrieve("1")}},
+			wantStatuses: []string{"miss", "hit"},
+			wantRequests: 1,
+		},
+		{
+			name:         "different tokens do not share entries",
+			calls:        []call{{"a", retrieve("1")}, {"b", retrieve("1")}, {"a", retrieve("1")}},
+			wantStatuses: []string{"miss", "miss", "hit"},
+			wantRequests: 2,
+		},
+		{
+			name:         "different URLs do not share entries",
+			calls:        []call{{"a", retrieve("1")}, {"a", retrieve("2")}},
+			wantStatuses: []string{"miss", "miss"},
+			wantRequests: 2,
+		},
+		{
+			name:         "delete invalidates the tweet",
+			calls:        []call{{"a", retrieve("1")}, {"a", lookup("1", "2")}, {"a", del("1")}, {"a", retrieve("1")}, {"a", lookup("1", "2")}},
+			wantStatuses: []string{"miss", "miss", "bypass", "miss", "miss"},
+			wantRequests: 5,
+		},
+		{
+			name:         "delete keeps other tweets",
+			calls:        []call{{"a", retrieve("2")}, {"a", del("1")}, {"a", retrieve("2")}},
+			wantStatuses: []string{"miss", "bypass", "hit"},
+			wantRequests: 2,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var requests int32
+			client := mockHTTPClient(func(req *http.Request) *http.Response {
+				atomic.AddInt32(&requests, 1)
+				body := `{"data":{"id":"1","text":"hello"}}`
+				switch {
+				case req.Method == http.MethodDelete:
+					body = `{"data":{"deleted":true}}`
+				case req.URL.Query().Has("ids"):
+					body = `{"data":[{"id":"1","text":"hello"}]}`
+				}
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(strings.NewReader(body)),
+				}
+			})
+			var statuses []string
+			mw := func(ctx context.Context, op string, req *http.Request, invoke gotwtr.Invoker) (any, error) {
+				res, err := invoke(ctx, req)
+				statuses = append(statuses, gotwtr.CacheStatusFromContext(ctx).String())
+				return res, err
+			}
+			cache := gotwtr.NewLRUCache(10)
+			for _, call := range tt.calls {
+				c := gotwtr.New(call.token, gotwtr.WithHTTPClient(client), gotwtr.WithCache(cache, time.Minute), gotwtr.WithMiddleware(mw))
+				if err := call.op(context.Background(), c); err != nil {
+					t.Fatalf("call error = %v", err)
+				}
+			}
+			if diff := cmp.Diff(tt.wantStatuses, statuses); diff != "" {
+				t.Errorf("cache statuses mismatch (-want +got):\n%s", diff)
+			}
+			if got := atomic.LoadInt32(&requests); got != tt.wantRequests {
+				t.Errorf("requests = %d, want %d", got, tt.wantRequests)
+			}
+		})
+	}
+}
+
+func Test_WithCache_unsuccessful(t *testing.T) {
+	t.Parallel()
+	var requests int32
+	client := mockHTTPClient(func(req *http.Request) *http.Response {
+		atomic.AddInt32(&requests, 1)
+		return &http.Response{
+			StatusCode: http.StatusNotFound,
+			Body:       io.NopCloser(strings.NewReader(`{"title":"Not Found Error","type":"https://api.twitter.com/2/problems/resource-not-found"}`)),
+		}
+	})
+	cache := gotwtr.NewLRUCache(10)
+	c := gotwtr.New("key", gotwtr.WithHTTPClient(client), gotwtr.WithCache(cache, time.Minute))
+	for i := 0; i < 2; i++ {
+		if _, err := c.RetrieveSingleTweet(context.Background(), "1"); err == nil {
+			t.Fatal("client.RetrieveSingleTweet() error = nil, want error")
+		}
+	}
+	if got := atomic.LoadInt32(&requests); got != 2 {
+		t.Errorf("requests = %d, want 2", got)
+	}
+	if cache.Len() != 0 {
+		t.Errorf("cache.Len() = %d, want 0", cache.Len())
+	}
+}
+
+func Test_LRUCache(t *testing.T) {
+	t.Parallel()
+	t.Run("evicts the least recently used entry", func(t *testing.T) {
+		t.Parallel()
+		l := gotwtr.NewLRUCache(2)
+		l.Set("a", []byte("a"), nil, time.Minute)
+		l.Set("b", []byte("b"), nil, time.Minute)
+		if _, ok := l.Get("a"); !ok {
+			t.Fatal("Get(a) = false, want true")
+		}
+		l.Set("c", []byte("c"), nil, time.Minute)
+		if _, ok := l.Get("b"); ok {
+			t.Error("Get(b) = true, want evicted")
+		}
+		for _, key := range []string{"a", "c"} {
+			if v, ok := l.Get(key); !ok || string(v) != key {
+				t.Errorf("Get(%s) = %q, %v, want %q, true", key, v, ok, key)
+			}
+		}
+	})
+	t.Run("expires entries", func(t *testing.T) {
+		t.Parallel()
+		l := gotwtr.NewLRUCache(2)
+		l.Set("a", []byte("a"), nil, time.Millisecond)
+		time.Sleep(5 * time.Millisecond)
+		if _, ok := l.Get("a"); ok {
+			t.Error("Get(a) = true, want expired")
+		}
+		if l.Len() != 0 {
+			t.Errorf("Len() = %d, want 0", l.Len())
+		}
+	})
+	t.Run("invalidates tagged entries", func(t *testing.T) {
+		t.Parallel()
+		l := gotwtr.NewLRUCache(3)
+		l.Set("a", []byte("a"), []string{"x"}, time.Minute)
+		l.Set("b", []byte("b"), []string{"x", "y"}, time.Minute)
+		l.Set("c", []byte("c"), []string{"y"}, time.Minute)
+		l.Invalidate("x")
+		if l.Len() != 1 {
+			t.Errorf("Len() = %d, want 1", l.Len())
+		}
+		if _, ok := l.Get("c"); !ok {
+			t.Error("Get(c) = false, want true")
+		}
+	})
+}
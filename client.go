@@ -3,7 +3,10 @@ package gotwtr
 import (
 	"context"
 	"io"
+	"log/slog"
 	"net/http"
+	"strings"
+	"time"
 )
 
 const (
@@ -189,11 +192,33 @@ type Twtr interface {
 	Analytics
 }
 
+const (
+	defaultBaseURL       = "https://api.x.com"
+	defaultUploadBaseURL = "https://api.x.com"
+	defaultOAuthBaseURL  = "https://api.x.com"
+)
+
 type client struct {
-	consumerKey    string
-	consumerSecret string
-	bearerToken    string
-	client         *http.Client
+	consumerKey     string
+	consumerSecret  string
+	bearerToken     *bearerTokenSource
+	client          *http.Client
+	baseURL         string
+	uploadBaseURL   string
+	oauthBaseURL    string
+	rateLimits      *rateLimits
+	waitRateLimit   bool
+	retryPolicy     *RetryPolicy
+	reconnectPolicy *ReconnectPolicy
+	stallTimeout    time.Duration
+	streamEvents    func(StreamEvent)
+	tokenSource     TokenSource
+	oauth1          *oauth1
+	middlewares     []Middleware
+	logger          *slog.Logger
+	logBodies       bool
+	cache           Cache
+	cacheTTL        time.Duration
 }
 
 // Client is an API client for Twitter v2 API.
@@ -223,13 +248,48 @@ func WithHTTPClient(httpClient *http.Client) ClientOption {
 	}
 }
 
+// WithBaseURL sets the base URL used by every v2 API endpoint, e.g. "http://127.0.0.1:8080".
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *client) {
+		c.baseURL = strings.TrimRight(baseURL, "/")
+	}
+}
+
+// WithUploadBaseURL sets the base URL used by the media upload endpoints.
+func WithUploadBaseURL(baseURL string) ClientOption {
+	return func(c *client) {
+		c.uploadBaseURL = strings.TrimRight(baseURL, "/")
+	}
+}
+
+// WithOAuthBaseURL sets the base URL used by the OAuth 2.0 token endpoints.
+func WithOAuthBaseURL(baseURL string) ClientOption {
+	return func(c *client) {
+		c.oauthBaseURL = strings.TrimRight(baseURL, "/")
+	}
+}
+
+// WithWaitOnRateLimit makes the client wait until the rate limit window resets
+// instead of sending a request that would be rejected with 429 Too Many Requests.
+// The remaining quota is tracked per API and shared by all goroutines using the client.
+func WithWaitOnRateLimit() ClientOption {
+	return func(c *client) {
+		c.waitRateLimit = true
+	}
+}
+
 func New(bearerToken string, opts ...ClientOption) *Client {
 	c := &client{
 		consumerKey:    "",
 		consumerSecret: "",
-		bearerToken:    bearerToken,
+		bearerToken:    &bearerTokenSource{token: bearerToken},
 		client:         http.DefaultClient,
+		baseURL:        defaultBaseURL,
+		uploadBaseURL:  defaultUploadBaseURL,
+		oauthBaseURL:   defaultOAuthBaseURL,
+		rateLimits:     newRateLimits(),
 	}
+	c.tokenSource = c.bearerToken
 	for _, opt := range opts {
 		opt(c)
 	}
@@ -238,6 +298,17 @@ func New(bearerToken string, opts ...ClientOption) *Client {
 	}
 }
 
+// RateLimit returns the latest rate limit state reported for the API, e.g. "search recent tweets".
+// The API name is the same one reported by HTTPError.APIName.
+func (c *Client) RateLimit(apiName string) (RateLimit, bool) {
+	return c.rateLimits.get(apiName)
+}
+
+// RateLimits returns the latest rate limit state of every API called by the client.
+func (c *Client) RateLimits() map[string]RateLimit {
+	return c.rateLimits.all()
+}
+
 // GenerateAppOnlyBearerToken generates a bearer token for app-only auth.
 func (c *Client) GenerateAppOnlyBearerToken(ctx context.Context) (bool, error) {
 	return generateAppOnlyBearerToken(ctx, c.client)
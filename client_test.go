@@ -0,0 +1,96 @@
+package gotwtr_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/sivchari/gotwtr"
+)
+
+func Test_WithBaseURL(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		opts []gotwtr.ClientOption
+		call func(c *gotwtr.Client) error
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "default base url",
+			args: args{
+				call: func(c *gotwtr.Client) error {
+					_, err := c.RetrieveSingleTweet(context.Background(), "1")
+					return err
+				},
+			},
+			want: "https://api.x.com/2/tweets/1",
+		},
+		{
+			name: "custom base url",
+			args: args{
+				opts: []gotwtr.ClientOption{gotwtr.WithBaseURL("http://127.0.0.1:8080/")},
+				call: func(c *gotwtr.Client) error {
+					_, err := c.RetrieveSingleTweet(context.Background(), "1")
+					return err
+				},
+			},
+			want: "http://127.0.0.1:8080/2/tweets/1",
+		},
+		{
+			name: "custom upload base url",
+			args: args{
+				opts: []gotwtr.ClientOption{
+					gotwtr.WithBaseURL("http://127.0.0.1:8080"),
+					gotwtr.WithUploadBaseURL("http://127.0.0.1:8081"),
+				},
+				call: func(c *gotwtr.Client) error {
+					_, err := c.UploadMedia(context.Background(), strings.NewReader("media"), "image/png")
+					return err
+				},
+			},
+			want: "http://127.0.0.1:8081/2/media/upload",
+		},
+		{
+			name: "custom oauth base url",
+			args: args{
+				opts: []gotwtr.ClientOption{
+					gotwtr.WithConsumerKey("key"),
+					gotwtr.WithConsumerSecret("secret"),
+					gotwtr.WithOAuthBaseURL("http://127.0.0.1:8082"),
+				},
+				call: func(c *gotwtr.Client) error {
+					_, err := c.GenerateAppOnlyBearerToken(context.Background())
+					return err
+				},
+			},
+			want: "http://127.0.0.1:8082/oauth2/token?grant_type=client_credentials",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var got string
+			client := mockHTTPClient(func(req *http.Request) *http.Response {
+				got = req.URL.String()
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(strings.NewReader(`{}`)),
+				}
+			})
+			c := gotwtr.New("key", append(tt.args.opts, gotwtr.WithHTTPClient(client))...)
+			if err := tt.args.call(c); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("request url = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -10,13 +10,11 @@ import (
 )
 
 func searchPostsEligibleForNotes(ctx context.Context, c *client, opt ...*SearchPostsEligibleForNotesOption) (*SearchPostsEligibleForNotesResponse, error) {
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, searchPostsEligibleForNotesURL, nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+searchPostsEligibleForNotesURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("search posts eligible for notes new request with ctx: %w", err)
 	}
 
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.bearerToken))
-
 	var sopt SearchPostsEligibleForNotesOption
 	switch len(opt) {
 	case 0:
@@ -30,37 +28,15 @@ func searchPostsEligibleForNotes(ctx context.Context, c *client, opt ...*SearchP
 	}
 	sopt.addQuery(req)
 
-	resp, err := c.client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("search posts eligible for notes response: %w", err)
-	}
-
-	defer func() { _ = resp.Body.Close() }()
-
-	var spr SearchPostsEligibleForNotesResponse
-	if err := json.NewDecoder(resp.Body).Decode(&spr); err != nil {
-		return nil, fmt.Errorf("search posts eligible for notes decode: %w", err)
-	}
-
-	if resp.StatusCode != http.StatusOK {
-		return &spr, &HTTPError{
-			APIName: "search posts eligible for notes",
-			Status:  resp.Status,
-			URL:     req.URL.String(),
-		}
-	}
-
-	return &spr, nil
+	return doJSON[SearchPostsEligibleForNotesResponse](c, req, "search posts eligible for notes", http.StatusOK)
 }
 
 func searchNotesWritten(ctx context.Context, c *client, opt ...*SearchNotesWrittenOption) (*SearchNotesWrittenResponse, error) {
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, searchNotesWrittenURL, nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+searchNotesWrittenURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("search notes written new request with ctx: %w", err)
 	}
 
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.bearerToken))
-
 	var sopt SearchNotesWrittenOption
 	switch len(opt) {
 	case 0:
@@ -74,27 +50,7 @@ func searchNotesWritten(ctx context.Context, c *client, opt ...*SearchNotesWritt
 	}
 	sopt.addQuery(req)
 
-	resp, err := c.client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("search notes written response: %w", err)
-	}
-
-	defer func() { _ = resp.Body.Close() }()
-
-	var snr SearchNotesWrittenResponse
-	if err := json.NewDecoder(resp.Body).Decode(&snr); err != nil {
-		return nil, fmt.Errorf("search notes written decode: %w", err)
-	}
-
-	if resp.StatusCode != http.StatusOK {
-		return &snr, &HTTPError{
-			APIName: "search notes written",
-			Status:  resp.Status,
-			URL:     req.URL.String(),
-		}
-	}
-
-	return &snr, nil
+	return doJSON[SearchNotesWrittenResponse](c, req, "search notes written", http.StatusOK)
 }
 
 func createCommunityNote(ctx context.Context, c *client, body *CreateCommunityNoteBody) (*CreateCommunityNoteResponse, error) {
@@ -116,33 +72,12 @@ func createCommunityNote(ctx context.Context, c *client, body *CreateCommunityNo
 		return nil, fmt.Errorf("create community note json marshal: %w", err)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, createCommunityNoteURL, bytes.NewReader(j))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+createCommunityNoteURL, bytes.NewReader(j))
 	if err != nil {
 		return nil, fmt.Errorf("create community note new request with ctx: %w", err)
 	}
 
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.bearerToken))
 	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := c.client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("create community note response: %w", err)
-	}
-
-	defer func() { _ = resp.Body.Close() }()
-
-	var cnr CreateCommunityNoteResponse
-	if err := json.NewDecoder(resp.Body).Decode(&cnr); err != nil {
-		return nil, fmt.Errorf("create community note decode: %w", err)
-	}
-
-	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
-		return &cnr, &HTTPError{
-			APIName: "create community note",
-			Status:  resp.Status,
-			URL:     req.URL.String(),
-		}
-	}
-
-	return &cnr, nil
+	return doJSON[CreateCommunityNoteResponse](c, req, "create community note", http.StatusCreated, http.StatusOK)
 }
\ No newline at end of file
@@ -2,7 +2,6 @@ package gotwtr
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
@@ -12,13 +11,12 @@ func lookUpAllOneToOneDM(ctx context.Context, c *client, participantID string, o
 	if participantID == "" {
 		return nil, errors.New("lookup all one to one DM: participant id parameter is required")
 	}
-	ep := fmt.Sprintf(lookUpAllOneToOneDMURL, participantID)
+	ep := c.baseURL + fmt.Sprintf(lookUpAllOneToOneDMURL, participantID)
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ep, nil)
 	if err != nil {
 		return nil, fmt.Errorf("lookup all one to one DM with ctx: %w", err)
 	}
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.bearerToken))
 
 	var dmopt DirectMessageOption
 	switch len(opt) {
@@ -31,37 +29,19 @@ func lookUpAllOneToOneDM(ctx context.Context, c *client, participantID string, o
 	}
 	dmopt.addQuery(req)
 
-	resp, err := c.client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("lookup all one to one DM response: %w", err)
-	}
-	defer func() { _ = resp.Body.Close() }()
-
-	var lookUpAllOneToOneDM LookUpAllOneToOneDMResponse
-	if err := json.NewDecoder(resp.Body).Decode(&lookUpAllOneToOneDM); err != nil {
-		return nil, fmt.Errorf("lookup all DM decode: %w", err)
-	}
-	if resp.StatusCode != http.StatusOK {
-		return &lookUpAllOneToOneDM, &HTTPError{
-			APIName: "lookup all one to one DM",
-			Status:  resp.Status,
-			URL:     req.URL.String(),
-		}
-	}
-	return &lookUpAllOneToOneDM, nil
+	return doJSON[LookUpAllOneToOneDMResponse](c, req, "lookup all one to one DM", http.StatusOK)
 }
 
 func lookUpDM(ctx context.Context, c *client, dmConversationID string, opt ...*DirectMessageOption) (*LookUpDMResponse, error) {
 	if dmConversationID == "" {
 		return nil, errors.New("lookup DM: dm conversation id parameter is required")
 	}
-	ep := fmt.Sprintf(lookUpDMURL, dmConversationID)
+	ep := c.baseURL + fmt.Sprintf(lookUpDMURL, dmConversationID)
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ep, nil)
 	if err != nil {
 		return nil, fmt.Errorf("lookup DM with ctx: %w", err)
 	}
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.bearerToken))
 
 	var dmopt DirectMessageOption
 	switch len(opt) {
@@ -74,32 +54,14 @@ func lookUpDM(ctx context.Context, c *client, dmConversationID string, opt ...*D
 	}
 	dmopt.addQuery(req)
 
-	resp, err := c.client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("lookup DM response: %w", err)
-	}
-	defer func() { _ = resp.Body.Close() }()
-
-	var lookUpDM LookUpDMResponse
-	if err := json.NewDecoder(resp.Body).Decode(&lookUpDM); err != nil {
-		return nil, fmt.Errorf("lookup DM decode: %w", err)
-	}
-	if resp.StatusCode != http.StatusOK {
-		return &lookUpDM, &HTTPError{
-			APIName: "lookup DM",
-			Status:  resp.Status,
-			URL:     req.URL.String(),
-		}
-	}
-	return &lookUpDM, nil
+	return doJSON[LookUpDMResponse](c, req, "lookup DM", http.StatusOK)
 }
 
 func lookUpAllDM(ctx context.Context, c *client, opt ...*DirectMessageOption) (*LookUpAllDMResponse, error) {
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, lookUpAllDMURL, nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+lookUpAllDMURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("lookup all DM with ctx: %w", err)
 	}
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.bearerToken))
 
 	var dmopt DirectMessageOption
 	switch len(opt) {
@@ -112,22 +74,5 @@ func lookUpAllDM(ctx context.Context, c *client, opt ...*DirectMessageOption) (*
 	}
 	dmopt.addQuery(req)
 
-	resp, err := c.client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("lookup all DM response: %w", err)
-	}
-	defer func() { _ = resp.Body.Close() }()
-
-	var lookUpAllDM LookUpAllDMResponse
-	if err := json.NewDecoder(resp.Body).Decode(&lookUpAllDM); err != nil {
-		return nil, fmt.Errorf("lookup all DM decode: %w", err)
-	}
-	if resp.StatusCode != http.StatusOK {
-		return &lookUpAllDM, &HTTPError{
-			APIName: "lookup all DM",
-			Status:  resp.Status,
-			URL:     req.URL.String(),
-		}
-	}
-	return &lookUpAllDM, nil
+	return doJSON[LookUpAllDMResponse](c, req, "lookup all DM", http.StatusOK)
 }
@@ -2,7 +2,6 @@ package gotwtr
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
@@ -16,7 +15,7 @@ func discoverSpaces(ctx context.Context, c *client, userIDs []string, opt ...*Di
 		return nil, errors.New("discover spaces: ids parameter must be less than or equal to 100")
 	default:
 	}
-	ep := discoverSpacesURL
+	ep := c.baseURL + discoverSpacesURL
 	for i, uid := range userIDs {
 		if i+1 < len(userIDs) {
 			ep += fmt.Sprintf("%s,", uid)
@@ -29,7 +28,6 @@ func discoverSpaces(ctx context.Context, c *client, userIDs []string, opt ...*Di
 	if err != nil {
 		return nil, fmt.Errorf("discover spaces: %w", err)
 	}
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.bearerToken))
 
 	var dopt DiscoverSpacesOption
 	switch len(opt) {
@@ -42,25 +40,5 @@ func discoverSpaces(ctx context.Context, c *client, userIDs []string, opt ...*Di
 	}
 	dopt.addQuery(req)
 
-	resp, err := c.client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("discover spaces: %w", err)
-	}
-	defer func() { _ = resp.Body.Close() }()
-
-	var dsr DiscoverSpacesResponse
-
-	if err := json.NewDecoder(resp.Body).Decode(&dsr); err != nil {
-		return nil, fmt.Errorf("discover spaces: %w", err)
-	}
-
-	if resp.StatusCode != http.StatusOK {
-		return &dsr, &HTTPError{
-			APIName: "discover spaces",
-			Status:  resp.Status,
-			URL:     req.URL.String(),
-		}
-	}
-
-	return &dsr, nil
+	return doJSON[DiscoverSpacesResponse](c, req, "discover spaces", http.StatusOK)
 }
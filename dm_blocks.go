@@ -17,7 +17,7 @@ func postDMBlocking(ctx context.Context, c *client, userID string, targetUserID
 		return nil, errors.New("post dm blocking: targetUserID parameter is required")
 	}
 
-	ep := fmt.Sprintf(postDMBlockingURL, userID)
+	ep := c.baseURL + fmt.Sprintf(postDMBlockingURL, userID)
 
 	body := &DMBlockingBody{
 		TargetUserID: targetUserID,
@@ -33,30 +33,9 @@ func postDMBlocking(ctx context.Context, c *client, userID string, targetUserID
 		return nil, fmt.Errorf("post dm blocking new request with ctx: %w", err)
 	}
 
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.bearerToken))
 	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := c.client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("post dm blocking response: %w", err)
-	}
-
-	defer func() { _ = resp.Body.Close() }()
-
-	var pdbr PostDMBlockingResponse
-	if err := json.NewDecoder(resp.Body).Decode(&pdbr); err != nil {
-		return nil, fmt.Errorf("post dm blocking decode: %w", err)
-	}
-
-	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
-		return &pdbr, &HTTPError{
-			APIName: "post dm blocking",
-			Status:  resp.Status,
-			URL:     req.URL.String(),
-		}
-	}
-
-	return &pdbr, nil
+	return doJSON[PostDMBlockingResponse](c, req, "post dm blocking", http.StatusOK, http.StatusCreated)
 }
 
 func undoDMBlocking(ctx context.Context, c *client, userID string, targetUserID string) (*UndoDMBlockingResponse, error) {
@@ -67,7 +46,7 @@ func undoDMBlocking(ctx context.Context, c *client, userID string, targetUserID
 		return nil, errors.New("undo dm blocking: targetUserID parameter is required")
 	}
 
-	ep := fmt.Sprintf(undoDMBlockingURL, userID)
+	ep := c.baseURL + fmt.Sprintf(undoDMBlockingURL, userID)
 
 	body := &DMBlockingBody{
 		TargetUserID: targetUserID,
@@ -83,28 +62,7 @@ func undoDMBlocking(ctx context.Context, c *client, userID string, targetUserID
 		return nil, fmt.Errorf("undo dm blocking new request with ctx: %w", err)
 	}
 
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.bearerToken))
 	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := c.client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("undo dm blocking response: %w", err)
-	}
-
-	defer func() { _ = resp.Body.Close() }()
-
-	var udbr UndoDMBlockingResponse
-	if err := json.NewDecoder(resp.Body).Decode(&udbr); err != nil {
-		return nil, fmt.Errorf("undo dm blocking decode: %w", err)
-	}
-
-	if resp.StatusCode != http.StatusOK {
-		return &udbr, &HTTPError{
-			APIName: "undo dm blocking",
-			Status:  resp.Status,
-			URL:     req.URL.String(),
-		}
-	}
-
-	return &udbr, nil
+	return doJSON[UndoDMBlockingResponse](c, req, "undo dm blocking", http.StatusOK)
 }
\ No newline at end of file
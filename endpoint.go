@@ -1,156 +1,157 @@
 package gotwtr
 
-// EndpointURL is the base URL for the Twitter V2 API.
+// Endpoint paths are joined with the base URLs configured on the client.
 const (
-	generateAppOnlyBearerTokenURL = "https://api.twitter.com/oauth2/token?grant_type=client_credentials"
-	invalidateTokenURL            = "https://api.twitter.com/oauth2/invalidate_token"
+	generateAppOnlyBearerTokenURL = "/oauth2/token?grant_type=client_credentials"
+	invalidateTokenURL            = "/oauth2/invalidate_token"
+	oauth2TokenURL                = "/2/oauth2/token"
 )
 
 const (
 	// Tweets lookup
-	retrieveMultipleTweetsURL = "https://api.twitter.com/2/tweets?ids="
-	retrieveSingleTweetURL    = "https://api.twitter.com/2/tweets/%v"
+	retrieveMultipleTweetsURL = "/2/tweets?ids="
+	retrieveSingleTweetURL    = "/2/tweets/%v"
 	// Manage Tweets
-	deleteTweetURL = "https://api.twitter.com/2/tweets/%v"
-	postTweetURL   = "https://api.twitter.com/2/tweets"
+	deleteTweetURL = "/2/tweets/%v"
+	postTweetURL   = "/2/tweets"
 	// Timelines
-	userMentionTimelineURL              = "https://api.twitter.com/2/users/%v/mentions"
-	userTweetTimelineURL                = "https://api.twitter.com/2/users/%v/tweets"
-	userReverseChronologicalTimelineURL = "https://api.twitter.com/2/users/%v/timelines/reverse_chronological"
+	userMentionTimelineURL              = "/2/users/%v/mentions"
+	userTweetTimelineURL                = "/2/users/%v/tweets"
+	userReverseChronologicalTimelineURL = "/2/users/%v/timelines/reverse_chronological"
 	// Search Tweets
-	searchAllTweetsURL    = "https://api.twitter.com/2/tweets/search/all"
-	searchRecentTweetsURL = "https://api.twitter.com/2/tweets/search/recent"
+	searchAllTweetsURL    = "/2/tweets/search/all"
+	searchRecentTweetsURL = "/2/tweets/search/recent"
 	// Tweet counts
-	countsAllTweetsURL    = "https://api.twitter.com/2/tweets/counts/all"
-	countsRecentTweetsURL = "https://api.twitter.com/2/tweets/counts/recent"
+	countsAllTweetsURL    = "/2/tweets/counts/all"
+	countsRecentTweetsURL = "/2/tweets/counts/recent"
 	// Filtered stream
-	connectToStreamURL     = "https://api.twitter.com/2/tweets/search/stream"
-	retrieveStreamRulesURL = "https://api.twitter.com/2/tweets/search/stream/rules"
-	addOrDeleteRulesURL    = "https://api.twitter.com/2/tweets/search/stream/rules"
+	connectToStreamURL     = "/2/tweets/search/stream"
+	retrieveStreamRulesURL = "/2/tweets/search/stream/rules"
+	addOrDeleteRulesURL    = "/2/tweets/search/stream/rules"
 	// Volume streams
-	volumeStreamsURL   = "https://api.twitter.com/2/tweets/sample/stream"
-	volumeStreams10URL = "https://api.twitter.com/2/tweets/sample10/stream"
+	volumeStreamsURL   = "/2/tweets/sample/stream"
+	volumeStreams10URL = "/2/tweets/sample10/stream"
 	// Retweets
-	undoRetweetURL    = "https://api.twitter.com/2/users/%v/retweets/%v"
-	retweetsLookupURL = "https://api.twitter.com/2/tweets/%v/retweeted_by"
-	postRetweetURL    = "https://api.twitter.com/2/users/%v/retweets"
+	undoRetweetURL    = "/2/users/%v/retweets/%v"
+	retweetsLookupURL = "/2/tweets/%v/retweeted_by"
+	postRetweetURL    = "/2/users/%v/retweets"
 	// Quote tweets
-	quoteTweetsURL = "https://api.twitter.com/2/tweets/%v/quote_tweets"
+	quoteTweetsURL = "/2/tweets/%v/quote_tweets"
 	// Likes
-	usersLikingTweetURL     = "https://api.twitter.com/2/tweets/%v/liking_users"
-	tweetsUserLikedURL      = "https://api.twitter.com/2/users/%v/liked_tweets"
-	postUsersLikingTweetURL = "https://api.twitter.com/2/users/%v/likes"
-	undoUsersLikingTweetURL = "https://api.twitter.com/2/users/%v/likes/%v"
+	usersLikingTweetURL     = "/2/tweets/%v/liking_users"
+	tweetsUserLikedURL      = "/2/users/%v/liked_tweets"
+	postUsersLikingTweetURL = "/2/users/%v/likes"
+	undoUsersLikingTweetURL = "/2/users/%v/likes/%v"
 	// Bookmarks
-	removeBookmarkOfTweetURL = "https://api.twitter.com/2/users/%v/bookmarks/%v"
-	lookupUserBookmarksURL   = "https://api.twitter.com/2/users/%v/bookmarks"
-	bookmarkTweetURL         = "https://api.twitter.com/2/users/%v/bookmarks"
+	removeBookmarkOfTweetURL = "/2/users/%v/bookmarks/%v"
+	lookupUserBookmarksURL   = "/2/users/%v/bookmarks"
+	bookmarkTweetURL         = "/2/users/%v/bookmarks"
 	// Hide replies
-	hideRepliesURL = "https://api.twitter.com/2/tweets/%v/hidden"
+	hideRepliesURL = "/2/tweets/%v/hidden"
 )
 
 const (
 	// Users lookup
-	retrieveMultipleUsersWithIDsURL       = "https://api.twitter.com/2/users?ids="
-	retrieveSingleUserWithIDURL           = "https://api.twitter.com/2/users/%v"
-	retrieveMultipleUsersWithUserNamesURL = "https://api.twitter.com/2/users/by?usernames="
-	retrieveSingleUserWithUserNameURL     = "https://api.twitter.com/2/users/by/username/%v"
-	meURL                                 = "https://api.twitter.com/2/users/me"
+	retrieveMultipleUsersWithIDsURL       = "/2/users?ids="
+	retrieveSingleUserWithIDURL           = "/2/users/%v"
+	retrieveMultipleUsersWithUserNamesURL = "/2/users/by?usernames="
+	retrieveSingleUserWithUserNameURL     = "/2/users/by/username/%v"
+	meURL                                 = "/2/users/me"
 	// User search
-	searchUsersURL = "https://api.twitter.com/2/users/search"
+	searchUsersURL = "/2/users/search"
 	// Follows
-	undoFollowingURL = "https://api.twitter.com/2/users/%v/following/%v"
-	followersURL     = "https://api.twitter.com/2/users/%v/followers"
-	followingURL     = "https://api.twitter.com/2/users/%v/following"
-	postFollowingURL = "https://api.twitter.com/2/users/%v/following"
+	undoFollowingURL = "/2/users/%v/following/%v"
+	followersURL     = "/2/users/%v/followers"
+	followingURL     = "/2/users/%v/following"
+	postFollowingURL = "/2/users/%v/following"
 	// Blocks
-	blockingURL     = "https://api.twitter.com/2/users/%v/blocking"
-	postBlockingURL = "https://api.twitter.com/2/users/%v/blocking"
-	undoBlockingURL = "https://api.twitter.com/2/users/%v/blocking/%v"
+	blockingURL     = "/2/users/%v/blocking"
+	postBlockingURL = "/2/users/%v/blocking"
+	undoBlockingURL = "/2/users/%v/blocking/%v"
 	// Mutes
-	mutingURL     = "https://api.twitter.com/2/users/%v/muting"
-	postMutingURL = "https://api.twitter.com/2/users/%v/muting"
-	undoMutingURL = "https://api.twitter.com/2/users/%v/muting/%v"
+	mutingURL     = "/2/users/%v/muting"
+	postMutingURL = "/2/users/%v/muting"
+	undoMutingURL = "/2/users/%v/muting/%v"
 )
 
 const (
 	// Spaces lookup
-	spaceURL                     = "https://api.twitter.com/2/spaces/%v"
-	spacesURL                    = "https://api.twitter.com/2/spaces?ids="
-	usersPurchasedSpaceTicketURL = "https://api.twitter.com/2/spaces/%v/buyers"
-	discoverSpacesURL            = "https://api.twitter.com/2/spaces/by/creator_ids?user_ids="
+	spaceURL                     = "/2/spaces/%v"
+	spacesURL                    = "/2/spaces?ids="
+	usersPurchasedSpaceTicketURL = "/2/spaces/%v/buyers"
+	discoverSpacesURL            = "/2/spaces/by/creator_ids?user_ids="
 	// Spaces tweets
-	spacesTweetsURL = "https://api.twitter.com/2/spaces/%v/tweets"
+	spacesTweetsURL = "/2/spaces/%v/tweets"
 	// Search Spaces
-	searchSpacesURL = "https://api.twitter.com/2/spaces/search"
+	searchSpacesURL = "/2/spaces/search"
 )
 
 const (
 	// List lookup
-	lookUpListURL          = "https://api.twitter.com/2/lists/%v"
-	lookUpAllListsOwnedURL = "https://api.twitter.com/2/users/%v/owned_lists"
+	lookUpListURL          = "/2/lists/%v"
+	lookUpAllListsOwnedURL = "/2/users/%v/owned_lists"
 	// Manage Lists
-	deleteListURL            = "https://api.twitter.com/2/lists/%v"
-	updateMetaDataForListURL = "https://api.twitter.com/2/lists/%v"
-	createNewListURL         = "https://api.twitter.com/2/lists"
+	deleteListURL            = "/2/lists/%v"
+	updateMetaDataForListURL = "/2/lists/%v"
+	createNewListURL         = "/2/lists"
 	// List Tweets lookup
-	lookUpListTweetsURL = "https://api.twitter.com/2/lists/%v/tweets"
+	lookUpListTweetsURL = "/2/lists/%v/tweets"
 	// List members
-	undoListMembersURL    = "https://api.twitter.com/2/lists/%v/members/%v"
-	listMembersURL        = "https://api.twitter.com/2/lists/%v/members"
-	listsSpecifiedUserURL = "https://api.twitter.com/2/users/%v/list_memberships"
-	postListMembersURL    = "https://api.twitter.com/2/lists/%v/members"
+	undoListMembersURL    = "/2/lists/%v/members/%v"
+	listMembersURL        = "/2/lists/%v/members"
+	listsSpecifiedUserURL = "/2/users/%v/list_memberships"
+	postListMembersURL    = "/2/lists/%v/members"
 	// List follows
-	undoListFollowsURL     = "https://api.twitter.com/2/users/%v/followed_lists/%v"
-	listFollowersURL       = "https://api.twitter.com/2/lists/%v/followers"
-	allListsUserFollowsURL = "https://api.twitter.com/2/users/%v/followed_lists"
-	postListFollowsURL     = "https://api.twitter.com/2/users/%v/followed_lists"
+	undoListFollowsURL     = "/2/users/%v/followed_lists/%v"
+	listFollowersURL       = "/2/lists/%v/followers"
+	allListsUserFollowsURL = "/2/users/%v/followed_lists"
+	postListFollowsURL     = "/2/users/%v/followed_lists"
 	// Pinned Lists
-	undoPinnedListsURL = "https://api.twitter.com/2/users/%v/pinned_lists/%v"
-	pinnedListsURL     = "https://api.twitter.com/2/users/%v/pinned_lists"
-	postPinnedListsURL = "https://api.twitter.com/2/users/%v/pinned_lists"
+	undoPinnedListsURL = "/2/users/%v/pinned_lists/%v"
+	pinnedListsURL     = "/2/users/%v/pinned_lists"
+	postPinnedListsURL = "/2/users/%v/pinned_lists"
 )
 
 const (
 	// Batch compliance
-	complianceJobsURL      = "https://api.twitter.com/2/compliance/jobs"
-	complianceJobURL       = "https://api.twitter.com/2/compliance/jobs/%v"
-	createComplianceJobURL = "https://api.twitter.com/2/compliance/jobs"
+	complianceJobsURL      = "/2/compliance/jobs"
+	complianceJobURL       = "/2/compliance/jobs/%v"
+	createComplianceJobURL = "/2/compliance/jobs"
 )
 
 const (
 	// Manage Direct Message
-	createOneToOneDMURL = "https://api.twitter.com/2/dm_conversations/with/%v/messages"
-	createNewGroupDMURL = "https://api.twitter.com/2/dm_conversations/%v/messages"
-	postDMURL           = "https://api.twitter.com/2/dm_conversations"
+	createOneToOneDMURL = "/2/dm_conversations/with/%v/messages"
+	createNewGroupDMURL = "/2/dm_conversations/%v/messages"
+	postDMURL           = "/2/dm_conversations"
 )
 
 const (
 	// LookUp Direct Message
-	lookUpAllOneToOneDMURL = "https://api.twitter.com/2/dm_conversations/with/%v/dm_events"
-	lookUpDMURL            = "https://api.twitter.com/2/dm_conversations/%v/dm_events"
-	lookUpAllDMURL         = "https://api.twitter.com/2/dm_events"
+	lookUpAllOneToOneDMURL = "/2/dm_conversations/with/%v/dm_events"
+	lookUpDMURL            = "/2/dm_conversations/%v/dm_events"
+	lookUpAllDMURL         = "/2/dm_events"
 )
 
 const (
 	// DM Blocks
-	postDMBlockingURL = "https://api.twitter.com/2/users/%v/dm/block"
-	undoDMBlockingURL = "https://api.twitter.com/2/users/%v/dm/unblock"
+	postDMBlockingURL = "/2/users/%v/dm/block"
+	undoDMBlockingURL = "/2/users/%v/dm/unblock"
 )
 
 const (
 	// Community Notes
-	searchPostsEligibleForNotesURL = "https://api.x.com/2/notes/search/posts_eligible_for_notes"
-	searchNotesWrittenURL          = "https://api.x.com/2/notes/search/notes_written"
-	createCommunityNoteURL         = "https://api.x.com/2/notes"
+	searchPostsEligibleForNotesURL = "/2/notes/search/posts_eligible_for_notes"
+	searchNotesWrittenURL          = "/2/notes/search/notes_written"
+	createCommunityNoteURL         = "/2/notes"
 )
 
 const (
 	// Trends
-	trendsByWOEIDURL = "https://api.x.com/2/trends/by/woeid/%v"
+	trendsByWOEIDURL = "/2/trends/by/woeid/%v"
 )
 
 const (
 	// Media Upload
-	mediaUploadURL = "https://api.x.com/2/media/upload"
+	mediaUploadURL = "/2/media/upload"
 )
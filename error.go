@@ -1,13 +1,83 @@
 package gotwtr
 
+import (
+	"errors"
+	"net/http"
+	"strconv"
+)
+
+// Sentinel errors matched by HTTPError with errors.Is, e.g. errors.Is(err, gotwtr.ErrNotFound).
+var (
+	ErrBadRequest      = errors.New("gotwtr: bad request")
+	ErrUnauthorized    = errors.New("gotwtr: unauthorized")
+	ErrForbidden       = errors.New("gotwtr: forbidden")
+	ErrNotFound        = errors.New("gotwtr: not found")
+	ErrConflict        = errors.New("gotwtr: conflict")
+	ErrTooManyRequests = errors.New("gotwtr: too many requests")
+	ErrServerError     = errors.New("gotwtr: server error")
+)
+
+// maxErrorBodySize is the maximum size of the raw body kept in HTTPError.
+const maxErrorBodySize = 4 << 10
+
+// HTTPError is returned when the API responds with an unexpected status.
+// It carries the problem details and every errors[] entry of the response body.
 type HTTPError struct {
-	APIName string
-	Status  string
-	URL     string
+	APIName    string
+	Status     string
+	StatusCode int
+	URL        string
+	// Type, Title and Detail are the problem details of the response.
+	Type   string
+	Title  string
+	Detail string
+	// Errors holds every entry of the errors[] field of the response.
+	Errors []*APIResponseError
+	// Body is the raw response body truncated to 4KB.
+	Body string
 }
 
 func (e *HTTPError) Error() string {
-	return e.APIName + ": " + e.Status + " " + e.URL
+	status := e.Status
+	if status == "" {
+		status = strconv.Itoa(e.StatusCode)
+	}
+	msg := e.APIName + ": " + status + " " + e.URL
+	switch {
+	case e.Detail != "":
+		msg += ": " + e.Detail
+	case e.Title != "":
+		msg += ": " + e.Title
+	case len(e.Errors) > 0 && e.Errors[0].Message != "":
+		msg += ": " + e.Errors[0].Message
+	}
+	return msg
+}
+
+// Is reports whether the status code of e matches the sentinel error target,
+// or whether e reports a problem of the kind target if it is a ProblemKind.
+func (e *HTTPError) Is(target error) bool {
+	if k, ok := target.(ProblemKind); ok {
+		return e.hasProblem(k)
+	}
+	switch target {
+	case ErrBadRequest:
+		return e.StatusCode == http.StatusBadRequest
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized
+	case ErrForbidden:
+		return e.StatusCode == http.StatusForbidden
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrConflict:
+		return e.StatusCode == http.StatusConflict
+	case ErrTooManyRequests:
+		return e.StatusCode == http.StatusTooManyRequests
+	case ErrServerError:
+		return e.StatusCode >= http.StatusInternalServerError
+	default:
+		return false
+	}
 }
 
 type APIResponseError struct {
@@ -25,6 +95,7 @@ type APIResponseError struct {
 	RequiredEnrollment string      `json:"required_enrollment,omitempty"`
 	RegistrationURL    string      `json:"registration_url,omitempty"`
 	ConnectionIssue    string      `json:"connection_issue,omitempty"`
+	DisconnectType     string      `json:"disconnect_type,omitempty"`
 	Status             int         `json:"status,omitempty"`
 }
 
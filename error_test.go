@@ -0,0 +1,147 @@
+package gotwtr_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sivchari/gotwtr"
+)
+
+func Test_HTTPError(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		client *http.Client
+	}
+	tests := []struct {
+		name     string
+		args     args
+		want     *gotwtr.HTTPError
+		wantMsg  string
+		sentinel error
+	}{
+		{
+			name: "404 with problem details",
+			args: args{
+				client: mockHTTPClient(func(req *http.Request) *http.Response {
+					body := `{"errors":[{"message":"The id query parameter value [x] is not valid"}],"title":"Invalid Request","detail":"One or more parameters to your request was invalid.","type":"https://api.twitter.com/2/problems/invalid-request"}`
+					return &http.Response{
+						StatusCode: http.StatusNotFound,
+						Status:     "404 Not Found",
+						Body:       io.NopCloser(strings.NewReader(body)),
+					}
+				}),
+			},
+			want: &gotwtr.HTTPError{
+				APIName:    "retrieve single tweet",
+				Status:     "404 Not Found",
+				StatusCode: http.StatusNotFound,
+				URL:        "https://api.x.com/2/tweets/x",
+				Type:       "https://api.twitter.com/2/problems/invalid-request",
+				Title:      "Invalid Request",
+				Detail:     "One or more parameters to your request was invalid.",
+				Errors: []*gotwtr.APIResponseError{
+					{Message: "The id query parameter value [x] is not valid"},
+				},
+				Body: `{"errors":[{"message":"The id query parameter value [x] is not valid"}],"title":"Invalid Request","detail":"One or more parameters to your request was invalid.","type":"https://api.twitter.com/2/problems/invalid-request"}`,
+			},
+			wantMsg:  "retrieve single tweet: 404 Not Found https://api.x.com/2/tweets/x: One or more parameters to your request was invalid.",
+			sentinel: gotwtr.ErrNotFound,
+		},
+		{
+			name: "503 with html body",
+			args: args{
+				client: mockHTTPClient(func(req *http.Request) *http.Response {
+					return &http.Response{
+						StatusCode: http.StatusServiceUnavailable,
+						Status:     "503 Service Unavailable",
+						Body:       io.NopCloser(strings.NewReader("<html>Service Unavailable</html>")),
+					}
+				}),
+			},
+			want: &gotwtr.HTTPError{
+				APIName:    "retrieve single tweet",
+				Status:     "503 Service Unavailable",
+				StatusCode: http.StatusServiceUnavailable,
+				URL:        "https://api.x.com/2/tweets/x",
+				Body:       "<html>Service Unavailable</html>",
+			},
+			wantMsg:  "retrieve single tweet: 503 Service Unavailable https://api.x.com/2/tweets/x",
+			sentinel: gotwtr.ErrServerError,
+		},
+		{
+			name: "429 with html body",
+			args: args{
+				client: mockHTTPClient(func(req *http.Request) *http.Response {
+					return &http.Response{
+						StatusCode: http.StatusTooManyRequests,
+						Status:     "429 Too Many Requests",
+						Body:       io.NopCloser(strings.NewReader("<html>Too Many Requests</html>")),
+					}
+				}),
+			},
+			want: &gotwtr.HTTPError{
+				APIName:    "retrieve single tweet",
+				Status:     "429 Too Many Requests",
+				StatusCode: http.StatusTooManyRequests,
+				URL:        "https://api.x.com/2/tweets/x",
+				Body:       "<html>Too Many Requests</html>",
+			},
+			wantMsg:  "retrieve single tweet: rate limit exceeded https://api.x.com/2/tweets/x",
+			sentinel: gotwtr.ErrTooManyRequests,
+		},
+		{
+			name: "429 too many requests",
+			args: args{
+				client: mockHTTPClient(func(req *http.Request) *http.Response {
+					return &http.Response{
+						StatusCode: http.StatusTooManyRequests,
+						Status:     "429 Too Many Requests",
+						Body:       io.NopCloser(strings.NewReader(`{"title":"Too Many Requests","detail":"Too Many Requests","type":"about:blank","status":429}`)),
+					}
+				}),
+			},
+			want: &gotwtr.HTTPError{
+				APIName:    "retrieve single tweet",
+				Status:     "429 Too Many Requests",
+				StatusCode: http.StatusTooManyRequests,
+				URL:        "https://api.x.com/2/tweets/x",
+				Type:       "about:blank",
+				Title:      "Too Many Requests",
+				Detail:     "Too Many Requests",
+				Body:       `{"title":"Too Many Requests","detail":"Too Many Requests","type":"about:blank","status":429}`,
+			},
+			wantMsg:  "retrieve single tweet: rate limit exceeded https://api.x.com/2/tweets/x",
+			sentinel: gotwtr.ErrTooManyRequests,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			c := gotwtr.New("key", gotwtr.WithHTTPClient(tt.args.client))
+			_, err := c.RetrieveSingleTweet(context.Background(), "x")
+			var got *gotwtr.HTTPError
+			if !errors.As(err, &got) {
+				t.Fatalf("errors.As(HTTPError) = false: %v", err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("HTTPError mismatch (-want +got):\n%s", diff)
+			}
+			if got := err.Error(); got != tt.wantMsg {
+				t.Errorf("Error() = %q, want %q", got, tt.wantMsg)
+			}
+			if !errors.Is(err, tt.sentinel) {
+				t.Errorf("errors.Is(%v, %v) = false", err, tt.sentinel)
+			}
+			if errors.Is(err, gotwtr.ErrUnauthorized) {
+				t.Errorf("errors.Is(%v, ErrUnauthorized) = true", err)
+			}
+		})
+	}
+}
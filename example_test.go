@@ -188,6 +188,45 @@ func ExampleClient_ConnectToStream() {
 	log.Println("done")
 }
 
+func ExampleHandleConnectToStream() {
+	client := gotwtr.New("key")
+	consumer := gotwtr.HandleConnectToStream(context.Background(), client, func(ctx context.Context, resp *gotwtr.ConnectToStreamResponse) error {
+		log.Println(resp.Tweet)
+		return nil
+	}, &gotwtr.StreamHandlerOption{
+		BufferSize:   1000,
+		Backpressure: gotwtr.BackpressureDropOldest,
+		DrainTimeout: 5 * time.Second,
+		OnError: func(err error) {
+			log.Println(err)
+		},
+	})
+	log.Println("streaming...")
+	time.Sleep(time.Second * 10)
+	consumer.Stop()
+	log.Printf("dropped %d messages", consumer.Stats().Dropped())
+}
+
+func ExampleStreamRouter() {
+	client := gotwtr.New("key")
+	router := gotwtr.NewStreamRouter()
+	router.HandlePrefix("team-a:", func(ctx context.Context, resp *gotwtr.ConnectToStreamResponse) error {
+		log.Println("team a", resp.Tweet)
+		return nil
+	})
+	router.Handle("cats", func(ctx context.Context, resp *gotwtr.ConnectToStreamResponse) error {
+		log.Println("cats", resp.Tweet)
+		return nil
+	})
+	router.HandleFallback(func(ctx context.Context, resp *gotwtr.ConnectToStreamResponse) error {
+		log.Println("unmatched", resp.MatchingRules)
+		return nil
+	})
+	consumer := gotwtr.HandleConnectToStream(context.Background(), client, router.HandleMessage, nil)
+	time.Sleep(time.Second * 10)
+	consumer.Stop()
+}
+
 func ExampleClient_VolumeStreams() {
 	client := gotwtr.New("key")
 	ch := make(chan gotwtr.VolumeStreamsResponse, 5)
@@ -792,3 +831,24 @@ func ExampleClient_LookUpAllDM() {
 	}
 	log.Println(dm)
 }
+
+func ExampleOAuth2Config_AuthorizeLoopback() {
+	cfg := &gotwtr.OAuth2Config{
+		ClientID:    "client_id",
+		RedirectURL: "http://127.0.0.1:8080/callback",
+		Scopes:      []string{"tweet.read", "users.read", "offline.access"},
+	}
+	tok, err := cfg.AuthorizeLoopback(context.Background(), 2*time.Minute, func(authURL string) error {
+		log.Println("open the following URL in your browser:", authURL)
+		return nil
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+	client := gotwtr.New("", gotwtr.WithTokenSource(cfg.TokenSource(tok)))
+	me, err := client.Me(context.Background())
+	if err != nil {
+		log.Fatal(err)
+	}
+	log.Println(me.Me.UserName)
+}
@@ -2,8 +2,10 @@ package gotwtr
 
 func (c *client) ExportClient() map[string]string {
 	return map[string]string{
-		"bearerToken":    c.bearerToken,
+		"bearerToken":    c.bearerToken.get(),
 		"consumerKey":    c.consumerKey,
 		"consumerSecret": c.consumerSecret,
 	}
 }
+
+var OAuth1Authorization = oauth1Authorization
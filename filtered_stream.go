@@ -9,6 +9,7 @@ import (
 	"io"
 	"net/http"
 	"sync"
+	"time"
 )
 
 func addOrDeleteRules(ctx context.Context, c *client, body *AddOrDeleteJSONBody, opt ...*AddOrDeleteRulesOption) (*AddOrDeleteRulesResponse, error) {
@@ -33,11 +34,10 @@ func addOrDeleteRules(ctx context.Context, c *client, body *AddOrDeleteJSONBody,
 		return nil, errors.New("add or delete rules : can not marshal")
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, addOrDeleteRulesURL, bytes.NewBuffer(j))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+addOrDeleteRulesURL, bytes.NewBuffer(j))
 	if err != nil {
 		return nil, fmt.Errorf("add or delete rules new request with ctx: %w", err)
 	}
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.bearerToken))
 	req.Header.Set("Content-Type", "application/json")
 
 	var topt AddOrDeleteRulesOption
@@ -51,33 +51,14 @@ func addOrDeleteRules(ctx context.Context, c *client, body *AddOrDeleteJSONBody,
 	}
 	topt.addQuery(req)
 
-	resp, err := c.client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("add or delete rules: %w", err)
-	}
-	defer func() { _ = resp.Body.Close() }()
-
-	var addOrDelete AddOrDeleteRulesResponse
-	if err := json.NewDecoder(resp.Body).Decode(&addOrDelete); err != nil {
-		return nil, fmt.Errorf("add or delete rules decode: %w", err)
-	}
-	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
-		return &addOrDelete, &HTTPError{
-			APIName: "add or delete",
-			Status:  resp.Status,
-			URL:     req.URL.String(),
-		}
-	}
-
-	return &addOrDelete, nil
+	return doJSON[AddOrDeleteRulesResponse](c, req, "add or delete", http.StatusCreated, http.StatusOK)
 }
 
 func retrieveStreamRules(ctx context.Context, c *client, opt ...*RetrieveStreamRulesOption) (*RetrieveStreamRulesResponse, error) {
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, retrieveStreamRulesURL, nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+retrieveStreamRulesURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("retrieve stream rules new request with ctx: %w", err)
 	}
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.bearerToken))
 
 	var topt RetrieveStreamRulesOption
 	switch len(opt) {
@@ -90,69 +71,36 @@ func retrieveStreamRules(ctx context.Context, c *client, opt ...*RetrieveStreamR
 	}
 	topt.addQuery(req)
 
-	resp, err := c.client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("retrieve stream rules: %w", err)
-	}
-	defer func() { _ = resp.Body.Close() }()
-
-	var tweet RetrieveStreamRulesResponse
-	if err := json.NewDecoder(resp.Body).Decode(&tweet); err != nil {
-		return nil, fmt.Errorf("retrieve stream rules decode: %w", err)
-	}
-	if resp.StatusCode != http.StatusOK {
-		return &tweet, &HTTPError{
-			APIName: "retrieve stream rules",
-			Status:  resp.Status,
-			URL:     req.URL.String(),
-		}
-	}
-
-	return &tweet, nil
+	return doJSON[RetrieveStreamRulesResponse](c, req, "retrieve stream rules", http.StatusOK)
 }
 
+// Stop closes the connection of the stream and waits until its goroutine has ended.
 func (s *ConnectToStream) Stop() {
 	close(s.done)
+	s.cancel()
 	s.wg.Wait()
 }
 
+// LastActivity returns the time the stream last received data, keep-alives included.
+// It returns the zero time until the stream is connected.
+func (s *ConnectToStream) LastActivity() time.Time {
+	return lastActivity(&s.last)
+}
+
+// retry connects to the stream, and reconnects according to the reconnect policy of the client until the stream is stopped.
 func (s *ConnectToStream) retry(req *http.Request) {
 	defer s.wg.Done()
-	resp, err := s.client.Do(req)
-	if err != nil {
-		s.errCh <- err
-		return
-	}
-	defer func() { _ = resp.Body.Close() }()
-	if resp.StatusCode != http.StatusOK {
-		s.errCh <- &HTTPError{
-			APIName: "connect to stream",
-			Status:  resp.Status,
-			URL:     req.URL.String(),
-		}
-		return
-	}
-	dec := json.NewDecoder(resp.Body)
-
-	for !stopped(s.done) {
-		var connectToStream ConnectToStreamResponse
-		err := dec.Decode(&connectToStream)
-		if err != nil {
-			s.errCh <- err
-			if err == io.EOF {
-				break
-			}
-		}
-		s.ch <- connectToStream
-	}
+	s.client.stream(req, "connect to stream", s.done, s.errCh, &s.last, func(body io.Reader) error {
+		return readStream(s.client, "connect to stream", body, s.done, s.ch, s.errCh)
+	})
 }
 
 func connectToStream(ctx context.Context, c *client, ch chan<- ConnectToStreamResponse, errCh chan<- error, opt ...*ConnectToStreamOption) *ConnectToStream {
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, connectToStreamURL, nil)
+	ctx, cancel := context.WithCancel(ctx)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+connectToStreamURL, nil)
 	if err != nil {
 		errCh <- fmt.Errorf("connect to stream new request with ctx: %w", err)
 	}
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.bearerToken))
 
 	var copt ConnectToStreamOption
 	switch len(opt) {
@@ -166,10 +114,11 @@ func connectToStream(ctx context.Context, c *client, ch chan<- ConnectToStreamRe
 	copt.addQuery(req)
 
 	s := &ConnectToStream{
-		client: c.client,
+		client: c,
 		errCh:  errCh,
 		ch:     ch,
 		done:   make(chan struct{}),
+		cancel: cancel,
 		wg:     &sync.WaitGroup{},
 	}
 
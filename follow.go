@@ -13,15 +13,13 @@ func followers(ctx context.Context, c *client, userID string, opt ...*FollowOpti
 	if userID == "" {
 		return nil, errors.New("followers: userID parameter is required")
 	}
-	ep := fmt.Sprintf(followersURL, userID)
+	ep := c.baseURL + fmt.Sprintf(followersURL, userID)
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ep, nil)
 	if err != nil {
 		return nil, fmt.Errorf("followers new request with ctx: %w", err)
 	}
 
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.bearerToken))
-
 	var fopt FollowOption
 	switch len(opt) {
 	case 0:
@@ -44,41 +42,20 @@ func followers(ctx context.Context, c *client, userID string, opt ...*FollowOpti
 	}
 	fopt.addQuery(req)
 
-	resp, err := c.client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("followers response: %w", err)
-	}
-
-	defer func() { _ = resp.Body.Close() }()
-
-	var f FollowersResponse
-	if err := json.NewDecoder(resp.Body).Decode(&f); err != nil {
-		return nil, fmt.Errorf("followers by id decode: %w", err)
-	}
-	if resp.StatusCode != http.StatusOK {
-		return &f, &HTTPError{
-			APIName: "followers",
-			Status:  resp.Status,
-			URL:     req.URL.String(),
-		}
-	}
-
-	return &f, nil
+	return doJSON[FollowersResponse](c, req, "followers", http.StatusOK)
 }
 
 func following(ctx context.Context, c *client, userID string, opt ...*FollowOption) (*FollowingResponse, error) {
 	if userID == "" {
 		return nil, errors.New("following: userID parameter is required")
 	}
-	ep := fmt.Sprintf(followingURL, userID)
+	ep := c.baseURL + fmt.Sprintf(followingURL, userID)
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ep, nil)
 	if err != nil {
 		return nil, fmt.Errorf("following new request with ctx: %w", err)
 	}
 
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.bearerToken))
-
 	var fopt FollowOption
 	switch len(opt) {
 	case 0:
@@ -101,33 +78,14 @@ func following(ctx context.Context, c *client, userID string, opt ...*FollowOpti
 	}
 	fopt.addQuery(req)
 
-	resp, err := c.client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("following response: %w", err)
-	}
-
-	defer func() { _ = resp.Body.Close() }()
-
-	var f FollowingResponse
-	if err := json.NewDecoder(resp.Body).Decode(&f); err != nil {
-		return nil, fmt.Errorf("following: %w", err)
-	}
-	if resp.StatusCode != http.StatusOK {
-		return &f, &HTTPError{
-			APIName: "following",
-			Status:  resp.Status,
-			URL:     req.URL.String(),
-		}
-	}
-
-	return &f, nil
+	return doJSON[FollowingResponse](c, req, "following", http.StatusOK)
 }
 
 func postFollowing(ctx context.Context, c *client, userID string, targetUserID string) (*PostFollowingResponse, error) {
 	if userID == "" {
 		return nil, errors.New("post following: userID parameter is required")
 	}
-	ep := fmt.Sprintf(postFollowingURL, userID)
+	ep := c.baseURL + fmt.Sprintf(postFollowingURL, userID)
 
 	if targetUserID == "" {
 		return nil, errors.New("post following: targetUserID parameter is required")
@@ -144,28 +102,9 @@ func postFollowing(ctx context.Context, c *client, userID string, targetUserID s
 	if err != nil {
 		return nil, fmt.Errorf("post following new request with ctx: %w", err)
 	}
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.bearerToken))
 	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := c.client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("post following response: %w", err)
-	}
-	defer func() { _ = resp.Body.Close() }()
-
-	var postFollowing PostFollowingResponse
-	if err := json.NewDecoder(resp.Body).Decode(&postFollowing); err != nil {
-		return nil, fmt.Errorf("post following decode: %w", err)
-	}
-	if resp.StatusCode != http.StatusOK {
-		return &postFollowing, &HTTPError{
-			APIName: "post following",
-			Status:  resp.Status,
-			URL:     req.URL.String(),
-		}
-	}
-
-	return &postFollowing, nil
+	return doJSON[PostFollowingResponse](c, req, "post following", http.StatusOK)
 }
 
 func undoFollowing(ctx context.Context, c *client, sourceUserID string, targetUserID string) (*UndoFollowingResponse, error) {
@@ -175,31 +114,12 @@ func undoFollowing(ctx context.Context, c *client, sourceUserID string, targetUs
 	if targetUserID == "" {
 		return nil, errors.New("undo following: targetUserID parameter is required")
 	}
-	ep := fmt.Sprintf(undoFollowingURL, sourceUserID, targetUserID)
+	ep := c.baseURL + fmt.Sprintf(undoFollowingURL, sourceUserID, targetUserID)
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, ep, nil)
 	if err != nil {
 		return nil, fmt.Errorf("undo following new request with ctx: %w", err)
 	}
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.bearerToken))
-
-	resp, err := c.client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("undo following response: %w", err)
-	}
-	defer func() { _ = resp.Body.Close() }()
-
-	var undoFollowing UndoFollowingResponse
-	if err := json.NewDecoder(resp.Body).Decode(&undoFollowing); err != nil {
-		return nil, fmt.Errorf("undo following decode: %w", err)
-	}
-	if resp.StatusCode != http.StatusOK {
-		return &undoFollowing, &HTTPError{
-			APIName: "undo following",
-			Status:  resp.Status,
-			URL:     req.URL.String(),
-		}
-	}
 
-	return &undoFollowing, nil
+	return doJSON[UndoFollowingResponse](c, req, "undo following", http.StatusOK)
 }
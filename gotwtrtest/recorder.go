@@ -0,0 +1,499 @@
+// Package gotwtrtest records the HTTP exchanges of a gotwtr client to a cassette file,
+// and replays them deterministically in tests without network access.
+//
+// A test records its cassette once against the real API, with GOTWTRTEST_RECORD=1:
+//
+//	rec := gotwtrtest.Start(t, "testdata/retrieve_single_tweet.json")
+//	client := gotwtr.New(os.Getenv("BEARER_TOKEN"), gotwtr.WithHTTPClient(rec.Client()))
+//
+// and replays it on later runs. Requests are matched on their method, path and query.
+// Credentials such as the Authorization header and OAuth tokens are scrubbed before the cassette is written.
+package gotwtrtest
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/sivchari/gotwtr/internal/sensitive"
+)
+
+// RecordEnv is the environment variable which makes Start record, when set to a non-empty value.
+const RecordEnv = "GOTWTRTEST_RECORD"
+
+// Redacted replaces the scrubbed credentials in a cassette.
+const Redacted = "REDACTED"
+
+// defaultStopTimeout is how long Stop waits for the bodies of recorded streams to be closed.
+const defaultStopTimeout = 10 * time.Second
+
+// ErrNoInteraction is returned when replaying a request the cassette has no unused interaction for.
+var ErrNoInteraction = errors.New("gotwtrtest: no recorded interaction matches the request")
+
+// Mode is whether a Recorder records or replays.
+type Mode int
+
+const (
+	// ModeReplay replays the interactions of the cassette, which must exist.
+	ModeReplay Mode = iota
+	// ModeRecord sends the requests to the real transport and records them, overwriting the cassette.
+	ModeRecord
+	// ModeReplayOrRecord replays the cassette if it exists, and records it otherwise.
+	ModeReplayOrRecord
+)
+
+// Cassette is the content of a cassette file.
+type Cassette struct {
+	Interactions []*Interaction `json:"interactions"`
+}
+
+// Interaction is a recorded request and its response.
+type Interaction struct {
+	Request  *Request  `json:"request"`
+	Response *Response `json:"response"`
+}
+
+// Request is a recorded request.
+type Request struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+	Body   Body        `json:"body,omitempty"`
+}
+
+// Response is a recorded response.
+type Response struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       Body        `json:"body,omitempty"`
+}
+
+// Body is a recorded body. It is written as a string if it is valid UTF-8, and as base64 otherwise.
+type Body []byte
+
+func (b Body) MarshalJSON() ([]byte, error) {
+	if utf8.Valid(b) {
+		return json.Marshal(string(b))
+	}
+	return json.Marshal(map[string]string{"base64": base64.StdEncoding.EncodeToString(b)})
+}
+
+func (b *Body) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		*b = Body(s)
+		return nil
+	}
+	var enc struct {
+		Base64 string `json:"base64"`
+	}
+	if err := json.Unmarshal(data, &enc); err != nil {
+		return err
+	}
+	v, err := base64.StdEncoding.DecodeString(enc.Base64)
+	if err != nil {
+		return err
+	}
+	*b = v
+	return nil
+}
+
+// Option is an option of NewRecorder and Start.
+type Option func(*Recorder)
+
+// WithTransport sets the transport requests are sent with while recording. The default is http.DefaultTransport.
+func WithTransport(rt http.RoundTripper) Option {
+	return func(r *Recorder) {
+		r.transport = rt
+	}
+}
+
+// WithStopTimeout sets how long Stop waits for the bodies of recorded streams to be closed. The default is 10 seconds.
+func WithStopTimeout(d time.Duration) Option {
+	return func(r *Recorder) {
+		r.stopTimeout = d
+	}
+}
+
+// WithScrubber adds a function scrubbing every interaction before it is written,
+// e.g. to remove user data the default scrubbing does not know about.
+func WithScrubber(scrub func(*Interaction)) Option {
+	return func(r *Recorder) {
+		r.scrubbers = append(r.scrubbers, scrub)
+	}
+}
+
+// Recorder is an http.RoundTripper recording or replaying the interactions of a cassette.
+type Recorder struct {
+	path        string
+	mode        Mode
+	transport   http.RoundTripper
+	scrubbers   []func(*Interaction)
+	stopTimeout time.Duration
+
+	mu       sync.Mutex
+	cassette *Cassette
+	used     []bool
+	// pending are the stream bodies being recorded, which are written once closed.
+	pending map[*recordingBody]string
+}
+
+// NewRecorder returns a Recorder of the cassette file at path.
+// Call Stop once done, to write the cassette when recording.
+func NewRecorder(path string, mode Mode, opts ...Option) (*Recorder, error) {
+	r := &Recorder{
+		path:        path,
+		mode:        mode,
+		transport:   http.DefaultTransport,
+		stopTimeout: defaultStopTimeout,
+		cassette:    &Cassette{},
+		pending:     make(map[*recordingBody]string),
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	if r.mode == ModeReplayOrRecord {
+		r.mode = ModeReplay
+		if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+			r.mode = ModeRecord
+		}
+	}
+	if r.mode == ModeRecord {
+		return r, nil
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("gotwtrtest: read cassette: %w", err)
+	}
+	if err := json.Unmarshal(b, r.cassette); err != nil {
+		return nil, fmt.Errorf("gotwtrtest: decode cassette %s: %w", path, err)
+	}
+	r.used = make([]bool, len(r.cassette.Interactions))
+	return r, nil
+}
+
+// Start returns a Recorder of the cassette file at path which is stopped when the test finishes.
+// It records if RecordEnv is set, and replays otherwise.
+func Start(t testing.TB, path string, opts ...Option) *Recorder {
+	t.Helper()
+	mode := ModeReplay
+	if os.Getenv(RecordEnv) != "" {
+		mode = ModeRecord
+	}
+	r, err := NewRecorder(path, mode, opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := r.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+	return r
+}
+
+// Mode returns whether the recorder records or replays.
+func (r *Recorder) Mode() Mode {
+	return r.mode
+}
+
+// Client returns an HTTP client using the recorder as its transport, e.g. for gotwtr.WithHTTPClient.
+func (r *Recorder) Client() *http.Client {
+	return &http.Client{Transport: r}
+}
+
+// RoundTrip records or replays req.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	if r.mode == ModeRecord {
+		return r.record(req)
+	}
+	return r.replay(req)
+}
+
+// Stop waits for the stream bodies being recorded to be closed, and writes the cassette when recording.
+// It fails, naming the requests, if a stream body is not closed within the stop timeout.
+func (r *Recorder) Stop() error {
+	if r.mode != ModeRecord {
+		return nil
+	}
+	if err := r.waitPending(); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, in := range r.cassette.Interactions {
+		scrub(in)
+		for _, s := range r.scrubbers {
+			s(in)
+		}
+	}
+	b, err := json.MarshalIndent(r.cassette, "", "  ")
+	if err != nil {
+		return fmt.Errorf("gotwtrtest: encode cassette: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return fmt.Errorf("gotwtrtest: write cassette: %w", err)
+	}
+	if err := os.WriteFile(r.path, append(b, '\n'), 0o600); err != nil {
+		return fmt.Errorf("gotwtrtest: write cassette: %w", err)
+	}
+	return nil
+}
+
+func (r *Recorder) record(req *http.Request) (*http.Response, error) {
+	var reqBody []byte
+	if req.Body != nil && req.Body != http.NoBody {
+		b, err := io.ReadAll(req.Body)
+		_ = req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		reqBody = b
+		req = req.Clone(req.Context())
+		req.Body = io.NopCloser(bytes.NewReader(b))
+	}
+	in := &Interaction{
+		Request: &Request{
+			Method: req.Method,
+			URL:    req.URL.String(),
+			Header: req.Header.Clone(),
+			Body:   reqBody,
+		},
+	}
+	resp, err := r.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	in.Response = &Response{
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header.Clone(),
+	}
+	if !isStream(req) {
+		// The body is read right away, so that a body the test never closes does not hold up Stop.
+		b, err := io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		in.Response.Body = b
+		resp.Body = io.NopCloser(bytes.NewReader(b))
+		r.mu.Lock()
+		r.cassette.Interactions = append(r.cassette.Interactions, in)
+		r.mu.Unlock()
+		return resp, nil
+	}
+	// A stream is recorded as it is read, up to where the client closed it.
+	body := &recordingBody{
+		rc:     resp.Body,
+		closed: make(chan struct{}),
+	}
+	body.done = func(b []byte) {
+		r.mu.Lock()
+		in.Response.Body = b
+		delete(r.pending, body)
+		r.mu.Unlock()
+		close(body.closed)
+	}
+	r.mu.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, in)
+	r.pending[body] = req.Method + " " + req.URL.String()
+	r.mu.Unlock()
+	resp.Body = body
+	return resp, nil
+}
+
+// isStream reports whether req connects to a streaming endpoint, e.g. /2/tweets/search/stream.
+func isStream(req *http.Request) bool {
+	return strings.HasSuffix(req.URL.Path, "/stream")
+}
+
+// waitPending waits for the pending stream bodies to be closed within the stop timeout.
+func (r *Recorder) waitPending() error {
+	r.mu.Lock()
+	bodies := make([]*recordingBody, 0, len(r.pending))
+	for b := range r.pending {
+		bodies = append(bodies, b)
+	}
+	r.mu.Unlock()
+	timer := time.NewTimer(r.stopTimeout)
+	defer timer.Stop()
+	for _, b := range bodies {
+		select {
+		case <-b.closed:
+		case <-timer.C:
+			r.mu.Lock()
+			defer r.mu.Unlock()
+			reqs := make([]string, 0, len(r.pending))
+			for _, req := range r.pending {
+				reqs = append(reqs, req)
+			}
+			sort.Strings(reqs)
+			return fmt.Errorf("gotwtrtest: response body never closed after %s: %s", r.stopTimeout, strings.Join(reqs, ", "))
+		}
+	}
+	return nil
+}
+
+// recordingBody copies what is read from rc, and passes it to done once closed.
+type recordingBody struct {
+	rc     io.ReadCloser
+	buf    bytes.Buffer
+	once   sync.Once
+	done   func([]byte)
+	closed chan struct{}
+}
+
+func (b *recordingBody) Read(p []byte) (int, error) {
+	n, err := b.rc.Read(p)
+	b.buf.Write(p[:n])
+	return n, err
+}
+
+func (b *recordingBody) Close() error {
+	err := b.rc.Close()
+	b.once.Do(func() { b.done(b.buf.Bytes()) })
+	return err
+}
+
+func (r *Recorder) replay(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		_ = req.Body.Close()
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, in := range r.cassette.Interactions {
+		if r.used[i] || !matches(in.Request, req) {
+			continue
+		}
+		r.used[i] = true
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", in.Response.StatusCode, http.StatusText(in.Response.StatusCode)),
+			StatusCode:    in.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        in.Response.Header.Clone(),
+			Body:          io.NopCloser(bytes.NewReader(in.Response.Body)),
+			ContentLength: int64(len(in.Response.Body)),
+			Request:       req,
+		}, nil
+	}
+	return nil, fmt.Errorf("%w: %s %s", ErrNoInteraction, req.Method, req.URL)
+}
+
+// matches reports whether the recorded request has the method, path and query of req.
+// Credentials in the query are compared scrubbed, as they were recorded.
+func matches(recorded *Request, req *http.Request) bool {
+	if recorded.Method != req.Method {
+		return false
+	}
+	u, err := url.Parse(recorded.URL)
+	if err != nil {
+		return false
+	}
+	return u.Path == req.URL.Path && reflect.DeepEqual(scrubValues(u.Query()), scrubValues(req.URL.Query()))
+}
+
+// scrub redacts the credentials of the interaction.
+func scrub(in *Interaction) {
+	if in.Request != nil {
+		in.Request.Header = scrubHeader(in.Request.Header)
+		if u, err := url.Parse(in.Request.URL); err == nil {
+			u.User = nil
+			u.RawQuery = scrubValues(u.Query()).Encode()
+			in.Request.URL = u.String()
+		}
+		in.Request.Body = scrubBody(in.Request.Body, in.Request.Header.Get("Content-Type"))
+	}
+	if in.Response != nil {
+		in.Response.Header = scrubHeader(in.Response.Header)
+		in.Response.Body = scrubBody(in.Response.Body, in.Response.Header.Get("Content-Type"))
+	}
+}
+
+func scrubHeader(h http.Header) http.Header {
+	for _, k := range sensitive.Headers {
+		if h.Get(k) != "" {
+			h.Set(k, Redacted)
+		}
+	}
+	return h
+}
+
+func scrubValues(v url.Values) url.Values {
+	for k := range v {
+		if sensitive.Key(k) {
+			v[k] = []string{Redacted}
+		}
+	}
+	return v
+}
+
+func scrubBody(b Body, contentType string) Body {
+	switch {
+	case len(b) == 0:
+		return b
+	case strings.HasPrefix(contentType, "application/x-www-form-urlencoded"):
+		v, err := url.ParseQuery(string(b))
+		if err != nil {
+			return b
+		}
+		return Body(scrubValues(v).Encode())
+	case json.Valid(b):
+		var v any
+		d := json.NewDecoder(bytes.NewReader(b))
+		d.UseNumber()
+		if err := d.Decode(&v); err != nil {
+			return b
+		}
+		if !scrubJSON(v) {
+			return b
+		}
+		s, err := json.Marshal(v)
+		if err != nil {
+			return b
+		}
+		return s
+	default:
+		return b
+	}
+}
+
+// scrubJSON redacts the sensitive keys of v in place, and reports whether it redacted any.
+func scrubJSON(v any) bool {
+	scrubbed := false
+	switch v := v.(type) {
+	case map[string]any:
+		for k, e := range v {
+			if sensitive.Key(k) {
+				v[k] = Redacted
+				scrubbed = true
+				continue
+			}
+			if scrubJSON(e) {
+				scrubbed = true
+			}
+		}
+	case []any:
+		for _, e := range v {
+			if scrubJSON(e) {
+				scrubbed = true
+			}
+		}
+	}
+	return scrubbed
+}
@@ -0,0 +1,238 @@
+package gotwtrtest_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sivchari/gotwtr"
+	"github.com/sivchari/gotwtr/gotwtrtest"
+)
+
+func Test_Recorder_replay(t *testing.T) {
+	t.Parallel()
+	rec, err := gotwtrtest.NewRecorder("testdata/retrieve_single_tweet.json", gotwtrtest.ModeReplay)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := gotwtr.New("key", gotwtr.WithHTTPClient(rec.Client()))
+	got, err := c.RetrieveSingleTweet(context.Background(), "1460323737035677698", &gotwtr.RetriveTweetOption{
+		TweetFields: []gotwtr.TweetField{gotwtr.TweetFieldCreatedAt},
+	})
+	if err != nil {
+		t.Fatalf("client.RetrieveSingleTweet() error = %v", err)
+	}
+	want := &gotwtr.TweetResponse{
+		Tweet: &gotwtr.Tweet{
+			ID:        "1460323737035677698",
+			Text:      "Introducing a new era for the Twitter Developer Platform!",
+			CreatedAt: "2021-11-15T19:08:05.000Z",
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("client.RetrieveSingleTweet() mismatch (-want +got):\n%s", diff)
+	}
+
+	// The interaction is used up, and a request with another query never matched it.
+	_, err = c.RetrieveSingleTweet(context.Background(), "1460323737035677698")
+	if !errors.Is(err, gotwtrtest.ErrNoInteraction) {
+		t.Errorf("client.RetrieveSingleTweet() error = %v, want ErrNoInteraction", err)
+	}
+}
+
+func Test_Recorder_record(t *testing.T) {
+	t.Parallel()
+	var calls int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Set-Cookie", "session=secret-cookie")
+		_, _ = fmt.Fprintf(w, `{"data":{"id":%q,"text":"hello"}}`, strings.TrimPrefix(r.URL.Path, "/2/tweets/"))
+	}))
+	path := filepath.Join(t.TempDir(), "cassette.json")
+
+	rec, err := gotwtrtest.NewRecorder(path, gotwtrtest.ModeReplayOrRecord)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rec.Mode() != gotwtrtest.ModeRecord {
+		t.Fatalf("Mode() = %v, want ModeRecord", rec.Mode())
+	}
+	c := gotwtr.New("secret-bearer-token", gotwtr.WithHTTPClient(rec.Client()), gotwtr.WithBaseURL(srv.URL))
+	for _, id := range []string{"1", "2", "1"} {
+		if _, err := c.RetrieveSingleTweet(context.Background(), id); err != nil {
+			t.Fatalf("client.RetrieveSingleTweet() error = %v", err)
+		}
+	}
+	if err := rec.Stop(); err != nil {
+		t.Fatalf("Stop() error = %v", err)
+	}
+	srv.Close()
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, secret := range []string{"secret-bearer-token", "secret-cookie"} {
+		if strings.Contains(string(b), secret) {
+			t.Errorf("cassette contains %q:\n%s", secret, b)
+		}
+	}
+
+	rec, err = gotwtrtest.NewRecorder(path, gotwtrtest.ModeReplayOrRecord)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rec.Mode() != gotwtrtest.ModeReplay {
+		t.Fatalf("Mode() = %v, want ModeReplay", rec.Mode())
+	}
+	c = gotwtr.New("another-token", gotwtr.WithHTTPClient(rec.Client()), gotwtr.WithBaseURL(srv.URL))
+	for _, id := range []string{"2", "1", "1"} {
+		got, err := c.RetrieveSingleTweet(context.Background(), id)
+		if err != nil {
+			t.Fatalf("client.RetrieveSingleTweet() error = %v", err)
+		}
+		if got.Tweet.ID != id {
+			t.Errorf("client.RetrieveSingleTweet() id = %s, want %s", got.Tweet.ID, id)
+		}
+	}
+	if calls != 3 {
+		t.Errorf("server calls = %d, want 3", calls)
+	}
+}
+
+func Test_Recorder_scrubsTokens(t *testing.T) {
+	t.Parallel()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `{"token_type":"bearer","access_token":"secret-access-token","refresh_token":"secret-refresh-token","expires_in":7200,"scope":"tweet.read"}`)
+	}))
+	defer srv.Close()
+	path := filepath.Join(t.TempDir(), "cassette.json")
+
+	rec, err := gotwtrtest.NewRecorder(path, gotwtrtest.ModeRecord, gotwtrtest.WithScrubber(func(in *gotwtrtest.Interaction) {
+		in.Request.URL = strings.Replace(in.Request.URL, "secret-client", "client", 1)
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := &gotwtr.OAuth2Config{
+		ClientID:     "secret-client",
+		ClientSecret: "secret-client-secret",
+		RedirectURL:  "http://127.0.0.1/callback",
+		TokenURL:     srv.URL + "/2/oauth2/token?client=secret-client",
+		HTTPClient:   rec.Client(),
+	}
+	tok, err := cfg.Exchange(context.Background(), "secret-code", "secret-verifier")
+	if err != nil {
+		t.Fatalf("Exchange() error = %v", err)
+	}
+	if tok.AccessToken != "secret-access-token" {
+		t.Errorf("Exchange() access token = %q, want the recorded one", tok.AccessToken)
+	}
+	if err := rec.Stop(); err != nil {
+		t.Fatalf("Stop() error = %v", err)
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, secret := range []string{"secret-access-token", "secret-refresh-token", "secret-code", "secret-verifier", "secret-client"} {
+		if strings.Contains(string(b), secret) {
+			t.Errorf("cassette contains %q:\n%s", secret, b)
+		}
+	}
+	if !strings.Contains(string(b), gotwtrtest.Redacted) {
+		t.Errorf("cassette has nothing redacted:\n%s", b)
+	}
+}
+
+func Test_NewRecorder_missingCassette(t *testing.T) {
+	t.Parallel()
+	_, err := gotwtrtest.NewRecorder(filepath.Join(t.TempDir(), "missing.json"), gotwtrtest.ModeReplay)
+	if !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("NewRecorder() error = %v, want os.ErrNotExist", err)
+	}
+}
+
+func Test_Recorder_Stop_unclosedBody(t *testing.T) {
+	t.Parallel()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `{"data":{"id":"1","text":"hello"}}`)
+		if strings.HasSuffix(r.URL.Path, "/stream") {
+			if f, ok := w.(http.Flusher); ok {
+				f.Flush()
+			}
+			<-r.Context().Done()
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	tests := []struct {
+		name    string
+		path    string
+		wantErr bool
+	}{
+		{
+			name:    "lookup",
+			path:    "/2/tweets/1",
+			wantErr: false,
+		},
+		{
+			name:    "stream",
+			path:    "/2/tweets/search/stream",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			path := filepath.Join(t.TempDir(), "cassette.json")
+			rec, err := gotwtrtest.NewRecorder(path, gotwtrtest.ModeRecord, gotwtrtest.WithStopTimeout(50*time.Millisecond))
+			if err != nil {
+				t.Fatal(err)
+			}
+			req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, srv.URL+tt.path, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			// The test leaks the body.
+			resp, err := rec.Client().Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			t.Cleanup(func() { _ = resp.Body.Close() })
+
+			err = rec.Stop()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Stop() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				if !strings.Contains(err.Error(), "GET "+srv.URL+tt.path) {
+					t.Errorf("Stop() error = %v, want the request of the unclosed body", err)
+				}
+				return
+			}
+			b, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !strings.Contains(string(b), "hello") {
+				t.Errorf("cassette does not contain the response body:\n%s", b)
+			}
+		})
+	}
+}
@@ -0,0 +1,522 @@
+package gotwtrtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sivchari/gotwtr"
+)
+
+// Server is a fake of the v2 API backed by in-memory state, for integration tests of code using a *gotwtr.Client.
+// It implements the routes of Tweets, timelines, recent search, users, follows, likes, bookmarks, lists,
+// list members, Direct Messages, filtered stream rules and the filtered stream.
+// Writes change the later reads, e.g. a Tweet posted with PostTweet is returned by RetrieveSingleTweet
+// and pushed to the filtered stream connections whose rules match it.
+//
+// Like the real API, lookups of several IDs return the resources found along with errors for the others,
+// and lists are paginated with max_results and opaque pagination tokens.
+// Every request must have an Authorization header, but any credentials are accepted.
+// Fields and expansions are not filtered: every stored field of a resource is returned.
+type Server struct {
+	*httptest.Server
+
+	mu      sync.Mutex
+	nextID  int64
+	me      string
+	users   []*gotwtr.User
+	tweets  []*gotwtr.Tweet
+	follows map[string][]string
+	likes   map[string][]string
+	marks   map[string][]string
+	lists   []*gotwtr.List
+	members map[string][]string
+	convs   map[string][]string
+	dms     []*gotwtr.DirectMessage
+	rules   []*gotwtr.FilteredRule
+	streams map[*streamConn]struct{}
+	routes  []route
+}
+
+// NewServer starts a Server with no data. Call Close once done.
+func NewServer() *Server {
+	s := &Server{
+		nextID:  1_500_000_000_000_000_000,
+		follows: make(map[string][]string),
+		likes:   make(map[string][]string),
+		marks:   make(map[string][]string),
+		members: make(map[string][]string),
+		convs:   make(map[string][]string),
+		streams: make(map[*streamConn]struct{}),
+	}
+	s.routes = s.newRoutes()
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// Close closes the stream connections and shuts down the server.
+func (s *Server) Close() {
+	s.CloseStreams()
+	s.Server.Close()
+}
+
+// Client returns a client of the server authenticated with a fake bearer token.
+func (s *Server) Client(opts ...gotwtr.ClientOption) *gotwtr.Client {
+	opts = append([]gotwtr.ClientOption{
+		gotwtr.WithHTTPClient(s.Server.Client()),
+		gotwtr.WithBaseURL(s.URL),
+	}, opts...)
+	return gotwtr.New("gotwtrtest-bearer-token", opts...)
+}
+
+// AddUser stores the user, and returns it with an ID assigned if it had none.
+// The first user added is the authenticated user, unless SetMe is called.
+func (s *Server) AddUser(u *gotwtr.User) *gotwtr.User {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	u = cloneUser(u)
+	if u.ID == "" {
+		u.ID = s.newID()
+	}
+	if u.CreatedAt == "" {
+		u.CreatedAt = now()
+	}
+	s.users = append(s.users, u)
+	if s.me == "" {
+		s.me = u.ID
+	}
+	return cloneUser(u)
+}
+
+// SetMe sets the authenticated user, whom Me returns and who posts Tweets, Lists and Direct Messages.
+func (s *Server) SetMe(userID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.me = userID
+}
+
+// AddTweet stores the Tweet, and returns it with an ID, creation time and author assigned if it had none.
+// It is not pushed to the filtered stream.
+func (s *Server) AddTweet(t *gotwtr.Tweet) *gotwtr.Tweet {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return cloneTweet(s.addTweet(t))
+}
+
+// PushTweet stores the Tweet like AddTweet, and pushes it to the filtered stream connections if it matches a rule.
+func (s *Server) PushTweet(t *gotwtr.Tweet) *gotwtr.Tweet {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t = s.addTweet(t)
+	s.push(t)
+	return cloneTweet(t)
+}
+
+// Tweet returns the stored Tweet of the ID.
+func (s *Server) Tweet(id string) (*gotwtr.Tweet, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t := s.tweet(id)
+	return cloneTweet(t), t != nil
+}
+
+// StreamConnections returns the number of open filtered stream connections.
+func (s *Server) StreamConnections() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.streams)
+}
+
+// CloseStreams disconnects every filtered stream connection, as the API does on maintenance.
+func (s *Server) CloseStreams() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for c := range s.streams {
+		c.close()
+		delete(s.streams, c)
+	}
+}
+
+func (s *Server) newID() string {
+	s.nextID++
+	return strconv.FormatInt(s.nextID, 10)
+}
+
+func (s *Server) addTweet(t *gotwtr.Tweet) *gotwtr.Tweet {
+	t = cloneTweet(t)
+	if t.ID == "" {
+		t.ID = s.newID()
+	}
+	if t.CreatedAt == "" {
+		t.CreatedAt = now()
+	}
+	if t.AuthorID == "" {
+		t.AuthorID = s.me
+	}
+	if len(t.EditHistoryIDs) == 0 {
+		t.EditHistoryIDs = []string{t.ID}
+	}
+	s.tweets = append(s.tweets, t)
+	return t
+}
+
+func (s *Server) tweet(id string) *gotwtr.Tweet {
+	for _, t := range s.tweets {
+		if t.ID == id {
+			return t
+		}
+	}
+	return nil
+}
+
+func (s *Server) user(id string) *gotwtr.User {
+	for _, u := range s.users {
+		if u.ID == id {
+			return u
+		}
+	}
+	return nil
+}
+
+func (s *Server) userByName(name string) *gotwtr.User {
+	for _, u := range s.users {
+		if strings.EqualFold(u.UserName, name) {
+			return u
+		}
+	}
+	return nil
+}
+
+func (s *Server) list(id string) *gotwtr.List {
+	for _, l := range s.lists {
+		if l.ID == id {
+			return l
+		}
+	}
+	return nil
+}
+
+// newestFirst returns the stored Tweets matching keep, the newest first.
+func (s *Server) newestFirst(keep func(t *gotwtr.Tweet) bool) []*gotwtr.Tweet {
+	var tweets []*gotwtr.Tweet
+	for i := len(s.tweets) - 1; i >= 0; i-- {
+		if keep(s.tweets[i]) {
+			tweets = append(tweets, s.tweets[i])
+		}
+	}
+	return tweets
+}
+
+// tweetsOf returns the stored Tweets of the IDs, skipping deleted ones, the last ID first.
+func (s *Server) tweetsOf(ids []string) []*gotwtr.Tweet {
+	var tweets []*gotwtr.Tweet
+	for i := len(ids) - 1; i >= 0; i-- {
+		if t := s.tweet(ids[i]); t != nil {
+			tweets = append(tweets, t)
+		}
+	}
+	return tweets
+}
+
+// usersOf returns the stored users of the IDs, the last ID first.
+func (s *Server) usersOf(ids []string) []*gotwtr.User {
+	var users []*gotwtr.User
+	for i := len(ids) - 1; i >= 0; i-- {
+		if u := s.user(ids[i]); u != nil {
+			users = append(users, u)
+		}
+	}
+	return users
+}
+
+// matches reports whether the Tweet matches the query of a rule or a search.
+// The query is a space separated list of keywords which must all be in the text, case-insensitively.
+// A keyword prefixed with - must not be in the text, and from:username matches the author.
+func (s *Server) matches(query string, t *gotwtr.Tweet) bool {
+	terms := strings.Fields(query)
+	if len(terms) == 0 {
+		return false
+	}
+	text := strings.ToLower(t.Text)
+	for _, term := range terms {
+		term = strings.ToLower(strings.Trim(term, `"`))
+		switch {
+		case strings.HasPrefix(term, "from:"):
+			u := s.user(t.AuthorID)
+			if u == nil || !strings.EqualFold(u.UserName, strings.TrimPrefix(term, "from:")) {
+				return false
+			}
+		case strings.HasPrefix(term, "-") && len(term) > 1:
+			if strings.Contains(text, term[1:]) {
+				return false
+			}
+		default:
+			if !strings.Contains(text, term) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// route is a method and a path pattern, whose segments starting with : match any segment.
+type route struct {
+	method  string
+	pattern []string
+	handle  func(w http.ResponseWriter, r *http.Request, p params)
+}
+
+type params map[string]string
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Authorization") == "" {
+		writeJSON(w, http.StatusUnauthorized, map[string]any{
+			"title":  "Unauthorized",
+			"type":   "about:blank",
+			"status": http.StatusUnauthorized,
+			"detail": "Unauthorized",
+		})
+		return
+	}
+	segs := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	for _, rt := range s.routes {
+		if rt.method != r.Method || len(rt.pattern) != len(segs) {
+			continue
+		}
+		p := params{}
+		ok := true
+		for i, seg := range rt.pattern {
+			switch {
+			case strings.HasPrefix(seg, ":"):
+				p[seg[1:]] = segs[i]
+			case seg != segs[i]:
+				ok = false
+			}
+			if !ok {
+				break
+			}
+		}
+		if !ok {
+			continue
+		}
+		if rt.pattern[len(rt.pattern)-1] == "stream" {
+			// The stream handler holds the lock only while registering the connection.
+			rt.handle(w, r, p)
+			return
+		}
+		s.mu.Lock()
+		rt.handle(w, r, p)
+		s.mu.Unlock()
+		return
+	}
+	writeJSON(w, http.StatusNotFound, map[string]any{
+		"title":  "Not Found Error",
+		"type":   "about:blank",
+		"status": http.StatusNotFound,
+		"detail": fmt.Sprintf("%s %s is not a route of the fake server", r.Method, r.URL.Path),
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// invalidRequest writes the 400 problem the API returns for an invalid parameter.
+func invalidRequest(w http.ResponseWriter, parameter, value, message string) {
+	writeJSON(w, http.StatusBadRequest, map[string]any{
+		"errors": []map[string]any{{
+			"parameters": map[string][]string{parameter: {value}},
+			"message":    message,
+		}},
+		"title":  "Invalid Request",
+		"detail": "One or more parameters to your request was invalid.",
+		"type":   "https://api.twitter.com/2/problems/invalid-request",
+	})
+}
+
+// writeData writes the data found along with the errors for the resources not found, as lookups do.
+func writeData(w http.ResponseWriter, data any, errs []*apiError) {
+	res := map[string]any{}
+	if v := reflect.ValueOf(data); !v.IsNil() && (v.Kind() != reflect.Slice || v.Len() > 0) {
+		res["data"] = data
+	}
+	if len(errs) > 0 {
+		res["errors"] = errs
+	}
+	writeJSON(w, http.StatusOK, res)
+}
+
+// writeErrors writes the errors-only response the API returns when the requested resource does not exist.
+func writeErrors(w http.ResponseWriter, errs ...*apiError) {
+	writeData(w, []struct{}(nil), errs)
+}
+
+// apiError is an element of errors[], with the fields of a resource-not-found error.
+type apiError struct {
+	Value        string `json:"value"`
+	Detail       string `json:"detail"`
+	Title        string `json:"title"`
+	ResourceType string `json:"resource_type"`
+	Parameter    string `json:"parameter"`
+	ResourceID   string `json:"resource_id"`
+	Type         string `json:"type"`
+}
+
+// notFound returns the error the API reports in errors[] for a resource that does not exist.
+func notFound(resourceType, parameter, value string) *apiError {
+	return &apiError{
+		Value:        value,
+		Detail:       fmt.Sprintf("Could not find %s with %s: [%s].", resourceType, parameter, value),
+		Title:        "Not Found Error",
+		ResourceType: resourceType,
+		Parameter:    parameter,
+		ResourceID:   value,
+		Type:         "https://api.twitter.com/2/problems/resource-not-found",
+	}
+}
+
+// decodeBody decodes the JSON body of r into v, writing a 400 problem if it is invalid.
+func decodeBody(w http.ResponseWriter, r *http.Request, v any) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		invalidRequest(w, "body", "", "The request body is not valid JSON: "+err.Error())
+		return false
+	}
+	return true
+}
+
+// pageSpec is how an endpoint paginates: the name of its token parameter, and the range and default of max_results.
+type pageSpec struct {
+	tokenParam string
+	min, max   int
+	def        int
+}
+
+var (
+	timelinePage = pageSpec{"pagination_token", 5, 100, 10}
+	searchPage   = pageSpec{"next_token", 10, 100, 10}
+	usersPage    = pageSpec{"pagination_token", 1, 1000, 100}
+	itemsPage    = pageSpec{"pagination_token", 1, 100, 100}
+)
+
+// page returns the page of n items requested by r, as the offset and end of the items,
+// and the meta of the page. It writes a 400 problem if a parameter is invalid.
+func page(w http.ResponseWriter, r *http.Request, spec pageSpec, n int) (from, to int, meta map[string]any, ok bool) {
+	q := r.URL.Query()
+	size := spec.def
+	if v := q.Get("max_results"); v != "" {
+		m, err := strconv.Atoi(v)
+		if err != nil || m < spec.min || m > spec.max {
+			invalidRequest(w, "max_results", v, fmt.Sprintf("The `max_results` query parameter value [%s] is not between %d and %d", v, spec.min, spec.max))
+			return 0, 0, nil, false
+		}
+		size = m
+	}
+	if v := q.Get(spec.tokenParam); v != "" {
+		offset, err := parseToken(v)
+		if err != nil || offset > n {
+			invalidRequest(w, spec.tokenParam, v, fmt.Sprintf("The `%s` query parameter value [%s] is not valid", spec.tokenParam, v))
+			return 0, 0, nil, false
+		}
+		from = offset
+	}
+	to = min(from+size, n)
+	meta = map[string]any{"result_count": to - from}
+	if to < n {
+		meta["next_token"] = token(to)
+	}
+	if from > 0 {
+		meta["previous_token"] = token(max(from-size, 0))
+	}
+	return from, to, meta, true
+}
+
+// token encodes the offset of a page as an opaque pagination token.
+func token(offset int) string {
+	return "gt" + strconv.FormatInt(int64(offset)*7919+104729, 36)
+}
+
+func parseToken(tok string) (int, error) {
+	if !strings.HasPrefix(tok, "gt") {
+		return 0, fmt.Errorf("invalid token %q", tok)
+	}
+	v, err := strconv.ParseInt(tok[2:], 36, 64)
+	if err != nil || (v-104729)%7919 != 0 || v < 104729 {
+		return 0, fmt.Errorf("invalid token %q", tok)
+	}
+	return int((v - 104729) / 7919), nil
+}
+
+// tweetsMeta adds the newest and oldest IDs of the page to meta, as timelines and searches report them.
+func tweetsMeta(meta map[string]any, tweets []*gotwtr.Tweet) map[string]any {
+	if len(tweets) > 0 {
+		meta["newest_id"] = tweets[0].ID
+		meta["oldest_id"] = tweets[len(tweets)-1].ID
+	}
+	delete(meta, "previous_token")
+	return meta
+}
+
+// pageResponse writes the page of items with its meta. An empty page has no data, like in the API.
+func pageResponse[T any](w http.ResponseWriter, items []T, meta map[string]any) {
+	res := map[string]any{"meta": meta}
+	if len(items) > 0 {
+		res["data"] = items
+	}
+	writeJSON(w, http.StatusOK, res)
+}
+
+func now() string {
+	return time.Now().UTC().Format("2006-01-02T15:04:05.000Z")
+}
+
+func cloneTweet(t *gotwtr.Tweet) *gotwtr.Tweet {
+	if t == nil {
+		return nil
+	}
+	c := *t
+	c.EditHistoryIDs = append([]string(nil), t.EditHistoryIDs...)
+	return &c
+}
+
+func cloneUser(u *gotwtr.User) *gotwtr.User {
+	if u == nil {
+		return nil
+	}
+	c := *u
+	return &c
+}
+
+// remove returns ids without id, and whether it was in them.
+func remove(ids []string, id string) ([]string, bool) {
+	for i, v := range ids {
+		if v == id {
+			return append(ids[:i:i], ids[i+1:]...), true
+		}
+	}
+	return ids, false
+}
+
+func contains(ids []string, id string) bool {
+	for _, v := range ids {
+		if v == id {
+			return true
+		}
+	}
+	return false
+}
+
+// conversationID returns the ID of the one to one conversation of two users, which is the same for both of them.
+func conversationID(a, b string) string {
+	ids := []string{a, b}
+	sort.Strings(ids)
+	return ids[0] + "-" + ids[1]
+}
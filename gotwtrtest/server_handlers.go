@@ -0,0 +1,816 @@
+package gotwtrtest
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/sivchari/gotwtr"
+)
+
+func (s *Server) newRoutes() []route {
+	r := func(method, pattern string, handle func(w http.ResponseWriter, r *http.Request, p params)) route {
+		return route{method: method, pattern: strings.Split(strings.Trim(pattern, "/"), "/"), handle: handle}
+	}
+	// Literal routes come before the routes with a parameter in their place.
+	return []route{
+		// Tweets
+		r(http.MethodGet, "/2/tweets", s.lookUpTweets),
+		r(http.MethodPost, "/2/tweets", s.postTweet),
+		r(http.MethodGet, "/2/tweets/search/recent", s.searchRecentTweets),
+		r(http.MethodGet, "/2/tweets/search/stream/rules", s.retrieveStreamRules),
+		r(http.MethodPost, "/2/tweets/search/stream/rules", s.addOrDeleteRules),
+		r(http.MethodGet, "/2/tweets/search/stream", s.connectToStream),
+		r(http.MethodGet, "/2/tweets/:id", s.lookUpTweet),
+		r(http.MethodDelete, "/2/tweets/:id", s.deleteTweet),
+		r(http.MethodGet, "/2/tweets/:id/liking_users", s.likingUsers),
+		// Users
+		r(http.MethodGet, "/2/users", s.lookUpUsers),
+		r(http.MethodGet, "/2/users/me", s.lookUpMe),
+		r(http.MethodGet, "/2/users/by", s.lookUpUsersByName),
+		r(http.MethodGet, "/2/users/by/username/:username", s.lookUpUserByName),
+		r(http.MethodGet, "/2/users/:id", s.lookUpUser),
+		// Timelines
+		r(http.MethodGet, "/2/users/:id/tweets", s.userTweets),
+		r(http.MethodGet, "/2/users/:id/mentions", s.userMentions),
+		r(http.MethodGet, "/2/users/:id/timelines/reverse_chronological", s.homeTimeline),
+		// Follows
+		r(http.MethodGet, "/2/users/:id/following", s.following),
+		r(http.MethodPost, "/2/users/:id/following", s.postFollowing),
+		r(http.MethodDelete, "/2/users/:id/following/:target", s.undoFollowing),
+		r(http.MethodGet, "/2/users/:id/followers", s.followers),
+		// Likes
+		r(http.MethodGet, "/2/users/:id/liked_tweets", s.likedTweets),
+		r(http.MethodPost, "/2/users/:id/likes", s.postLike),
+		r(http.MethodDelete, "/2/users/:id/likes/:tweet", s.undoLike),
+		// Bookmarks
+		r(http.MethodGet, "/2/users/:id/bookmarks", s.bookmarks),
+		r(http.MethodPost, "/2/users/:id/bookmarks", s.postBookmark),
+		r(http.MethodDelete, "/2/users/:id/bookmarks/:tweet", s.removeBookmark),
+		// Lists
+		r(http.MethodPost, "/2/lists", s.createList),
+		r(http.MethodGet, "/2/lists/:id", s.lookUpList),
+		r(http.MethodPut, "/2/lists/:id", s.updateList),
+		r(http.MethodDelete, "/2/lists/:id", s.deleteList),
+		r(http.MethodGet, "/2/users/:id/owned_lists", s.ownedLists),
+		r(http.MethodGet, "/2/lists/:id/tweets", s.listTweets),
+		// List members
+		r(http.MethodGet, "/2/lists/:id/members", s.listMembers),
+		r(http.MethodPost, "/2/lists/:id/members", s.postListMember),
+		r(http.MethodDelete, "/2/lists/:id/members/:user", s.undoListMember),
+		r(http.MethodGet, "/2/users/:id/list_memberships", s.listMemberships),
+		// Direct Messages
+		r(http.MethodPost, "/2/dm_conversations", s.postDM),
+		r(http.MethodPost, "/2/dm_conversations/with/:participant/messages", s.createOneToOneDM),
+		r(http.MethodPost, "/2/dm_conversations/:conversation/messages", s.createGroupDM),
+		r(http.MethodGet, "/2/dm_conversations/with/:participant/dm_events", s.oneToOneDMEvents),
+		r(http.MethodGet, "/2/dm_conversations/:conversation/dm_events", s.conversationDMEvents),
+		r(http.MethodGet, "/2/dm_events", s.dmEvents),
+	}
+}
+
+// Tweets
+
+func (s *Server) lookUpTweets(w http.ResponseWriter, r *http.Request, _ params) {
+	ids := r.URL.Query().Get("ids")
+	if ids == "" {
+		invalidRequest(w, "ids", "", "The `ids` query parameter can not be empty")
+		return
+	}
+	var (
+		tweets []*gotwtr.Tweet
+		errs   []*apiError
+	)
+	for _, id := range strings.Split(ids, ",") {
+		if t := s.tweet(id); t != nil {
+			tweets = append(tweets, t)
+		} else {
+			errs = append(errs, notFound("tweet", "ids", id))
+		}
+	}
+	writeData(w, tweets, errs)
+}
+
+func (s *Server) lookUpTweet(w http.ResponseWriter, _ *http.Request, p params) {
+	t := s.tweet(p["id"])
+	if t == nil {
+		writeErrors(w, notFound("tweet", "id", p["id"]))
+		return
+	}
+	writeData(w, t, nil)
+}
+
+func (s *Server) postTweet(w http.ResponseWriter, r *http.Request, _ params) {
+	var body gotwtr.PostTweetOption
+	if !decodeBody(w, r, &body) {
+		return
+	}
+	if body.Text == "" && body.Media == nil && body.Poll == nil && body.QuoteTweetID == "" {
+		invalidRequest(w, "text", "", "The `text` field is required when no media, poll or quote is attached")
+		return
+	}
+	t := &gotwtr.Tweet{Text: body.Text}
+	if body.Reply != nil && body.Reply.InReplyToTweetID != "" {
+		parent := s.tweet(body.Reply.InReplyToTweetID)
+		if parent == nil {
+			invalidRequest(w, "reply.in_reply_to_tweet_id", body.Reply.InReplyToTweetID, "The Tweet to reply to does not exist")
+			return
+		}
+		t.InReplyToUserID = parent.AuthorID
+		t.ConversationID = parent.ConversationID
+		t.ReferencedTweets = []*gotwtr.TweetReferencedTweet{{Type: "replied_to", ID: parent.ID}}
+	}
+	if body.QuoteTweetID != "" {
+		t.ReferencedTweets = append(t.ReferencedTweets, &gotwtr.TweetReferencedTweet{Type: "quoted", ID: body.QuoteTweetID})
+	}
+	t = s.addTweet(t)
+	if t.ConversationID == "" {
+		t.ConversationID = t.ID
+	}
+	s.push(t)
+	writeJSON(w, http.StatusCreated, map[string]any{"data": map[string]any{
+		"id":                     t.ID,
+		"text":                   t.Text,
+		"edit_history_tweet_ids": t.EditHistoryIDs,
+	}})
+}
+
+func (s *Server) deleteTweet(w http.ResponseWriter, _ *http.Request, p params) {
+	deleted := false
+	for i, t := range s.tweets {
+		if t.ID == p["id"] {
+			s.tweets = append(s.tweets[:i:i], s.tweets[i+1:]...)
+			deleted = true
+			break
+		}
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"data": map[string]bool{"deleted": deleted}})
+}
+
+func (s *Server) searchRecentTweets(w http.ResponseWriter, r *http.Request, _ params) {
+	query := r.URL.Query().Get("query")
+	if query == "" {
+		invalidRequest(w, "query", "", "The `query` query parameter can not be empty")
+		return
+	}
+	tweets := s.newestFirst(func(t *gotwtr.Tweet) bool { return s.matches(query, t) })
+	from, to, meta, ok := page(w, r, searchPage, len(tweets))
+	if !ok {
+		return
+	}
+	pageResponse(w, tweets[from:to], tweetsMeta(meta, tweets[from:to]))
+}
+
+func (s *Server) likingUsers(w http.ResponseWriter, r *http.Request, p params) {
+	var ids []string
+	for _, u := range s.users {
+		if contains(s.likes[u.ID], p["id"]) {
+			ids = append(ids, u.ID)
+		}
+	}
+	s.usersPage(w, r, usersPage, s.usersOf(ids))
+}
+
+// Users
+
+func (s *Server) lookUpUsers(w http.ResponseWriter, r *http.Request, _ params) {
+	ids := r.URL.Query().Get("ids")
+	if ids == "" {
+		invalidRequest(w, "ids", "", "The `ids` query parameter can not be empty")
+		return
+	}
+	var (
+		users []*gotwtr.User
+		errs  []*apiError
+	)
+	for _, id := range strings.Split(ids, ",") {
+		if u := s.user(id); u != nil {
+			users = append(users, u)
+		} else {
+			errs = append(errs, notFound("user", "ids", id))
+		}
+	}
+	writeData(w, users, errs)
+}
+
+func (s *Server) lookUpUsersByName(w http.ResponseWriter, r *http.Request, _ params) {
+	names := r.URL.Query().Get("usernames")
+	if names == "" {
+		invalidRequest(w, "usernames", "", "The `usernames` query parameter can not be empty")
+		return
+	}
+	var (
+		users []*gotwtr.User
+		errs  []*apiError
+	)
+	for _, name := range strings.Split(names, ",") {
+		if u := s.userByName(name); u != nil {
+			users = append(users, u)
+		} else {
+			errs = append(errs, notFound("user", "usernames", name))
+		}
+	}
+	writeData(w, users, errs)
+}
+
+func (s *Server) lookUpUser(w http.ResponseWriter, _ *http.Request, p params) {
+	s.writeUser(w, s.user(p["id"]), "id", p["id"])
+}
+
+func (s *Server) lookUpUserByName(w http.ResponseWriter, _ *http.Request, p params) {
+	s.writeUser(w, s.userByName(p["username"]), "username", p["username"])
+}
+
+func (s *Server) writeUser(w http.ResponseWriter, u *gotwtr.User, parameter, value string) {
+	if u == nil {
+		writeErrors(w, notFound("user", parameter, value))
+		return
+	}
+	writeData(w, u, nil)
+}
+
+func (s *Server) lookUpMe(w http.ResponseWriter, _ *http.Request, _ params) {
+	u := s.user(s.me)
+	if u == nil {
+		writeJSON(w, http.StatusUnauthorized, map[string]any{
+			"title":  "Unauthorized",
+			"type":   "about:blank",
+			"status": http.StatusUnauthorized,
+			"detail": "The fake server has no authenticated user; call AddUser or SetMe first",
+		})
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"data": u})
+}
+
+// usersPage writes the page of users requested by r.
+func (s *Server) usersPage(w http.ResponseWriter, r *http.Request, spec pageSpec, users []*gotwtr.User) {
+	from, to, meta, ok := page(w, r, spec, len(users))
+	if !ok {
+		return
+	}
+	pageResponse(w, users[from:to], meta)
+}
+
+// tweetsPage writes the page of Tweets requested by r.
+func (s *Server) tweetsPage(w http.ResponseWriter, r *http.Request, spec pageSpec, tweets []*gotwtr.Tweet) {
+	from, to, meta, ok := page(w, r, spec, len(tweets))
+	if !ok {
+		return
+	}
+	pageResponse(w, tweets[from:to], meta)
+}
+
+// userOrError reports whether the user of the path exists, writing the errors-only response of the API if not.
+func (s *Server) userOrError(w http.ResponseWriter, id string) bool {
+	if s.user(id) != nil {
+		return true
+	}
+	writeErrors(w, notFound("user", "id", id))
+	return false
+}
+
+// Timelines
+
+func (s *Server) userTweets(w http.ResponseWriter, r *http.Request, p params) {
+	if !s.userOrError(w, p["id"]) {
+		return
+	}
+	tweets := s.newestFirst(func(t *gotwtr.Tweet) bool { return t.AuthorID == p["id"] })
+	s.timelinePage(w, r, tweets)
+}
+
+func (s *Server) userMentions(w http.ResponseWriter, r *http.Request, p params) {
+	if !s.userOrError(w, p["id"]) {
+		return
+	}
+	mention := "@" + strings.ToLower(s.user(p["id"]).UserName)
+	tweets := s.newestFirst(func(t *gotwtr.Tweet) bool {
+		return strings.Contains(strings.ToLower(t.Text), mention)
+	})
+	s.timelinePage(w, r, tweets)
+}
+
+func (s *Server) homeTimeline(w http.ResponseWriter, r *http.Request, p params) {
+	if !s.userOrError(w, p["id"]) {
+		return
+	}
+	following := s.follows[p["id"]]
+	tweets := s.newestFirst(func(t *gotwtr.Tweet) bool {
+		return t.AuthorID == p["id"] || contains(following, t.AuthorID)
+	})
+	s.timelinePage(w, r, tweets)
+}
+
+func (s *Server) timelinePage(w http.ResponseWriter, r *http.Request, tweets []*gotwtr.Tweet) {
+	from, to, meta, ok := page(w, r, timelinePage, len(tweets))
+	if !ok {
+		return
+	}
+	pageResponse(w, tweets[from:to], tweetsMeta(meta, tweets[from:to]))
+}
+
+// Follows
+
+func (s *Server) following(w http.ResponseWriter, r *http.Request, p params) {
+	if !s.userOrError(w, p["id"]) {
+		return
+	}
+	s.usersPage(w, r, usersPage, s.usersOf(s.follows[p["id"]]))
+}
+
+func (s *Server) followers(w http.ResponseWriter, r *http.Request, p params) {
+	if !s.userOrError(w, p["id"]) {
+		return
+	}
+	var users []*gotwtr.User
+	for i := len(s.users) - 1; i >= 0; i-- {
+		if contains(s.follows[s.users[i].ID], p["id"]) {
+			users = append(users, s.users[i])
+		}
+	}
+	s.usersPage(w, r, usersPage, users)
+}
+
+func (s *Server) postFollowing(w http.ResponseWriter, r *http.Request, p params) {
+	var body gotwtr.FollowingBody
+	if !decodeBody(w, r, &body) || !s.userOrError(w, p["id"]) {
+		return
+	}
+	if s.user(body.TargetUserID) == nil {
+		writeErrors(w, notFound("user", "target_user_id", body.TargetUserID))
+		return
+	}
+	if !contains(s.follows[p["id"]], body.TargetUserID) {
+		s.follows[p["id"]] = append(s.follows[p["id"]], body.TargetUserID)
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"data": &gotwtr.Following{Following: true}})
+}
+
+func (s *Server) undoFollowing(w http.ResponseWriter, _ *http.Request, p params) {
+	if !s.userOrError(w, p["id"]) {
+		return
+	}
+	s.follows[p["id"]], _ = remove(s.follows[p["id"]], p["target"])
+	writeJSON(w, http.StatusOK, map[string]any{"data": &gotwtr.Following{Following: false}})
+}
+
+// Likes
+
+func (s *Server) likedTweets(w http.ResponseWriter, r *http.Request, p params) {
+	if !s.userOrError(w, p["id"]) {
+		return
+	}
+	s.tweetsPage(w, r, itemsPage, s.tweetsOf(s.likes[p["id"]]))
+}
+
+func (s *Server) postLike(w http.ResponseWriter, r *http.Request, p params) {
+	var body gotwtr.UsersLikingBody
+	if !decodeBody(w, r, &body) || !s.userOrError(w, p["id"]) {
+		return
+	}
+	if s.tweet(body.TweetID) == nil {
+		writeErrors(w, notFound("tweet", "tweet_id", body.TweetID))
+		return
+	}
+	if !contains(s.likes[p["id"]], body.TweetID) {
+		s.likes[p["id"]] = append(s.likes[p["id"]], body.TweetID)
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"data": &gotwtr.Liked{Liked: true}})
+}
+
+func (s *Server) undoLike(w http.ResponseWriter, _ *http.Request, p params) {
+	if !s.userOrError(w, p["id"]) {
+		return
+	}
+	s.likes[p["id"]], _ = remove(s.likes[p["id"]], p["tweet"])
+	writeJSON(w, http.StatusOK, map[string]any{"data": &gotwtr.Liked{Liked: false}})
+}
+
+// Bookmarks
+
+func (s *Server) bookmarks(w http.ResponseWriter, r *http.Request, p params) {
+	if !s.userOrError(w, p["id"]) {
+		return
+	}
+	s.tweetsPage(w, r, itemsPage, s.tweetsOf(s.marks[p["id"]]))
+}
+
+func (s *Server) postBookmark(w http.ResponseWriter, r *http.Request, p params) {
+	var body gotwtr.BookmarkTweetBody
+	if !decodeBody(w, r, &body) || !s.userOrError(w, p["id"]) {
+		return
+	}
+	if s.tweet(body.TweetID) == nil {
+		writeErrors(w, notFound("tweet", "tweet_id", body.TweetID))
+		return
+	}
+	if !contains(s.marks[p["id"]], body.TweetID) {
+		s.marks[p["id"]] = append(s.marks[p["id"]], body.TweetID)
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"data": map[string]bool{"bookmarked": true}})
+}
+
+func (s *Server) removeBookmark(w http.ResponseWriter, _ *http.Request, p params) {
+	if !s.userOrError(w, p["id"]) {
+		return
+	}
+	s.marks[p["id"]], _ = remove(s.marks[p["id"]], p["tweet"])
+	writeJSON(w, http.StatusOK, map[string]any{"data": map[string]bool{"bookmarked": false}})
+}
+
+// Lists
+
+// listOrError reports whether the list of the path exists, writing the errors-only response of the API if not.
+func (s *Server) listOrError(w http.ResponseWriter, id string) bool {
+	if s.list(id) != nil {
+		return true
+	}
+	writeErrors(w, notFound("list", "id", id))
+	return false
+}
+
+func (s *Server) createList(w http.ResponseWriter, r *http.Request, _ params) {
+	var body gotwtr.CreateNewListBody
+	if !decodeBody(w, r, &body) {
+		return
+	}
+	if body.Name == "" {
+		invalidRequest(w, "name", "", "The `name` field is required")
+		return
+	}
+	l := &gotwtr.List{
+		ID:          s.newID(),
+		Name:        body.Name,
+		Description: body.Description,
+		Private:     body.Private,
+		OwnerID:     s.me,
+		CreatedAt:   now(),
+	}
+	s.lists = append(s.lists, l)
+	writeJSON(w, http.StatusCreated, map[string]any{"data": &gotwtr.CreateNewListData{ID: l.ID, Name: l.Name}})
+}
+
+func (s *Server) lookUpList(w http.ResponseWriter, _ *http.Request, p params) {
+	l := s.list(p["id"])
+	if l == nil {
+		writeErrors(w, notFound("list", "id", p["id"]))
+		return
+	}
+	writeData(w, l, nil)
+}
+
+func (s *Server) updateList(w http.ResponseWriter, r *http.Request, p params) {
+	var body gotwtr.UpdateMetaDataForListBody
+	if !decodeBody(w, r, &body) || !s.listOrError(w, p["id"]) {
+		return
+	}
+	l := s.list(p["id"])
+	if body.Name != "" {
+		l.Name = body.Name
+	}
+	if body.Description != "" {
+		l.Description = body.Description
+	}
+	l.Private = body.Private
+	writeJSON(w, http.StatusOK, map[string]any{"data": &gotwtr.UpdateMetaDataForListData{Updated: true}})
+}
+
+func (s *Server) deleteList(w http.ResponseWriter, _ *http.Request, p params) {
+	deleted := false
+	for i, l := range s.lists {
+		if l.ID == p["id"] {
+			s.lists = append(s.lists[:i:i], s.lists[i+1:]...)
+			delete(s.members, l.ID)
+			deleted = true
+			break
+		}
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"data": &gotwtr.DeleteListData{Deleted: deleted}})
+}
+
+func (s *Server) ownedLists(w http.ResponseWriter, r *http.Request, p params) {
+	if !s.userOrError(w, p["id"]) {
+		return
+	}
+	var lists []*gotwtr.List
+	for i := len(s.lists) - 1; i >= 0; i-- {
+		if s.lists[i].OwnerID == p["id"] {
+			lists = append(lists, s.lists[i])
+		}
+	}
+	s.listsPage(w, r, lists)
+}
+
+func (s *Server) listTweets(w http.ResponseWriter, r *http.Request, p params) {
+	if !s.listOrError(w, p["id"]) {
+		return
+	}
+	members := s.members[p["id"]]
+	tweets := s.newestFirst(func(t *gotwtr.Tweet) bool { return contains(members, t.AuthorID) })
+	s.tweetsPage(w, r, itemsPage, tweets)
+}
+
+func (s *Server) listsPage(w http.ResponseWriter, r *http.Request, lists []*gotwtr.List) {
+	from, to, meta, ok := page(w, r, itemsPage, len(lists))
+	if !ok {
+		return
+	}
+	pageResponse(w, lists[from:to], meta)
+}
+
+// List members
+
+func (s *Server) listMembers(w http.ResponseWriter, r *http.Request, p params) {
+	if !s.listOrError(w, p["id"]) {
+		return
+	}
+	s.usersPage(w, r, itemsPage, s.usersOf(s.members[p["id"]]))
+}
+
+func (s *Server) postListMember(w http.ResponseWriter, r *http.Request, p params) {
+	var body gotwtr.ListMembersBody
+	if !decodeBody(w, r, &body) || !s.listOrError(w, p["id"]) {
+		return
+	}
+	if s.user(body.UserID) == nil {
+		writeErrors(w, notFound("user", "user_id", body.UserID))
+		return
+	}
+	if !contains(s.members[p["id"]], body.UserID) {
+		s.members[p["id"]] = append(s.members[p["id"]], body.UserID)
+		s.list(p["id"]).MemberCount++
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"data": &gotwtr.IsMember{IsMember: true}})
+}
+
+func (s *Server) undoListMember(w http.ResponseWriter, _ *http.Request, p params) {
+	if !s.listOrError(w, p["id"]) {
+		return
+	}
+	var removed bool
+	if s.members[p["id"]], removed = remove(s.members[p["id"]], p["user"]); removed {
+		s.list(p["id"]).MemberCount--
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"data": &gotwtr.IsMember{IsMember: false}})
+}
+
+func (s *Server) listMemberships(w http.ResponseWriter, r *http.Request, p params) {
+	if !s.userOrError(w, p["id"]) {
+		return
+	}
+	var lists []*gotwtr.List
+	for i := len(s.lists) - 1; i >= 0; i-- {
+		if contains(s.members[s.lists[i].ID], p["id"]) {
+			lists = append(lists, s.lists[i])
+		}
+	}
+	s.listsPage(w, r, lists)
+}
+
+// Direct Messages
+
+// sendDM stores a message of the authenticated user to the conversation, and writes the created response.
+func (s *Server) sendDM(w http.ResponseWriter, conversation, text string) {
+	dm := &gotwtr.DirectMessage{
+		ID:               s.newID(),
+		EventType:        string(gotwtr.EventTypesFieldMessageCreate),
+		Text:             text,
+		SenderID:         s.me,
+		DMConversationID: conversation,
+		CreatedAt:        now(),
+	}
+	s.dms = append(s.dms, dm)
+	// The client decodes the IDs at the top level of the response.
+	writeJSON(w, http.StatusCreated, &gotwtr.CreateOneToOneDMResponse{DMConversationID: conversation, DMEventFieldID: dm.ID})
+}
+
+func (s *Server) createOneToOneDM(w http.ResponseWriter, r *http.Request, p params) {
+	var body gotwtr.CreateOneToOneDMBody
+	if !decodeBody(w, r, &body) || !s.userOrError(w, p["participant"]) {
+		return
+	}
+	if body.Text == "" && len(body.Attachments) == 0 {
+		invalidRequest(w, "text", "", "The `text` field is required when no attachment is given")
+		return
+	}
+	id := conversationID(s.me, p["participant"])
+	s.convs[id] = []string{s.me, p["participant"]}
+	s.sendDM(w, id, body.Text)
+}
+
+func (s *Server) createGroupDM(w http.ResponseWriter, r *http.Request, p params) {
+	var body gotwtr.CreateNewGroupDMBody
+	if !decodeBody(w, r, &body) {
+		return
+	}
+	if _, ok := s.convs[p["conversation"]]; !ok {
+		writeErrors(w, notFound("dm_conversation", "dm_conversation_id", p["conversation"]))
+		return
+	}
+	s.sendDM(w, p["conversation"], body.Text)
+}
+
+func (s *Server) postDM(w http.ResponseWriter, r *http.Request, _ params) {
+	var body gotwtr.PostDMBody
+	if !decodeBody(w, r, &body) {
+		return
+	}
+	if len(body.ParticipantIDs) == 0 {
+		invalidRequest(w, "participant_ids", "", "The `participant_ids` field is required")
+		return
+	}
+	id := s.newID()
+	s.convs[id] = append([]string{s.me}, body.ParticipantIDs...)
+	var text string
+	if body.Message != nil {
+		text = body.Message.Text
+	}
+	s.sendDM(w, id, text)
+}
+
+func (s *Server) oneToOneDMEvents(w http.ResponseWriter, r *http.Request, p params) {
+	s.dmEventsOf(w, r, func(dm *gotwtr.DirectMessage) bool {
+		return dm.DMConversationID == conversationID(s.me, p["participant"])
+	})
+}
+
+func (s *Server) conversationDMEvents(w http.ResponseWriter, r *http.Request, p params) {
+	s.dmEventsOf(w, r, func(dm *gotwtr.DirectMessage) bool { return dm.DMConversationID == p["conversation"] })
+}
+
+func (s *Server) dmEvents(w http.ResponseWriter, r *http.Request, _ params) {
+	s.dmEventsOf(w, r, func(dm *gotwtr.DirectMessage) bool { return contains(s.convs[dm.DMConversationID], s.me) })
+}
+
+// dmEventsOf writes the page of the messages matching keep, the newest first.
+func (s *Server) dmEventsOf(w http.ResponseWriter, r *http.Request, keep func(dm *gotwtr.DirectMessage) bool) {
+	var dms []*gotwtr.DirectMessage
+	for i := len(s.dms) - 1; i >= 0; i-- {
+		if keep(s.dms[i]) {
+			dms = append(dms, s.dms[i])
+		}
+	}
+	from, to, meta, ok := page(w, r, itemsPage, len(dms))
+	if !ok {
+		return
+	}
+	pageResponse(w, dms[from:to], meta)
+}
+
+// Filtered stream
+
+func (s *Server) retrieveStreamRules(w http.ResponseWriter, r *http.Request, _ params) {
+	rules := s.rules
+	if ids := r.URL.Query().Get("ids"); ids != "" {
+		rules = nil
+		for _, rule := range s.rules {
+			if contains(strings.Split(ids, ","), rule.ID) {
+				rules = append(rules, rule)
+			}
+		}
+	}
+	res := map[string]any{"meta": map[string]any{"sent": now(), "result_count": len(rules)}}
+	if len(rules) > 0 {
+		res["data"] = rules
+	}
+	writeJSON(w, http.StatusOK, res)
+}
+
+func (s *Server) addOrDeleteRules(w http.ResponseWriter, r *http.Request, _ params) {
+	var body gotwtr.AddOrDeleteJSONBody
+	if !decodeBody(w, r, &body) {
+		return
+	}
+	dryRun := r.URL.Query().Get("dry_run") == "true"
+	summary := &gotwtr.AddOrDeleteMetaSummary{}
+	var (
+		data []*gotwtr.FilteredRule
+		errs []map[string]any
+	)
+	if body.Delete != nil {
+		for _, id := range body.Delete.IDs {
+			found := false
+			for i, rule := range s.rules {
+				if rule.ID == id {
+					if !dryRun {
+						s.rules = append(s.rules[:i:i], s.rules[i+1:]...)
+					}
+					found = true
+					break
+				}
+			}
+			if found {
+				summary.Deleted++
+			} else {
+				summary.NotDeleted++
+				errs = append(errs, map[string]any{"errors": []map[string]string{{"message": "Rule does not exist"}}, "id": id, "title": "Not Found Error", "type": "https://api.twitter.com/2/problems/resource-not-found"})
+			}
+		}
+	}
+	for _, add := range body.Add {
+		var dup *gotwtr.FilteredRule
+		for _, rule := range s.rules {
+			if rule.Value == add.Value {
+				dup = rule
+			}
+		}
+		if dup != nil {
+			summary.NotCreated++
+			summary.Invalid++
+			errs = append(errs, map[string]any{"value": add.Value, "id": dup.ID, "title": "DuplicateRule", "type": "https://api.twitter.com/2/problems/duplicate-rules"})
+			continue
+		}
+		rule := &gotwtr.FilteredRule{ID: s.newID(), Value: add.Value, Tag: add.Tag}
+		if !dryRun {
+			s.rules = append(s.rules, rule)
+		}
+		data = append(data, rule)
+		summary.Created++
+		summary.Valid++
+	}
+	res := map[string]any{"meta": &gotwtr.AddOrDeleteRulesMeta{Sent: now(), Summary: summary}}
+	if len(data) > 0 {
+		res["data"] = data
+	}
+	if len(errs) > 0 {
+		res["errors"] = errs
+	}
+	status := http.StatusOK
+	if len(body.Add) > 0 {
+		status = http.StatusCreated
+	}
+	writeJSON(w, status, res)
+}
+
+// streamConn is an open filtered stream connection.
+type streamConn struct {
+	msgs chan []byte
+	done chan struct{}
+	once sync.Once
+}
+
+func (c *streamConn) close() {
+	c.once.Do(func() { close(c.done) })
+}
+
+// streamBuffer is the number of messages buffered for a connection. Messages are dropped while it is full.
+const streamBuffer = 1024
+
+func (s *Server) connectToStream(w http.ResponseWriter, r *http.Request, _ params) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+	c := &streamConn{msgs: make(chan []byte, streamBuffer), done: make(chan struct{})}
+	s.mu.Lock()
+	s.streams[c] = struct{}{}
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		delete(s.streams, c)
+		s.mu.Unlock()
+	}()
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-c.done:
+			return
+		case msg := <-c.msgs:
+			if _, err := w.Write(append(msg, '\r', '\n')); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+// push sends the Tweet to the stream connections if it matches a rule.
+func (s *Server) push(t *gotwtr.Tweet) {
+	var matching []*gotwtr.MatchingRule
+	for _, rule := range s.rules {
+		if s.matches(rule.Value, t) {
+			matching = append(matching, &gotwtr.MatchingRule{ID: rule.ID, Tag: rule.Tag})
+		}
+	}
+	if len(matching) == 0 {
+		return
+	}
+	msg, err := json.Marshal(&gotwtr.ConnectToStreamResponse{Tweet: t, MatchingRules: matching})
+	if err != nil {
+		return
+	}
+	for c := range s.streams {
+		select {
+		case c.msgs <- msg:
+		default:
+		}
+	}
+}
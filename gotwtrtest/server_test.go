@@ -0,0 +1,300 @@
+package gotwtrtest_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sivchari/gotwtr"
+	"github.com/sivchari/gotwtr/gotwtrtest"
+)
+
+func newServer(t *testing.T) (srv *gotwtrtest.Server, alice, bob *gotwtr.User) {
+	t.Helper()
+	srv = gotwtrtest.NewServer()
+	t.Cleanup(srv.Close)
+	alice = srv.AddUser(&gotwtr.User{Name: "Alice", UserName: "alice"})
+	bob = srv.AddUser(&gotwtr.User{Name: "Bob", UserName: "bob"})
+	return srv, alice, bob
+}
+
+func Test_Server_tweets(t *testing.T) {
+	t.Parallel()
+	srv, alice, _ := newServer(t)
+	c := srv.Client()
+	ctx := context.Background()
+
+	posted, err := c.PostTweet(ctx, &gotwtr.PostTweetOption{Text: "hello gotwtr"})
+	if err != nil {
+		t.Fatalf("PostTweet() error = %v", err)
+	}
+	id := posted.PostTweetData.ID
+	got, err := c.RetrieveSingleTweet(ctx, id)
+	if err != nil {
+		t.Fatalf("RetrieveSingleTweet() error = %v", err)
+	}
+	if got.Tweet.Text != "hello gotwtr" || got.Tweet.AuthorID != alice.ID {
+		t.Errorf("RetrieveSingleTweet() = %+v, want the posted tweet of alice", got.Tweet)
+	}
+
+	multi, err := c.RetrieveMultipleTweets(ctx, []string{id, "404"})
+	if err != nil {
+		t.Fatalf("RetrieveMultipleTweets() error = %v", err)
+	}
+	if len(multi.Tweets) != 1 || multi.Tweets[0].ID != id {
+		t.Errorf("RetrieveMultipleTweets() tweets = %+v, want the posted tweet", multi.Tweets)
+	}
+	if len(multi.Errors) != 1 || multi.Errors[0].ResourceID != "404" || multi.Errors[0].Title != "Not Found Error" {
+		t.Errorf("RetrieveMultipleTweets() errors = %+v, want not found 404", multi.Errors)
+	}
+
+	if _, err := c.DeleteTweet(ctx, id); err != nil {
+		t.Fatalf("DeleteTweet() error = %v", err)
+	}
+	got, err = c.RetrieveSingleTweet(ctx, id)
+	if err != nil {
+		t.Fatalf("RetrieveSingleTweet() error = %v", err)
+	}
+	if got.Tweet != nil || len(got.Errors) != 1 {
+		t.Errorf("RetrieveSingleTweet() = %+v, want only a not found error", got)
+	}
+}
+
+func Test_Server_pagination(t *testing.T) {
+	t.Parallel()
+	srv, alice, _ := newServer(t)
+	c := srv.Client()
+	ctx := context.Background()
+	var want []string
+	for i := 0; i < 25; i++ {
+		want = append([]string{srv.AddTweet(&gotwtr.Tweet{Text: "tweet"}).ID}, want...)
+	}
+
+	var (
+		got   []string
+		pages int
+	)
+	p := gotwtr.NewUserTweetTimelinePager(c, alice.ID, &gotwtr.UserTweetTimelineOption{MaxResults: 10})
+	for p.HasNext() {
+		page, err := p.Next(ctx)
+		if err != nil {
+			t.Fatalf("Pager.Next() error = %v", err)
+		}
+		pages++
+		for _, tw := range page.Tweets {
+			got = append(got, tw.ID)
+		}
+	}
+	if pages != 3 {
+		t.Errorf("pages = %d, want 3", pages)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("timeline mismatch (-want +got):\n%s", diff)
+	}
+
+	_, err := c.UserTweetTimeline(ctx, alice.ID, &gotwtr.UserTweetTimelineOption{PaginationToken: "bogus"})
+	if !errors.Is(err, gotwtr.ErrBadRequest) {
+		t.Errorf("UserTweetTimeline() error = %v, want ErrBadRequest", err)
+	}
+}
+
+func Test_Server_follows(t *testing.T) {
+	t.Parallel()
+	srv, alice, bob := newServer(t)
+	c := srv.Client()
+	ctx := context.Background()
+
+	if _, err := c.PostFollowing(ctx, alice.ID, bob.ID); err != nil {
+		t.Fatalf("PostFollowing() error = %v", err)
+	}
+	following, err := c.Following(ctx, alice.ID)
+	if err != nil {
+		t.Fatalf("Following() error = %v", err)
+	}
+	if len(following.Users) != 1 || following.Users[0].ID != bob.ID || following.Meta.ResultCount != 1 {
+		t.Errorf("Following() = %+v, want bob", following)
+	}
+	followers, err := c.Followers(ctx, bob.ID)
+	if err != nil {
+		t.Fatalf("Followers() error = %v", err)
+	}
+	if len(followers.Users) != 1 || followers.Users[0].ID != alice.ID {
+		t.Errorf("Followers() = %+v, want alice", followers)
+	}
+
+	tw := srv.AddTweet(&gotwtr.Tweet{Text: "from bob", AuthorID: bob.ID})
+	home, err := c.UserReverseChronologicalTimeline(ctx, alice.ID)
+	if err != nil {
+		t.Fatalf("UserReverseChronologicalTimeline() error = %v", err)
+	}
+	if len(home.Tweets) != 1 || home.Tweets[0].ID != tw.ID {
+		t.Errorf("UserReverseChronologicalTimeline() = %+v, want the tweet of bob", home.Tweets)
+	}
+}
+
+func Test_Server_likesAndBookmarks(t *testing.T) {
+	t.Parallel()
+	srv, alice, bob := newServer(t)
+	c := srv.Client()
+	ctx := context.Background()
+	tw := srv.AddTweet(&gotwtr.Tweet{Text: "like me", AuthorID: bob.ID})
+
+	if _, err := c.PostUsersLikingTweet(ctx, alice.ID, tw.ID); err != nil {
+		t.Fatalf("PostUsersLikingTweet() error = %v", err)
+	}
+	liked, err := c.TweetsUserLiked(ctx, alice.ID)
+	if err != nil {
+		t.Fatalf("TweetsUserLiked() error = %v", err)
+	}
+	if len(liked.Tweets) != 1 || liked.Tweets[0].ID != tw.ID {
+		t.Errorf("TweetsUserLiked() = %+v, want the liked tweet", liked.Tweets)
+	}
+	if _, err := c.UndoUsersLikingTweet(ctx, alice.ID, tw.ID); err != nil {
+		t.Fatalf("UndoUsersLikingTweet() error = %v", err)
+	}
+	liked, err = c.TweetsUserLiked(ctx, alice.ID)
+	if err != nil {
+		t.Fatalf("TweetsUserLiked() error = %v", err)
+	}
+	if len(liked.Tweets) != 0 {
+		t.Errorf("TweetsUserLiked() = %+v, want none", liked.Tweets)
+	}
+
+	if _, err := c.BookmarkTweet(ctx, alice.ID, &gotwtr.BookmarkTweetBody{TweetID: tw.ID}); err != nil {
+		t.Fatalf("BookmarkTweet() error = %v", err)
+	}
+	marks, err := c.LookupUserBookmarks(ctx, alice.ID)
+	if err != nil {
+		t.Fatalf("LookupUserBookmarks() error = %v", err)
+	}
+	if len(marks.Tweets) != 1 || marks.Tweets[0].ID != tw.ID {
+		t.Errorf("LookupUserBookmarks() = %+v, want the bookmarked tweet", marks.Tweets)
+	}
+}
+
+func Test_Server_lists(t *testing.T) {
+	t.Parallel()
+	srv, _, bob := newServer(t)
+	c := srv.Client()
+	ctx := context.Background()
+
+	created, err := c.CreateNewList(ctx, &gotwtr.CreateNewListBody{Name: "gophers"})
+	if err != nil {
+		t.Fatalf("CreateNewList() error = %v", err)
+	}
+	listID := created.CreateNewListData.ID
+	if _, err := c.PostListMembers(ctx, listID, bob.ID); err != nil {
+		t.Fatalf("PostListMembers() error = %v", err)
+	}
+	members, err := c.ListMembers(ctx, listID)
+	if err != nil {
+		t.Fatalf("ListMembers() error = %v", err)
+	}
+	if len(members.Users) != 1 || members.Users[0].ID != bob.ID {
+		t.Errorf("ListMembers() = %+v, want bob", members.Users)
+	}
+	tw := srv.AddTweet(&gotwtr.Tweet{Text: "in the list", AuthorID: bob.ID})
+	tweets, err := c.LookUpListTweets(ctx, listID)
+	if err != nil {
+		t.Fatalf("LookUpListTweets() error = %v", err)
+	}
+	if len(tweets.Tweets) != 1 || tweets.Tweets[0].ID != tw.ID {
+		t.Errorf("LookUpListTweets() = %+v, want the tweet of bob", tweets.Tweets)
+	}
+	list, err := c.LookUpList(ctx, listID)
+	if err != nil {
+		t.Fatalf("LookUpList() error = %v", err)
+	}
+	if list.List.Name != "gophers" || list.List.MemberCount != 1 {
+		t.Errorf("LookUpList() = %+v, want gophers with 1 member", list.List)
+	}
+}
+
+func Test_Server_directMessages(t *testing.T) {
+	t.Parallel()
+	srv, _, bob := newServer(t)
+	c := srv.Client()
+	ctx := context.Background()
+
+	sent, err := c.CreateOneToOneDM(ctx, bob.ID, &gotwtr.CreateOneToOneDMBody{Text: "hi bob"})
+	if err != nil {
+		t.Fatalf("CreateOneToOneDM() error = %v", err)
+	}
+	dms, err := c.LookUpAllOneToOneDM(ctx, bob.ID)
+	if err != nil {
+		t.Fatalf("LookUpAllOneToOneDM() error = %v", err)
+	}
+	if len(dms.Message) != 1 || dms.Message[0].ID != sent.DMEventFieldID || dms.Message[0].Text != "hi bob" {
+		t.Errorf("LookUpAllOneToOneDM() = %+v, want the sent message", dms.Message)
+	}
+}
+
+func Test_Server_stream(t *testing.T) {
+	t.Parallel()
+	srv, _, _ := newServer(t)
+	c := srv.Client()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	rules, err := c.AddOrDeleteRules(ctx, &gotwtr.AddOrDeleteJSONBody{Add: []*gotwtr.AddRule{{Value: "gopher", Tag: "go"}}})
+	if err != nil {
+		t.Fatalf("AddOrDeleteRules() error = %v", err)
+	}
+	if rules.Meta.Summary.Created != 1 {
+		t.Fatalf("AddOrDeleteRules() summary = %+v, want 1 created", rules.Meta.Summary)
+	}
+	dup, err := c.AddOrDeleteRules(ctx, &gotwtr.AddOrDeleteJSONBody{Add: []*gotwtr.AddRule{{Value: "gopher"}}})
+	if err != nil {
+		t.Fatalf("AddOrDeleteRules() error = %v", err)
+	}
+	if dup.Meta.Summary.NotCreated != 1 || len(dup.Errors) != 1 {
+		t.Errorf("AddOrDeleteRules() = %+v, want a duplicate rule error", dup)
+	}
+
+	ch := make(chan gotwtr.ConnectToStreamResponse, 16)
+	errCh := make(chan error, 16)
+	stream := c.ConnectToStream(ctx, ch, errCh)
+	deadline := time.Now().Add(5 * time.Second)
+	for srv.StreamConnections() == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("the stream did not connect")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	srv.PushTweet(&gotwtr.Tweet{Text: "no match"})
+	pushed := srv.PushTweet(&gotwtr.Tweet{Text: "a Gopher appears"})
+	select {
+	case got := <-ch:
+		if got.Tweet.ID != pushed.ID || len(got.MatchingRules) != 1 || got.MatchingRules[0].Tag != "go" {
+			t.Errorf("stream message = %+v, want the matching tweet", got)
+		}
+	case err := <-errCh:
+		t.Fatalf("stream error = %v", err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no stream message")
+	}
+
+	stream.Stop()
+}
+
+func Test_Server_unauthorized(t *testing.T) {
+	t.Parallel()
+	srv := gotwtrtest.NewServer()
+	defer srv.Close()
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, srv.URL+"/2/users/me", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := srv.Server.Client().Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("status = %d, want 401", resp.StatusCode)
+	}
+}
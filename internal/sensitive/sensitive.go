@@ -0,0 +1,33 @@
+// Package sensitive lists the credentials redacted from the logs of gotwtr and the cassettes of gotwtrtest.
+package sensitive
+
+import "strings"
+
+// Headers are the headers holding credentials.
+var Headers = []string{
+	"Authorization",
+	"Proxy-Authorization",
+	"Cookie",
+	"Set-Cookie",
+}
+
+// keys are the query, form and JSON keys holding credentials.
+var keys = map[string]struct{}{
+	"access_token":       {},
+	"refresh_token":      {},
+	"token":              {},
+	"client_secret":      {},
+	"consumer_secret":    {},
+	"code":               {},
+	"code_verifier":      {},
+	"oauth_token":        {},
+	"oauth_token_secret": {},
+	"oauth_signature":    {},
+	"password":           {},
+}
+
+// Key reports whether the value of the query, form or JSON key k is a credential. Keys are matched case-insensitively.
+func Key(k string) bool {
+	_, ok := keys[strings.ToLower(k)]
+	return ok
+}
@@ -13,13 +13,12 @@ func usersLikingTweet(ctx context.Context, c *client, tweetID string, opt ...*Us
 	if tweetID == "" {
 		return nil, errors.New("users liking tweet: tweet id parameter is required")
 	}
-	ep := fmt.Sprintf(usersLikingTweetURL, tweetID)
+	ep := c.baseURL + fmt.Sprintf(usersLikingTweetURL, tweetID)
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ep, nil)
 	if err != nil {
 		return nil, fmt.Errorf("users liking tweet new request with ctx: %w", err)
 	}
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.bearerToken))
 
 	var uopt UsersLikingTweetOption
 	switch len(opt) {
@@ -32,38 +31,19 @@ func usersLikingTweet(ctx context.Context, c *client, tweetID string, opt ...*Us
 	}
 	uopt.addQuery(req)
 
-	resp, err := c.client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("users liking tweet: %w", err)
-	}
-	defer func() { _ = resp.Body.Close() }()
-
-	var ultr UsersLikingTweetResponse
-	if err := json.NewDecoder(resp.Body).Decode(&ultr); err != nil {
-		return nil, fmt.Errorf("users liking tweet: %w", err)
-	}
-	if resp.StatusCode != http.StatusOK {
-		return &ultr, &HTTPError{
-			APIName: "users liking tweet",
-			Status:  resp.Status,
-			URL:     req.URL.String(),
-		}
-	}
-
-	return &ultr, nil
+	return doJSON[UsersLikingTweetResponse](c, req, "users liking tweet", http.StatusOK)
 }
 
 func tweetsUserLiked(ctx context.Context, c *client, userID string, opt ...*TweetsUserLikedOption) (*TweetsUserLikedResponse, error) {
 	if userID == "" {
 		return nil, errors.New("tweets user liked: user id parameter is required")
 	}
-	ep := fmt.Sprintf(tweetsUserLikedURL, userID)
+	ep := c.baseURL + fmt.Sprintf(tweetsUserLikedURL, userID)
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ep, nil)
 	if err != nil {
 		return nil, fmt.Errorf("tweets user liked new request with ctx: %w", err)
 	}
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.bearerToken))
 
 	var topt TweetsUserLikedOption
 	switch len(opt) {
@@ -87,32 +67,14 @@ func tweetsUserLiked(ctx context.Context, c *client, userID string, opt ...*Twee
 	}
 	topt.addQuery(req)
 
-	resp, err := c.client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("tweets user liked: %w", err)
-	}
-	defer func() { _ = resp.Body.Close() }()
-
-	var tulr TweetsUserLikedResponse
-	if err := json.NewDecoder(resp.Body).Decode(&tulr); err != nil {
-		return nil, fmt.Errorf("tweets user liked: %w", err)
-	}
-	if resp.StatusCode != http.StatusOK {
-		return &tulr, &HTTPError{
-			APIName: "tweets user liked",
-			Status:  resp.Status,
-			URL:     req.URL.String(),
-		}
-	}
-
-	return &tulr, nil
+	return doJSON[TweetsUserLikedResponse](c, req, "tweets user liked", http.StatusOK)
 }
 
 func postUsersLikingTweet(ctx context.Context, c *client, userID string, tweetID string) (*PostUsersLikingTweetResponse, error) {
 	if userID == "" {
 		return nil, errors.New("post users liking tweet: userID parameter is required")
 	}
-	ep := fmt.Sprintf(postUsersLikingTweetURL, userID)
+	ep := c.baseURL + fmt.Sprintf(postUsersLikingTweetURL, userID)
 
 	if tweetID == "" {
 		return nil, errors.New("post users liking tweet: tweetID parameter is required")
@@ -129,28 +91,9 @@ func postUsersLikingTweet(ctx context.Context, c *client, userID string, tweetID
 	if err != nil {
 		return nil, fmt.Errorf("post users liking tweet new request with ctx: %w", err)
 	}
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.bearerToken))
 	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := c.client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("post users liking tweet response: %w", err)
-	}
-	defer func() { _ = resp.Body.Close() }()
-
-	var postUsersLikingTweet PostUsersLikingTweetResponse
-	if err := json.NewDecoder(resp.Body).Decode(&postUsersLikingTweet); err != nil {
-		return nil, fmt.Errorf("post users liking tweet decode: %w", err)
-	}
-	if resp.StatusCode != http.StatusOK {
-		return &postUsersLikingTweet, &HTTPError{
-			APIName: "post users liking tweet",
-			Status:  resp.Status,
-			URL:     req.URL.String(),
-		}
-	}
-
-	return &postUsersLikingTweet, nil
+	return doJSON[PostUsersLikingTweetResponse](c, req, "post users liking tweet", http.StatusOK)
 }
 
 func undoUsersLikingTweet(ctx context.Context, c *client, userID string, tweetID string) (*UndoUsersLikingTweetResponse, error) {
@@ -160,31 +103,12 @@ func undoUsersLikingTweet(ctx context.Context, c *client, userID string, tweetID
 	if tweetID == "" {
 		return nil, errors.New("undo users liking tweet: tweetID parameter is required")
 	}
-	ep := fmt.Sprintf(undoUsersLikingTweetURL, userID, tweetID)
+	ep := c.baseURL + fmt.Sprintf(undoUsersLikingTweetURL, userID, tweetID)
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, ep, nil)
 	if err != nil {
 		return nil, fmt.Errorf("undo users liking tweet new request with ctx: %w", err)
 	}
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.bearerToken))
-
-	resp, err := c.client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("undo users liking tweet response: %w", err)
-	}
-	defer func() { _ = resp.Body.Close() }()
-
-	var undoUsersLikingTweet UndoUsersLikingTweetResponse
-	if err := json.NewDecoder(resp.Body).Decode(&undoUsersLikingTweet); err != nil {
-		return nil, fmt.Errorf("undo users liking tweet decode: %w", err)
-	}
-	if resp.StatusCode != http.StatusOK {
-		return &undoUsersLikingTweet, &HTTPError{
-			APIName: "undo users liking tweet",
-			Status:  resp.Status,
-			URL:     req.URL.String(),
-		}
-	}
 
-	return &undoUsersLikingTweet, nil
+	return doJSON[UndoUsersLikingTweetResponse](c, req, "undo users liking tweet", http.StatusOK)
 }
@@ -13,15 +13,13 @@ func listFollowers(ctx context.Context, c *client, listID string, opt ...*ListFo
 	if listID == "" {
 		return nil, errors.New("list followers: listID parameter is required")
 	}
-	ep := fmt.Sprintf(listFollowersURL, listID)
+	ep := c.baseURL + fmt.Sprintf(listFollowersURL, listID)
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ep, nil)
 	if err != nil {
 		return nil, fmt.Errorf("list followers new request with ctx: %w", err)
 	}
 
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.bearerToken))
-
 	var lopt ListFollowersOption
 	switch len(opt) {
 	case 0:
@@ -44,38 +42,19 @@ func listFollowers(ctx context.Context, c *client, listID string, opt ...*ListFo
 	}
 	lopt.addQuery(req)
 
-	resp, err := c.client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("list followers: %w", err)
-	}
-	defer func() { _ = resp.Body.Close() }()
-
-	var lfr ListFollowersResponse
-	if err := json.NewDecoder(resp.Body).Decode(&lfr); err != nil {
-		return nil, fmt.Errorf("list followers: %w", err)
-	}
-	if resp.StatusCode != http.StatusOK {
-		return &lfr, &HTTPError{
-			APIName: "list followers",
-			Status:  resp.Status,
-			URL:     req.URL.String(),
-		}
-	}
-
-	return &lfr, nil
+	return doJSON[ListFollowersResponse](c, req, "list followers", http.StatusOK)
 }
 
 func allListsUserFollows(ctx context.Context, c *client, userID string, opt ...*ListFollowsOption) (*AllListsUserFollowsResponse, error) {
 	if userID == "" {
 		return nil, errors.New("all lists user follows: userID parameter is required")
 	}
-	ep := fmt.Sprintf(allListsUserFollowsURL, userID)
+	ep := c.baseURL + fmt.Sprintf(allListsUserFollowsURL, userID)
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ep, nil)
 	if err != nil {
 		return nil, fmt.Errorf("all lists user follows new request with ctx: %w", err)
 	}
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.bearerToken))
 
 	var lopt ListFollowsOption
 	switch len(opt) {
@@ -99,32 +78,14 @@ func allListsUserFollows(ctx context.Context, c *client, userID string, opt ...*
 	}
 	lopt.addQuery(req)
 
-	resp, err := c.client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("all lists user follows: %w", err)
-	}
-	defer func() { _ = resp.Body.Close() }()
-
-	var alufr AllListsUserFollowsResponse
-	if err := json.NewDecoder(resp.Body).Decode(&alufr); err != nil {
-		return nil, fmt.Errorf("all lists user follows: %w", err)
-	}
-	if resp.StatusCode != http.StatusOK {
-		return &alufr, &HTTPError{
-			APIName: "all lists user follows",
-			Status:  resp.Status,
-			URL:     req.URL.String(),
-		}
-	}
-
-	return &alufr, nil
+	return doJSON[AllListsUserFollowsResponse](c, req, "all lists user follows", http.StatusOK)
 }
 
 func postListFollows(ctx context.Context, c *client, listID string, userID string) (*PostListFollowsResponse, error) {
 	if userID == "" {
 		return nil, errors.New("post list follows: userID parameter is required")
 	}
-	ep := fmt.Sprintf(postListFollowsURL, listID)
+	ep := c.baseURL + fmt.Sprintf(postListFollowsURL, listID)
 
 	if listID == "" {
 		return nil, errors.New("post list follows: listID parameter is required")
@@ -141,28 +102,9 @@ func postListFollows(ctx context.Context, c *client, listID string, userID strin
 	if err != nil {
 		return nil, fmt.Errorf("post list follows new request with ctx: %w", err)
 	}
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.bearerToken))
 	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := c.client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("post list follows response: %w", err)
-	}
-	defer func() { _ = resp.Body.Close() }()
-
-	var postListFollows PostListFollowsResponse
-	if err := json.NewDecoder(resp.Body).Decode(&postListFollows); err != nil {
-		return nil, fmt.Errorf("post list follows decode: %w", err)
-	}
-	if resp.StatusCode != http.StatusOK {
-		return &postListFollows, &HTTPError{
-			APIName: "post list follows",
-			Status:  resp.Status,
-			URL:     req.URL.String(),
-		}
-	}
-
-	return &postListFollows, nil
+	return doJSON[PostListFollowsResponse](c, req, "post list follows", http.StatusOK)
 }
 
 func undoListFollows(ctx context.Context, c *client, listID string, userID string) (*UndoListFollowsResponse, error) {
@@ -172,32 +114,13 @@ func undoListFollows(ctx context.Context, c *client, listID string, userID strin
 	if userID == "" {
 		return nil, errors.New("undo list follows: userID parameter is required")
 	}
-	ep := fmt.Sprintf(undoListFollowsURL, userID, listID)
+	ep := c.baseURL + fmt.Sprintf(undoListFollowsURL, userID, listID)
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, ep, nil)
 	if err != nil {
 		return nil, fmt.Errorf("undo list follows new request with ctx: %w", err)
 	}
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.bearerToken))
 	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := c.client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("undo list follows response: %w", err)
-	}
-	defer func() { _ = resp.Body.Close() }()
-
-	var undoListFollows UndoListFollowsResponse
-	if err := json.NewDecoder(resp.Body).Decode(&undoListFollows); err != nil {
-		return nil, fmt.Errorf("undo list follows decode: %w", err)
-	}
-	if resp.StatusCode != http.StatusOK {
-		return &undoListFollows, &HTTPError{
-			APIName: "undo list follows",
-			Status:  resp.Status,
-			URL:     req.URL.String(),
-		}
-	}
-
-	return &undoListFollows, nil
+	return doJSON[UndoListFollowsResponse](c, req, "undo list follows", http.StatusOK)
 }
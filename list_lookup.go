@@ -2,7 +2,6 @@ package gotwtr
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
@@ -12,13 +11,12 @@ func lookUpList(ctx context.Context, c *client, listID string, opt ...*LookUpLis
 	if listID == "" {
 		return nil, errors.New("look up list: listID parameter is required")
 	}
-	ep := fmt.Sprintf(lookUpListURL, listID)
+	ep := c.baseURL + fmt.Sprintf(lookUpListURL, listID)
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ep, nil)
 	if err != nil {
 		return nil, fmt.Errorf("look up list new request with ctx: %w", err)
 	}
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.bearerToken))
 
 	var lopt LookUpListOption
 	switch len(opt) {
@@ -31,40 +29,20 @@ func lookUpList(ctx context.Context, c *client, listID string, opt ...*LookUpLis
 	}
 	lopt.addQuery(req)
 
-	resp, err := c.client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("look up list response: %w", err)
-	}
-	defer func() { _ = resp.Body.Close() }()
-
-	var lr ListResponse
-	if err := json.NewDecoder(resp.Body).Decode(&lr); err != nil {
-		return nil, fmt.Errorf("look up list decode: %w", err)
-	}
-	if resp.StatusCode != http.StatusOK {
-		return &lr, &HTTPError{
-			APIName: "look up list",
-			Status:  resp.Status,
-			URL:     req.URL.String(),
-		}
-	}
-
-	return &lr, nil
+	return doJSON[ListResponse](c, req, "look up list", http.StatusOK)
 }
 
 func lookUpAllListsOwned(ctx context.Context, c *client, userID string, opt ...*AllListsOwnedOption) (*AllListsOwnedResponse, error) {
 	if userID == "" {
 		return nil, errors.New("look up all lists owned: userID parameter is required")
 	}
-	ep := fmt.Sprintf(lookUpAllListsOwnedURL, userID)
+	ep := c.baseURL + fmt.Sprintf(lookUpAllListsOwnedURL, userID)
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ep, nil)
 	if err != nil {
 		return nil, fmt.Errorf("look up all lists owned new request with ctx: %w", err)
 	}
 
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.bearerToken))
-
 	var aopt AllListsOwnedOption
 	switch len(opt) {
 	case 0:
@@ -87,24 +65,5 @@ func lookUpAllListsOwned(ctx context.Context, c *client, userID string, opt ...*
 	}
 	aopt.addQuery(req)
 
-	resp, err := c.client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("look up all lists owned response: %w", err)
-	}
-
-	defer func() { _ = resp.Body.Close() }()
-
-	var alor AllListsOwnedResponse
-	if err := json.NewDecoder(resp.Body).Decode(&alor); err != nil {
-		return nil, fmt.Errorf("look up all lists owned decode: %w", err)
-	}
-	if resp.StatusCode != http.StatusOK {
-		return &alor, &HTTPError{
-			APIName: "look up all lists owned",
-			Status:  resp.Status,
-			URL:     req.URL.String(),
-		}
-	}
-
-	return &alor, nil
+	return doJSON[AllListsOwnedResponse](c, req, "look up all lists owned", http.StatusOK)
 }
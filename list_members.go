@@ -13,13 +13,12 @@ func listMembers(ctx context.Context, c *client, listID string, opt ...*ListMemb
 	if listID == "" {
 		return nil, errors.New("look up list members: id parameter is required")
 	}
-	lm := fmt.Sprintf(listMembersURL, listID)
+	lm := c.baseURL + fmt.Sprintf(listMembersURL, listID)
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, lm, nil)
 	if err != nil {
 		return nil, fmt.Errorf("look up list members: %w", err)
 	}
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.bearerToken))
 	req.Header.Set("Content-Type", "application/json")
 
 	var lopt ListMembersOption
@@ -44,26 +43,7 @@ func listMembers(ctx context.Context, c *client, listID string, opt ...*ListMemb
 	}
 	lopt.addQuery(req)
 
-	resp, err := c.client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("look up list members: %w", err)
-	}
-
-	defer func() { _ = resp.Body.Close() }()
-
-	var lmr ListMembersResponse
-	if err := json.NewDecoder(resp.Body).Decode(&lmr); err != nil {
-		return nil, fmt.Errorf("look up list members: %w", err)
-	}
-	if resp.StatusCode != http.StatusOK {
-		return &lmr, &HTTPError{
-			APIName: "owned lists lookup by id",
-			Status:  resp.Status,
-			URL:     req.URL.String(),
-		}
-	}
-
-	return &lmr, nil
+	return doJSON[ListMembersResponse](c, req, "owned lists lookup by id", http.StatusOK)
 }
 
 func listsSpecifiedUser(ctx context.Context, c *client, userID string, opt ...*ListsSpecifiedUserOption) (*ListsSpecifiedUserResponse, error) {
@@ -71,15 +51,13 @@ func listsSpecifiedUser(ctx context.Context, c *client, userID string, opt ...*L
 		return nil, errors.New("lists specified user: userID parameter is required")
 	}
 
-	lm := fmt.Sprintf(listsSpecifiedUserURL, userID)
+	lm := c.baseURL + fmt.Sprintf(listsSpecifiedUserURL, userID)
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, lm, nil)
 	if err != nil {
 		return nil, fmt.Errorf("lists specified user: %w", err)
 	}
 
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.bearerToken))
-
 	var lopt ListsSpecifiedUserOption
 	switch len(opt) {
 	case 0:
@@ -102,33 +80,14 @@ func listsSpecifiedUser(ctx context.Context, c *client, userID string, opt ...*L
 	}
 	lopt.addQuery(req)
 
-	resp, err := c.client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("lists specified user: %w", err)
-	}
-
-	defer func() { _ = resp.Body.Close() }()
-
-	var lmr ListsSpecifiedUserResponse
-	if err := json.NewDecoder(resp.Body).Decode(&lmr); err != nil {
-		return nil, fmt.Errorf("lists specified user: %w", err)
-	}
-	if resp.StatusCode != http.StatusOK {
-		return &lmr, &HTTPError{
-			APIName: "lists specified user",
-			Status:  resp.Status,
-			URL:     req.URL.String(),
-		}
-	}
-
-	return &lmr, nil
+	return doJSON[ListsSpecifiedUserResponse](c, req, "lists specified user", http.StatusOK)
 }
 
 func postListMembers(ctx context.Context, c *client, listID string, userID string) (*PostListMembersResponse, error) {
 	if listID == "" {
 		return nil, errors.New("post list members: listID parameter is required")
 	}
-	ep := fmt.Sprintf(postListMembersURL, listID)
+	ep := c.baseURL + fmt.Sprintf(postListMembersURL, listID)
 
 	if userID == "" {
 		return nil, errors.New("post list members: userID parameter is required")
@@ -145,28 +104,9 @@ func postListMembers(ctx context.Context, c *client, listID string, userID strin
 	if err != nil {
 		return nil, fmt.Errorf("post list members new request with ctx: %w", err)
 	}
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.bearerToken))
 	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := c.client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("post list members response: %w", err)
-	}
-	defer func() { _ = resp.Body.Close() }()
-
-	var postListMembers PostListMembersResponse
-	if err := json.NewDecoder(resp.Body).Decode(&postListMembers); err != nil {
-		return nil, fmt.Errorf("post list members decode: %w", err)
-	}
-	if resp.StatusCode != http.StatusOK {
-		return &postListMembers, &HTTPError{
-			APIName: "post list members",
-			Status:  resp.Status,
-			URL:     req.URL.String(),
-		}
-	}
-
-	return &postListMembers, nil
+	return doJSON[PostListMembersResponse](c, req, "post list members", http.StatusOK)
 }
 
 func undoListMembers(ctx context.Context, c *client, listID string, userID string) (*UndoListMembersResponse, error) {
@@ -176,31 +116,12 @@ func undoListMembers(ctx context.Context, c *client, listID string, userID strin
 	if userID == "" {
 		return nil, errors.New("undo list members: userID parameter is required")
 	}
-	ep := fmt.Sprintf(undoListMembersURL, listID, userID)
+	ep := c.baseURL + fmt.Sprintf(undoListMembersURL, listID, userID)
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, ep, nil)
 	if err != nil {
 		return nil, fmt.Errorf("undo list members new request with ctx: %w", err)
 	}
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.bearerToken))
-
-	resp, err := c.client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("undo list members response: %w", err)
-	}
-	defer func() { _ = resp.Body.Close() }()
-
-	var undoListMembers UndoListMembersResponse
-	if err := json.NewDecoder(resp.Body).Decode(&undoListMembers); err != nil {
-		return nil, fmt.Errorf("undo list members decode: %w", err)
-	}
-	if resp.StatusCode != http.StatusOK {
-		return &undoListMembers, &HTTPError{
-			APIName: "undo list members",
-			Status:  resp.Status,
-			URL:     req.URL.String(),
-		}
-	}
 
-	return &undoListMembers, nil
+	return doJSON[UndoListMembersResponse](c, req, "undo list members", http.StatusOK)
 }
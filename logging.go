@@ -0,0 +1,228 @@
+package gotwtr
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sivchari/gotwtr/internal/sensitive"
+)
+
+const redacted = "REDACTED"
+
+// maxLoggedBodySize is the maximum size of a request or response body written to the log.
+const maxLoggedBodySize = 4 << 10
+
+// WithLogger sets the logger the client writes every request to at debug level.
+// Credentials such as bearer tokens, consumer secrets and OAuth headers are always redacted.
+func WithLogger(logger *slog.Logger) ClientOption {
+	return func(c *client) {
+		c.logger = logger
+	}
+}
+
+// WithLogBodies makes the logger of the client also write request and response bodies and headers.
+// Bodies of streaming connections are never logged.
+func WithLogBodies() ClientOption {
+	return func(c *client) {
+		c.logBodies = true
+	}
+}
+
+type streamKey struct{}
+
+func withStream(ctx context.Context) context.Context {
+	return context.WithValue(ctx, streamKey{}, true)
+}
+
+func isStream(ctx context.Context) bool {
+	v, _ := ctx.Value(streamKey{}).(bool)
+	return v
+}
+
+// logRequest writes a single attempt of req to the logger of the client.
+func (c *client) logRequest(req *http.Request, apiName string, reqBody []byte, resp *http.Response, err error, latency time.Duration) {
+	if c.logger == nil {
+		return
+	}
+	ctx := req.Context()
+	if !c.logger.Enabled(ctx, slog.LevelDebug) {
+		return
+	}
+	// The credential of the header is redacted whether it came from the client, a TokenSource or the caller.
+	cred := credential(req.Header.Get("Authorization"))
+	attrs := []slog.Attr{
+		slog.String("method", req.Method),
+		slog.String("operation", apiName),
+		slog.String("url", c.redact(redactURL(req.URL), cred)),
+		slog.Duration("latency", latency),
+	}
+	if c.logBodies {
+		attrs = append(attrs, slog.Any("request_headers", redactHeader(req.Header)))
+		if reqBody != nil {
+			attrs = append(attrs, slog.String("request_body", c.redact(redactBody(req.Header.Get("Content-Type"), reqBody), cred)))
+		}
+	}
+	if err != nil {
+		attrs = append(attrs, slog.String("error", c.redact(err.Error(), cred)))
+		c.logger.LogAttrs(ctx, slog.LevelDebug, "gotwtr request", attrs...)
+		return
+	}
+	attrs = append(attrs, slog.Int("status", resp.StatusCode))
+	if rl := parseRateLimit(resp.Header); rl != nil {
+		attrs = append(attrs, slog.Group("rate_limit",
+			slog.Int("limit", rl.Limit),
+			slog.Int("remaining", rl.Remaining),
+			slog.Time("reset", rl.Reset),
+		))
+	}
+	if c.logBodies && !isStream(ctx) {
+		attrs = append(attrs, slog.Any("response_headers", redactHeader(resp.Header)))
+		if b := peekBody(resp); b != nil {
+			attrs = append(attrs, slog.String("response_body", c.redact(redactBody(resp.Header.Get("Content-Type"), b), cred)))
+		}
+	}
+	c.logger.LogAttrs(ctx, slog.LevelDebug, "gotwtr request", attrs...)
+}
+
+// requestBody returns a copy of the body of req without consuming it.
+func requestBody(req *http.Request) []byte {
+	if req.Body == nil || req.Body == http.NoBody || req.GetBody == nil {
+		return nil
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return nil
+	}
+	defer func() { _ = body.Close() }()
+	b, err := io.ReadAll(body)
+	if err != nil {
+		return nil
+	}
+	return b
+}
+
+// peekBody reads the body of resp and replaces it with a copy.
+func peekBody(resp *http.Response) []byte {
+	b, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(b))
+	if err != nil {
+		return nil
+	}
+	return b
+}
+
+// redact replaces the credentials of the client, and the extra ones, appearing in s.
+func (c *client) redact(s string, extra ...string) string {
+	secrets := append([]string{c.bearerToken.get(), c.consumerSecret}, extra...)
+	if c.oauth1 != nil {
+		secrets = append(secrets, c.oauth1.tokenSecret)
+	}
+	for _, secret := range secrets {
+		if secret != "" {
+			s = strings.ReplaceAll(s, secret, redacted)
+		}
+	}
+	return s
+}
+
+// credential returns the credential of an Authorization header, without its scheme.
+func credential(authorization string) string {
+	if _, cred, ok := strings.Cut(authorization, " "); ok {
+		return strings.TrimSpace(cred)
+	}
+	return authorization
+}
+
+func redactURL(u *url.URL) string {
+	r := *u
+	r.User = nil
+	q := r.Query()
+	for k := range q {
+		if sensitive.Key(k) {
+			q.Set(k, redacted)
+		}
+	}
+	r.RawQuery = q.Encode()
+	return r.String()
+}
+
+func redactHeader(h http.Header) http.Header {
+	r := h.Clone()
+	if r == nil {
+		return http.Header{}
+	}
+	for _, k := range sensitive.Headers {
+		if r.Get(k) != "" {
+			r.Set(k, redacted)
+		}
+	}
+	return r
+}
+
+// redactBody returns the body with the values of sensitive keys redacted.
+// Bodies that are neither JSON nor form-encoded are omitted.
+func redactBody(contentType string, b []byte) string {
+	switch {
+	case strings.HasPrefix(contentType, "application/x-www-form-urlencoded"):
+		q, err := url.ParseQuery(string(b))
+		if err != nil {
+			return "<unparsable form body omitted>"
+		}
+		for k := range q {
+			if sensitive.Key(k) {
+				q.Set(k, redacted)
+			}
+		}
+		return truncate(q.Encode())
+	case json.Valid(b):
+		var v any
+		if err := json.Unmarshal(b, &v); err != nil {
+			return "<unparsable json body omitted>"
+		}
+		j, err := json.Marshal(redactJSON(v))
+		if err != nil {
+			return "<unparsable json body omitted>"
+		}
+		return truncate(string(j))
+	default:
+		// Multipart uploads and other binary bodies are summarized by their size.
+		return "<" + strconv.Itoa(len(b)) + " bytes omitted>"
+	}
+}
+
+func redactJSON(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for k, e := range v {
+			if sensitive.Key(k) {
+				v[k] = redacted
+				continue
+			}
+			v[k] = redactJSON(e)
+		}
+		return v
+	case []any:
+		for i, e := range v {
+			v[i] = redactJSON(e)
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+func truncate(s string) string {
+	if len(s) > maxLoggedBodySize {
+		return s[:maxLoggedBodySize] + "...(truncated)"
+	}
+	return s
+}
@@ -0,0 +1,163 @@
+package gotwtr_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/sivchari/gotwtr"
+)
+
+func Test_WithLogger(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name       string
+		opts       []gotwtr.ClientOption
+		status     int
+		body       string
+		wantLogged []string
+		wantHidden []string
+	}{
+		{
+			name:   "without bodies",
+			status: http.StatusCreated,
+			body:   `{"data":{"id":"1","text":"hello"}}`,
+			wantLogged: []string{
+				"method=POST",
+				"operation=\"post tweet\"",
+				"url=https://api.x.com/2/tweets",
+				"status=201",
+				"latency=",
+				"rate_limit.limit=300",
+				"rate_limit.remaining=299",
+			},
+			wantHidden: []string{"secret-bearer-token", "request_body", "response_body"},
+		},
+		{
+			name:   "with bodies",
+			opts:   []gotwtr.ClientOption{gotwtr.WithLogBodies()},
+			status: http.StatusCreated,
+			body:   `{"data":{"id":"1","text":"hello secret-bearer-token"}}`,
+			wantLogged: []string{
+				"status=201",
+				"request_body=",
+				`\"text\":\"hello\"`,
+				"response_body=",
+				"Authorization:[REDACTED]",
+			},
+			wantHidden: []string{"secret-bearer-token"},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var buf bytes.Buffer
+			logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+			client := mockHTTPClient(func(req *http.Request) *http.Response {
+				return &http.Response{
+					StatusCode: tt.status,
+					Header: http.Header{
+						"X-Rate-Limit-Limit":     []string{"300"},
+						"X-Rate-Limit-Remaining": []string{"299"},
+						"X-Rate-Limit-Reset":     []string{"1700000000"},
+					},
+					Body: io.NopCloser(strings.NewReader(tt.body)),
+				}
+			})
+			opts := append([]gotwtr.ClientOption{gotwtr.WithHTTPClient(client), gotwtr.WithLogger(logger)}, tt.opts...)
+			c := gotwtr.New("secret-bearer-token", opts...)
+			got, err := c.PostTweet(context.Background(), &gotwtr.PostTweetOption{Text: "hello"})
+			if err != nil {
+				t.Fatalf("PostTweet() error = %v", err)
+			}
+			if got.PostTweetData.ID != "1" {
+				t.Errorf("PostTweet() = %+v, the response body must still be decoded", got)
+			}
+			logged := buf.String()
+			for _, want := range tt.wantLogged {
+				if !strings.Contains(logged, want) {
+					t.Errorf("log %q does not contain %q", logged, want)
+				}
+			}
+			for _, hidden := range tt.wantHidden {
+				if strings.Contains(logged, hidden) {
+					t.Errorf("log %q must not contain %q", logged, hidden)
+				}
+			}
+		})
+	}
+}
+
+func Test_WithLogger_redactsCredentials(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name       string
+		opts       []gotwtr.ClientOption
+		call       func(c *gotwtr.Client) error
+		wantHidden []string
+	}{
+		{
+			name: "app-only bearer token",
+			opts: []gotwtr.ClientOption{
+				gotwtr.WithConsumerSecret("consumer-secret"),
+				gotwtr.WithConsumerKey("consumer-key"),
+			},
+			call: func(c *gotwtr.Client) error {
+				_, err := c.GenerateAppOnlyBearerToken(context.Background())
+				return err
+			},
+			wantHidden: []string{"consumer-secret", "new-access-token", "Basic "},
+		},
+		{
+			name: "token source",
+			opts: []gotwtr.ClientOption{
+				gotwtr.WithTokenSource(gotwtr.StaticTokenSource(&gotwtr.OAuth2Token{AccessToken: "source-access-token", TokenType: "bearer"})),
+			},
+			call: func(c *gotwtr.Client) error {
+				_, err := c.RetrieveSingleTweet(context.Background(), "1")
+				return err
+			},
+			wantHidden: []string{"source-access-token", "Bearer "},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var buf bytes.Buffer
+			logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+			client := mockHTTPClient(func(req *http.Request) *http.Response {
+				// The response echoes the credential under a key that is not redacted by name.
+				_, cred, _ := strings.Cut(req.Header.Get("Authorization"), " ")
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Header:     http.Header{"Content-Type": {"application/json"}},
+					Body:       io.NopCloser(strings.NewReader(`{"token_type":"bearer","access_token":"new-access-token","data":{"id":"1","text":"` + cred + `"}}`)),
+				}
+			})
+			opts := append([]gotwtr.ClientOption{
+				gotwtr.WithHTTPClient(client),
+				gotwtr.WithLogger(logger),
+				gotwtr.WithLogBodies(),
+			}, tt.opts...)
+			c := gotwtr.New("", opts...)
+			if err := tt.call(c); err != nil {
+				t.Fatalf("call error = %v", err)
+			}
+			logged := buf.String()
+			for _, hidden := range tt.wantHidden {
+				if strings.Contains(logged, hidden) {
+					t.Errorf("log %q must not contain %q", logged, hidden)
+				}
+			}
+			if !strings.Contains(logged, "gotwtr request") {
+				t.Errorf("log %q does not contain the request", logged)
+			}
+		})
+	}
+}
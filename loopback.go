@@ -0,0 +1,138 @@
+package gotwtr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"net"
+	"net/http"
+	"net/url"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	defaultLoopbackRedirectURL = "http://127.0.0.1:0/callback"
+	defaultLoopbackTimeout     = 5 * time.Minute
+)
+
+// ErrAuthorizationTimeout is returned when the user does not complete the authorization in time.
+var ErrAuthorizationTimeout = errors.New("gotwtr: authorization timed out")
+
+// AuthorizeLoopback authorizes a user interactively through a temporary HTTP listener on the loopback interface,
+// and returns the exchanged token.
+// The listener serves the host and path of the RedirectURL of the config, e.g. http://127.0.0.1:8080/callback,
+// which must be registered for the app. A RedirectURL with port 0 listens on any free port.
+// open is called with the URL of the consent page, typically to open it in a browser or print it.
+// Callbacks whose state does not match are rejected with 400 Bad Request and do not end the authorization.
+// The code is exchanged before the page of the callback is rendered, so the page reports whether the exchange succeeded.
+// The listener shuts down once the callback is received, or returns ErrAuthorizationTimeout
+// if no callback is received within timeout. A timeout of 0 means 5 minutes.
+func (c *OAuth2Config) AuthorizeLoopback(ctx context.Context, timeout time.Duration, open func(authURL string) error) (*OAuth2Token, error) {
+	redirect := c.RedirectURL
+	if redirect == "" {
+		redirect = defaultLoopbackRedirectURL
+	}
+	u, err := url.Parse(redirect)
+	if err != nil {
+		return nil, fmt.Errorf("authorize loopback: %w", err)
+	}
+	if u.Scheme != "http" {
+		return nil, fmt.Errorf("authorize loopback: redirect url %q must be http", redirect)
+	}
+	if ip := net.ParseIP(u.Hostname()); (ip == nil || !ip.IsLoopback()) && u.Hostname() != "localhost" {
+		return nil, fmt.Errorf("authorize loopback: redirect url %q must be on the loopback interface", redirect)
+	}
+	var lc net.ListenConfig
+	ln, err := lc.Listen(ctx, "tcp", u.Host)
+	if err != nil {
+		return nil, fmt.Errorf("authorize loopback: %w", err)
+	}
+	// Port 0 listens on a free port, which the redirect URL must name.
+	_, port, err := net.SplitHostPort(ln.Addr().String())
+	if err != nil {
+		_ = ln.Close()
+		return nil, fmt.Errorf("authorize loopback: %w", err)
+	}
+	u.Host = net.JoinHostPort(u.Hostname(), port)
+	cfg := *c
+	cfg.RedirectURL = u.String()
+
+	ar, err := cfg.NewAuthorizationRequest()
+	if err != nil {
+		_ = ln.Close()
+		return nil, fmt.Errorf("authorize loopback: %w", err)
+	}
+
+	type result struct {
+		tok *OAuth2Token
+		err error
+	}
+	results := make(chan result, 1)
+	var handled atomic.Bool
+	path := u.Path
+	if path == "" {
+		path = "/"
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		code, err := VerifyCallback(r.URL.Query(), ar.State)
+		if errors.Is(err, ErrOAuth2StateMismatch) {
+			// Not the callback of this authorization; keep waiting for it.
+			http.Error(w, "state mismatch", http.StatusBadRequest)
+			return
+		}
+		if !handled.CompareAndSwap(false, true) {
+			http.Error(w, "authorization already handled", http.StatusBadRequest)
+			return
+		}
+		var tok *OAuth2Token
+		if err == nil {
+			tok, err = cfg.Exchange(ctx, code, ar.CodeVerifier)
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = fmt.Fprintf(w, loopbackPage, "Authorization failed", html.EscapeString(err.Error()))
+		} else {
+			_, _ = fmt.Fprintf(w, loopbackPage, "Authorization complete", "You can close this window and return to the application.")
+		}
+		results <- result{tok: tok, err: err}
+	})
+	srv := &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() { _ = srv.Serve(ln) }()
+	defer func() {
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		_ = srv.Shutdown(shutdownCtx)
+	}()
+
+	if err := open(ar.URL); err != nil {
+		return nil, fmt.Errorf("authorize loopback: %w", err)
+	}
+
+	if timeout <= 0 {
+		timeout = defaultLoopbackTimeout
+	}
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-timer.C:
+		return nil, ErrAuthorizationTimeout
+	case res := <-results:
+		return res.tok, res.err
+	}
+}
+
+const loopbackPage = `<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>gotwtr</title></head>
+<body><h1>%s</h1><p>%s</p></body>
+</html>
+`
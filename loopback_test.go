@@ -0,0 +1,161 @@
+package gotwtr_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/sivchari/gotwtr"
+)
+
+func Test_OAuth2Config_AuthorizeLoopback(t *testing.T) {
+	t.Parallel()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("ParseForm() error = %v", err)
+		}
+		if r.PostForm.Get("code") != "good" || r.PostForm.Get("redirect_uri") == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = io.WriteString(w, `{"error":"invalid_request"}`)
+			return
+		}
+		_, _ = io.WriteString(w, `{"token_type":"bearer","expires_in":7200,"access_token":"access","refresh_token":"refresh"}`)
+	}))
+	t.Cleanup(srv.Close)
+
+	tests := []struct {
+		name     string
+		callback func(t *testing.T, redirect string, state string)
+		wantErr  error
+	}{
+		{
+			name: "authorized",
+			callback: func(t *testing.T, redirect string, state string) {
+				t.Helper()
+				// A request of another authorization does not complete this one.
+				if status := get(t, redirect+"?code=other&state=other"); status != http.StatusBadRequest {
+					t.Errorf("callback with another state = %v, want 400", status)
+				}
+				if status := get(t, redirect+"?code=good&state="+url.QueryEscape(state)); status != http.StatusOK {
+					t.Errorf("callback = %v, want 200", status)
+				}
+			},
+		},
+		{
+			name: "access denied",
+			callback: func(t *testing.T, redirect string, state string) {
+				t.Helper()
+				if status := get(t, redirect+"?error=access_denied&state="+url.QueryEscape(state)); status != http.StatusBadRequest {
+					t.Errorf("callback = %v, want 400", status)
+				}
+			},
+			wantErr: &gotwtr.OAuth2CallbackError{Code: "access_denied"},
+		},
+		{
+			name: "error with another state",
+			callback: func(t *testing.T, redirect string, state string) {
+				t.Helper()
+				if status := get(t, redirect+"?error=access_denied&state=other"); status != http.StatusBadRequest {
+					t.Errorf("callback with another state = %v, want 400", status)
+				}
+				if status := get(t, redirect+"?code=good&state="+url.QueryEscape(state)); status != http.StatusOK {
+					t.Errorf("callback = %v, want 200", status)
+				}
+			},
+		},
+		{
+			name: "exchange failed",
+			callback: func(t *testing.T, redirect string, state string) {
+				t.Helper()
+				if status := get(t, redirect+"?code=bad&state="+url.QueryEscape(state)); status != http.StatusBadRequest {
+					t.Errorf("callback = %v, want 400", status)
+				}
+			},
+			wantErr: &gotwtr.HTTPError{StatusCode: http.StatusBadRequest},
+		},
+		{
+			name:     "timeout",
+			callback: func(t *testing.T, redirect string, state string) {},
+			wantErr:  gotwtr.ErrAuthorizationTimeout,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			cfg := &gotwtr.OAuth2Config{
+				ClientID:    "client-id",
+				RedirectURL: "http://127.0.0.1:0/callback",
+				TokenURL:    srv.URL,
+				HTTPClient:  srv.Client(),
+			}
+			done := make(chan struct{})
+			tok, err := cfg.AuthorizeLoopback(context.Background(), time.Second, func(authURL string) error {
+				u, err := url.Parse(authURL)
+				if err != nil {
+					return err
+				}
+				go func() {
+					defer close(done)
+					tt.callback(t, u.Query().Get("redirect_uri"), u.Query().Get("state"))
+				}()
+				return nil
+			})
+			<-done
+			var cerr *gotwtr.OAuth2CallbackError
+			var herr *gotwtr.HTTPError
+			switch {
+			case tt.wantErr == nil:
+				if err != nil {
+					t.Fatalf("AuthorizeLoopback() error = %v", err)
+				}
+				if tok.AccessToken != "access" || tok.RefreshToken != "refresh" {
+					t.Errorf("AuthorizeLoopback() = %+v", tok)
+				}
+			case errors.As(tt.wantErr, &cerr):
+				var got *gotwtr.OAuth2CallbackError
+				if !errors.As(err, &got) || got.Code != cerr.Code {
+					t.Errorf("AuthorizeLoopback() error = %v, want %v", err, tt.wantErr)
+				}
+			case errors.As(tt.wantErr, &herr):
+				var got *gotwtr.HTTPError
+				if !errors.As(err, &got) || got.StatusCode != herr.StatusCode {
+					t.Errorf("AuthorizeLoopback() error = %v, want %v", err, tt.wantErr)
+				}
+			default:
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("AuthorizeLoopback() error = %v, want %v", err, tt.wantErr)
+				}
+			}
+		})
+	}
+}
+
+func Test_OAuth2Config_AuthorizeLoopback_notLoopback(t *testing.T) {
+	t.Parallel()
+	cfg := &gotwtr.OAuth2Config{RedirectURL: "http://example.com/callback"}
+	if _, err := cfg.AuthorizeLoopback(context.Background(), time.Second, func(string) error { return nil }); err == nil {
+		t.Error("AuthorizeLoopback() with a non-loopback redirect url must fail")
+	}
+}
+
+func get(t *testing.T, rawURL string) int {
+	t.Helper()
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, rawURL, nil)
+	if err != nil {
+		t.Error(err)
+		return 0
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Errorf("GET %s error = %v", rawURL, err)
+		return 0
+	}
+	defer func() { _ = resp.Body.Close() }()
+	return resp.StatusCode
+}
@@ -2,18 +2,16 @@ package gotwtr
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
 )
 
 func me(ctx context.Context, c *client, opt ...*MeOption) (*MeResponse, error) {
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, meURL, nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+meURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("me new request with ctx: %w", err)
 	}
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.bearerToken))
 
 	var mopt MeOption
 	switch len(opt) {
@@ -26,22 +24,5 @@ func me(ctx context.Context, c *client, opt ...*MeOption) (*MeResponse, error) {
 	}
 	mopt.addQuery(req)
 
-	resp, err := c.client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("me response: %w", err)
-	}
-	defer func() { _ = resp.Body.Close() }()
-
-	var me MeResponse
-	if err := json.NewDecoder(resp.Body).Decode(&me); err != nil {
-		return nil, fmt.Errorf("me decode: %w", err)
-	}
-	if resp.StatusCode != http.StatusOK {
-		return &me, &HTTPError{
-			APIName: "me",
-			Status:  resp.Status,
-			URL:     req.URL.String(),
-		}
-	}
-	return &me, nil
+	return doJSON[MeResponse](c, req, "me", http.StatusOK)
 }
@@ -3,7 +3,6 @@ package gotwtr
 import (
 	"bytes"
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -69,35 +68,14 @@ func uploadMedia(ctx context.Context, c *client, media io.Reader, mediaType stri
 		return nil, fmt.Errorf("upload media: failed to close multipart writer: %w", err)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, mediaUploadURL, &buf)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.uploadBaseURL+mediaUploadURL, &buf)
 	if err != nil {
 		return nil, fmt.Errorf("upload media new request with ctx: %w", err)
 	}
 
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.bearerToken))
 	req.Header.Set("Content-Type", writer.FormDataContentType())
 
-	resp, err := c.client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("upload media response: %w", err)
-	}
-
-	defer func() { _ = resp.Body.Close() }()
-
-	var mur MediaUploadResponse
-	if err := json.NewDecoder(resp.Body).Decode(&mur); err != nil {
-		return nil, fmt.Errorf("upload media decode: %w", err)
-	}
-
-	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
-		return &mur, &HTTPError{
-			APIName: "upload media",
-			Status:  resp.Status,
-			URL:     req.URL.String(),
-		}
-	}
-
-	return &mur, nil
+	return doJSON[MediaUploadResponse](c, req, "upload media", http.StatusOK, http.StatusCreated)
 }
 
 func initializeChunkedUpload(ctx context.Context, c *client, req *MediaUploadInitRequest) (*MediaUploadResponse, error) {
@@ -125,35 +103,14 @@ func initializeChunkedUpload(ctx context.Context, c *client, req *MediaUploadIni
 		data.Set(fmt.Sprintf("additional_owners[%d]", i), owner)
 	}
 
-	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, mediaUploadURL, bytes.NewBufferString(data.Encode()))
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, c.uploadBaseURL+mediaUploadURL, bytes.NewBufferString(data.Encode()))
 	if err != nil {
 		return nil, fmt.Errorf("initialize chunked upload new request with ctx: %w", err)
 	}
 
-	httpReq.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.bearerToken))
 	httpReq.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 
-	resp, err := c.client.Do(httpReq)
-	if err != nil {
-		return nil, fmt.Errorf("initialize chunked upload response: %w", err)
-	}
-
-	defer func() { _ = resp.Body.Close() }()
-
-	var mur MediaUploadResponse
-	if err := json.NewDecoder(resp.Body).Decode(&mur); err != nil {
-		return nil, fmt.Errorf("initialize chunked upload decode: %w", err)
-	}
-
-	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusAccepted {
-		return &mur, &HTTPError{
-			APIName: "initialize chunked upload",
-			Status:  resp.Status,
-			URL:     httpReq.URL.String(),
-		}
-	}
-
-	return &mur, nil
+	return doJSON[MediaUploadResponse](c, httpReq, "initialize chunked upload", http.StatusOK, http.StatusCreated, http.StatusAccepted)
 }
 
 func appendChunkedUpload(ctx context.Context, c *client, req *MediaUploadAppendRequest) error {
@@ -199,39 +156,15 @@ func appendChunkedUpload(ctx context.Context, c *client, req *MediaUploadAppendR
 		return fmt.Errorf("append chunked upload: failed to close multipart writer: %w", err)
 	}
 
-	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, mediaUploadURL, &buf)
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, c.uploadBaseURL+mediaUploadURL, &buf)
 	if err != nil {
 		return fmt.Errorf("append chunked upload new request with ctx: %w", err)
 	}
 
-	httpReq.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.bearerToken))
 	httpReq.Header.Set("Content-Type", writer.FormDataContentType())
 
-	resp, err := c.client.Do(httpReq)
-	if err != nil {
-		return fmt.Errorf("append chunked upload response: %w", err)
-	}
-
-	defer func() { _ = resp.Body.Close() }()
-
-	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
-		// Read error response
-		var mur MediaUploadResponse
-		if decodeErr := json.NewDecoder(resp.Body).Decode(&mur); decodeErr == nil && len(mur.Errors) > 0 {
-			return &HTTPError{
-				APIName: "append chunked upload",
-				Status:  resp.Status,
-				URL:     httpReq.URL.String(),
-			}
-		}
-		return &HTTPError{
-			APIName: "append chunked upload",
-			Status:  resp.Status,
-			URL:     httpReq.URL.String(),
-		}
-	}
-
-	return nil
+	_, err = doJSON[MediaUploadResponse](c, httpReq, "append chunked upload", http.StatusOK, http.StatusNoContent)
+	return err
 }
 
 func finalizeChunkedUpload(ctx context.Context, c *client, req *MediaUploadFinalizeRequest) (*MediaUploadResponse, error) {
@@ -247,35 +180,14 @@ func finalizeChunkedUpload(ctx context.Context, c *client, req *MediaUploadFinal
 	data.Set("command", "FINALIZE")
 	data.Set("media_id", req.MediaID)
 
-	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, mediaUploadURL, bytes.NewBufferString(data.Encode()))
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, c.uploadBaseURL+mediaUploadURL, bytes.NewBufferString(data.Encode()))
 	if err != nil {
 		return nil, fmt.Errorf("finalize chunked upload new request with ctx: %w", err)
 	}
 
-	httpReq.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.bearerToken))
 	httpReq.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 
-	resp, err := c.client.Do(httpReq)
-	if err != nil {
-		return nil, fmt.Errorf("finalize chunked upload response: %w", err)
-	}
-
-	defer func() { _ = resp.Body.Close() }()
-
-	var mur MediaUploadResponse
-	if err := json.NewDecoder(resp.Body).Decode(&mur); err != nil {
-		return nil, fmt.Errorf("finalize chunked upload decode: %w", err)
-	}
-
-	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
-		return &mur, &HTTPError{
-			APIName: "finalize chunked upload",
-			Status:  resp.Status,
-			URL:     httpReq.URL.String(),
-		}
-	}
-
-	return &mur, nil
+	return doJSON[MediaUploadResponse](c, httpReq, "finalize chunked upload", http.StatusOK, http.StatusCreated)
 }
 
 func checkUploadStatus(ctx context.Context, c *client, req *MediaUploadStatusRequest) (*MediaUploadResponse, error) {
@@ -292,34 +204,12 @@ func checkUploadStatus(ctx context.Context, c *client, req *MediaUploadStatusReq
 	params.Set("media_id", req.MediaID)
 
 	// Create URL with query parameters
-	reqURL := fmt.Sprintf("%s?%s", mediaUploadURL, params.Encode())
+	reqURL := fmt.Sprintf("%s?%s", c.uploadBaseURL+mediaUploadURL, params.Encode())
 
 	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("check upload status new request with ctx: %w", err)
 	}
 
-	httpReq.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.bearerToken))
-
-	resp, err := c.client.Do(httpReq)
-	if err != nil {
-		return nil, fmt.Errorf("check upload status response: %w", err)
-	}
-
-	defer func() { _ = resp.Body.Close() }()
-
-	var mur MediaUploadResponse
-	if err := json.NewDecoder(resp.Body).Decode(&mur); err != nil {
-		return nil, fmt.Errorf("check upload status decode: %w", err)
-	}
-
-	if resp.StatusCode != http.StatusOK {
-		return &mur, &HTTPError{
-			APIName: "check upload status",
-			Status:  resp.Status,
-			URL:     httpReq.URL.String(),
-		}
-	}
-
-	return &mur, nil
+	return doJSON[MediaUploadResponse](c, httpReq, "check upload status", http.StatusOK)
 }
\ No newline at end of file
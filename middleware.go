@@ -0,0 +1,34 @@
+package gotwtr
+
+import (
+	"context"
+	"net/http"
+)
+
+// Invoker performs an API operation and returns its decoded response, e.g. *SearchTweetsResponse.
+type Invoker func(ctx context.Context, req *http.Request) (any, error)
+
+// Middleware intercepts every API operation performed by the client.
+// op is the operation name such as "search recent tweets", the same one reported by HTTPError.APIName.
+// A middleware may modify req before passing it to invoke, and observe the decoded response or error it returns.
+// For streaming operations the response is the *http.Response of the connection.
+type Middleware func(ctx context.Context, op string, req *http.Request, invoke Invoker) (any, error)
+
+// WithMiddleware appends middlewares to the client.
+// The first middleware is the outermost one and sees the operation first.
+func WithMiddleware(mw ...Middleware) ClientOption {
+	return func(c *client) {
+		c.middlewares = append(c.middlewares, mw...)
+	}
+}
+
+// intercept runs invoke through the middlewares of the client.
+func (c *client) intercept(ctx context.Context, op string, req *http.Request, invoke Invoker) (any, error) {
+	for i := len(c.middlewares) - 1; i >= 0; i-- {
+		mw, next := c.middlewares[i], invoke
+		invoke = func(ctx context.Context, req *http.Request) (any, error) {
+			return mw(ctx, op, req, next)
+		}
+	}
+	return invoke(ctx, req)
+}
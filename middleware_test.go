@@ -0,0 +1,123 @@
+package gotwtr_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sivchari/gotwtr"
+)
+
+func Test_WithMiddleware(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name       string
+		status     int
+		body       string
+		wantCalls  []string
+		wantResult bool
+		wantErr    bool
+	}{
+		{
+			name:       "200 ok",
+			status:     http.StatusOK,
+			body:       `{"data":[{"id":"1","text":"hello"}],"meta":{"result_count":1}}`,
+			wantCalls:  []string{"outer search recent tweets", "inner search recent tweets", "inner done", "outer done"},
+			wantResult: true,
+			wantErr:    false,
+		},
+		{
+			name:       "403 forbidden",
+			status:     http.StatusForbidden,
+			body:       `{"title":"Forbidden","type":"about:blank","status":403}`,
+			wantCalls:  []string{"outer search recent tweets", "inner search recent tweets", "inner done", "outer done"},
+			wantResult: true,
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var (
+				calls     []string
+				userAgent string
+				result    any
+				resultErr error
+			)
+			client := mockHTTPClient(func(req *http.Request) *http.Response {
+				userAgent = req.Header.Get("User-Agent")
+				return &http.Response{
+					StatusCode: tt.status,
+					Body:       io.NopCloser(strings.NewReader(tt.body)),
+				}
+			})
+			outer := func(ctx context.Context, op string, req *http.Request, invoke gotwtr.Invoker) (any, error) {
+				calls = append(calls, "outer "+op)
+				req.Header.Set("User-Agent", "gotwtr-test")
+				res, err := invoke(ctx, req)
+				result, resultErr = res, err
+				calls = append(calls, "outer done")
+				return res, err
+			}
+			inner := func(ctx context.Context, op string, req *http.Request, invoke gotwtr.Invoker) (any, error) {
+				calls = append(calls, "inner "+op)
+				res, err := invoke(ctx, req)
+				calls = append(calls, "inner done")
+				return res, err
+			}
+			c := gotwtr.New("key", gotwtr.WithHTTPClient(client), gotwtr.WithMiddleware(outer, inner))
+			got, err := c.SearchRecentTweets(context.Background(), "gotwtr")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("client.SearchRecentTweets() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.wantCalls, calls); diff != "" {
+				t.Errorf("middleware calls mismatch (-want +got):\n%s", diff)
+			}
+			if userAgent != "gotwtr-test" {
+				t.Errorf("User-Agent = %q, want %q", userAgent, "gotwtr-test")
+			}
+			if r, ok := result.(*gotwtr.SearchTweetsResponse); ok != tt.wantResult || r != got {
+				t.Errorf("middleware result = %#v, want %#v", result, got)
+			}
+			if !errors.Is(resultErr, err) {
+				t.Errorf("middleware error = %v, want %v", resultErr, err)
+			}
+		})
+	}
+}
+
+func Test_WithMiddleware_token(t *testing.T) {
+	t.Parallel()
+	client := mockHTTPClient(func(req *http.Request) *http.Response {
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(strings.NewReader(`{"token_type":"bearer","access_token":"token"}`)),
+		}
+	})
+	var ops []string
+	mw := func(ctx context.Context, op string, req *http.Request, invoke gotwtr.Invoker) (any, error) {
+		ops = append(ops, op)
+		return invoke(ctx, req)
+	}
+	c := gotwtr.New("",
+		gotwtr.WithHTTPClient(client),
+		gotwtr.WithConsumerKey("consumerKey"),
+		gotwtr.WithConsumerSecret("consumerSecret"),
+		gotwtr.WithMiddleware(mw),
+	)
+	if _, err := c.GenerateAppOnlyBearerToken(context.Background()); err != nil {
+		t.Fatalf("client.GenerateAppOnlyBearerToken() error = %v", err)
+	}
+	if _, err := c.InvalidateToken(context.Background()); err != nil {
+		t.Fatalf("client.InvalidateToken() error = %v", err)
+	}
+	if diff := cmp.Diff([]string{"generate app only bearer token", "invalidate token"}, ops); diff != "" {
+		t.Errorf("middleware operations mismatch (-want +got):\n%s", diff)
+	}
+}
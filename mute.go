@@ -13,15 +13,13 @@ func muting(ctx context.Context, c *client, userID string, opt ...*MuteOption) (
 	if userID == "" {
 		return nil, errors.New("muting: userID parameter is required")
 	}
-	ep := fmt.Sprintf(mutingURL, userID)
+	ep := c.baseURL + fmt.Sprintf(mutingURL, userID)
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ep, nil)
 	if err != nil {
 		return nil, fmt.Errorf("muting new request with ctx: %w", err)
 	}
 
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.bearerToken))
-
 	var fopt MuteOption
 	switch len(opt) {
 	case 0:
@@ -44,33 +42,14 @@ func muting(ctx context.Context, c *client, userID string, opt ...*MuteOption) (
 	}
 	fopt.addQuery(req)
 
-	resp, err := c.client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("muting response: %w", err)
-	}
-
-	defer func() { _ = resp.Body.Close() }()
-
-	var m MutingResponse
-	if err := json.NewDecoder(resp.Body).Decode(&m); err != nil {
-		return nil, fmt.Errorf("muting: %w", err)
-	}
-	if resp.StatusCode != http.StatusOK {
-		return &m, &HTTPError{
-			APIName: "muting",
-			Status:  resp.Status,
-			URL:     req.URL.String(),
-		}
-	}
-
-	return &m, nil
+	return doJSON[MutingResponse](c, req, "muting", http.StatusOK)
 }
 
 func postMuting(ctx context.Context, c *client, userID string, targetUserID string) (*PostMutingResponse, error) {
 	if userID == "" {
 		return nil, errors.New("post muting: userID parameter is required")
 	}
-	ep := fmt.Sprintf(postMutingURL, userID)
+	ep := c.baseURL + fmt.Sprintf(postMutingURL, userID)
 
 	if targetUserID == "" {
 		return nil, errors.New("post muting: targetUserID parameter is required")
@@ -87,28 +66,9 @@ func postMuting(ctx context.Context, c *client, userID string, targetUserID stri
 	if err != nil {
 		return nil, fmt.Errorf("post muting new request with ctx: %w", err)
 	}
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.bearerToken))
 	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := c.client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("post muting response: %w", err)
-	}
-	defer func() { _ = resp.Body.Close() }()
-
-	var postMuting PostMutingResponse
-	if err := json.NewDecoder(resp.Body).Decode(&postMuting); err != nil {
-		return nil, fmt.Errorf("post muting decode: %w", err)
-	}
-	if resp.StatusCode != http.StatusOK {
-		return &postMuting, &HTTPError{
-			APIName: "post muting",
-			Status:  resp.Status,
-			URL:     req.URL.String(),
-		}
-	}
-
-	return &postMuting, nil
+	return doJSON[PostMutingResponse](c, req, "post muting", http.StatusOK)
 }
 
 func undoMuting(ctx context.Context, c *client, sourceUserID string, targetUserID string) (*UndoMutingResponse, error) {
@@ -118,31 +78,12 @@ func undoMuting(ctx context.Context, c *client, sourceUserID string, targetUserI
 	if targetUserID == "" {
 		return nil, errors.New("undo muting: targetUserID parameter is required")
 	}
-	ep := fmt.Sprintf(undoMutingURL, sourceUserID, targetUserID)
+	ep := c.baseURL + fmt.Sprintf(undoMutingURL, sourceUserID, targetUserID)
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, ep, nil)
 	if err != nil {
 		return nil, fmt.Errorf("undo muting new request with ctx: %w", err)
 	}
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.bearerToken))
-
-	resp, err := c.client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("undo muting response: %w", err)
-	}
-	defer func() { _ = resp.Body.Close() }()
-
-	var undoMuting UndoMutingResponse
-	if err := json.NewDecoder(resp.Body).Decode(&undoMuting); err != nil {
-		return nil, fmt.Errorf("undo muting decode: %w", err)
-	}
-	if resp.StatusCode != http.StatusOK {
-		return &undoMuting, &HTTPError{
-			APIName: "undo muting",
-			Status:  resp.Status,
-			URL:     req.URL.String(),
-		}
-	}
 
-	return &undoMuting, nil
+	return doJSON[UndoMutingResponse](c, req, "undo muting", http.StatusOK)
 }
@@ -0,0 +1,173 @@
+package gotwtr
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/hex"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// oauth1 is the OAuth 1.0a access token the requests of the client are signed with.
+type oauth1 struct {
+	token       string
+	tokenSecret string
+}
+
+// WithOAuth1 makes the client sign each request with OAuth 1.0a HMAC-SHA1
+// using the consumer key and secret of the client and the user's access token and secret,
+// instead of sending a bearer token.
+// The consumer key and secret are set by WithConsumerKey and WithConsumerSecret.
+func WithOAuth1(accessToken, accessTokenSecret string) ClientOption {
+	return func(c *client) {
+		c.oauth1 = &oauth1{
+			token:       accessToken,
+			tokenSecret: accessTokenSecret,
+		}
+	}
+}
+
+// signOAuth1 sets the OAuth 1.0a Authorization header of req.
+func (c *client) signOAuth1(req *http.Request) error {
+	nonce, err := oauth1Nonce()
+	if err != nil {
+		return err
+	}
+	form, err := formParams(req)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", oauth1Authorization(req, form, c.consumerKey, c.consumerSecret, c.oauth1.token, c.oauth1.tokenSecret, nonce, time.Now().Unix()))
+	return nil
+}
+
+func oauth1Nonce() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// formParams returns the parameters of a form-encoded body, which are part of the signature.
+// JSON and multipart bodies are not signed.
+func formParams(req *http.Request) (url.Values, error) {
+	mt, _, _ := mime.ParseMediaType(req.Header.Get("Content-Type"))
+	if mt != "application/x-www-form-urlencoded" || req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	if req.GetBody == nil {
+		return nil, errRewindBody
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = body.Close() }()
+	b, err := io.ReadAll(body)
+	if err != nil {
+		return nil, err
+	}
+	return url.ParseQuery(string(b))
+}
+
+// oauth1Authorization returns the OAuth 1.0a Authorization header of req signed with HMAC-SHA1 as specified by RFC 5849.
+func oauth1Authorization(req *http.Request, form url.Values, consumerKey, consumerSecret, token, tokenSecret, nonce string, timestamp int64) string {
+	oauthParams := map[string]string{
+		"oauth_consumer_key":     consumerKey,
+		"oauth_nonce":            nonce,
+		"oauth_signature_method": "HMAC-SHA1",
+		"oauth_timestamp":        strconv.FormatInt(timestamp, 10),
+		"oauth_version":          "1.0",
+	}
+	if token != "" {
+		oauthParams["oauth_token"] = token
+	}
+
+	// The parameters are sorted by their encoded name and then value, not as joined "name=value" strings,
+	// in which a name like "a1" would sort before its prefix "a".
+	var params [][2]string
+	add := func(k, v string) {
+		params = append(params, [2]string{percentEncode(k), percentEncode(v)})
+	}
+	for k, v := range oauthParams {
+		add(k, v)
+	}
+	for k, vs := range req.URL.Query() {
+		for _, v := range vs {
+			add(k, v)
+		}
+	}
+	for k, vs := range form {
+		for _, v := range vs {
+			add(k, v)
+		}
+	}
+	sort.Slice(params, func(i, j int) bool {
+		if params[i][0] != params[j][0] {
+			return params[i][0] < params[j][0]
+		}
+		return params[i][1] < params[j][1]
+	})
+	pairs := make([]string, 0, len(params))
+	for _, p := range params {
+		pairs = append(pairs, p[0]+"="+p[1])
+	}
+
+	base := strings.ToUpper(req.Method) + "&" + percentEncode(baseStringURI(req.URL)) + "&" + percentEncode(strings.Join(pairs, "&"))
+	mac := hmac.New(sha1.New, []byte(percentEncode(consumerSecret)+"&"+percentEncode(tokenSecret)))
+	mac.Write([]byte(base))
+	oauthParams["oauth_signature"] = base64.StdEncoding.EncodeToString(mac.Sum(nil))
+
+	keys := make([]string, 0, len(oauthParams))
+	for k := range oauthParams {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	header := make([]string, 0, len(keys))
+	for _, k := range keys {
+		header = append(header, percentEncode(k)+`="`+percentEncode(oauthParams[k])+`"`)
+	}
+	return "OAuth " + strings.Join(header, ", ")
+}
+
+// baseStringURI returns the URI of the signature base string, without the query and default port.
+func baseStringURI(u *url.URL) string {
+	scheme := strings.ToLower(u.Scheme)
+	host := strings.ToLower(u.Hostname())
+	if port := u.Port(); port != "" && !(scheme == "http" && port == "80") && !(scheme == "https" && port == "443") {
+		host += ":" + port
+	}
+	path := u.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	return scheme + "://" + host + path
+}
+
+// percentEncode encodes s as specified by RFC 3986, leaving only unreserved characters.
+func percentEncode(s string) string {
+	const hex = "0123456789ABCDEF"
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		ch := s[i]
+		switch {
+		case 'A' <= ch && ch <= 'Z', 'a' <= ch && ch <= 'z', '0' <= ch && ch <= '9',
+			ch == '-', ch == '.', ch == '_', ch == '~':
+			b.WriteByte(ch)
+		default:
+			b.WriteByte('%')
+			b.WriteByte(hex[ch>>4])
+			b.WriteByte(hex[ch&0x0f])
+		}
+	}
+	return b.String()
+}
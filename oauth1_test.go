@@ -0,0 +1,152 @@
+package gotwtr_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/sivchari/gotwtr"
+)
+
+func Test_oauth1Authorization(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		method         string
+		url            string
+		form           url.Values
+		consumerKey    string
+		consumerSecret string
+		token          string
+		tokenSecret    string
+		nonce          string
+		timestamp      int64
+	}
+	tests := []struct {
+		name          string
+		args          args
+		wantSignature string
+	}{
+		{
+			// https://developer.x.com/en/docs/authentication/oauth-1-0a/creating-a-signature
+			name: "x developer documentation",
+			args: args{
+				method:         http.MethodPost,
+				url:            "https://api.twitter.com/1.1/statuses/update.json?include_entities=true",
+				form:           url.Values{"status": {"Hello Ladies + Gentlemen, a signed OAuth request!"}},
+				consumerKey:    "xvz1evFS4wEEPTGEFPHBog",
+				consumerSecret: "kAcSOqF21Fu85e7zjz7ZN2U4ZRhfV3WpwPAoE3Z7kBw",
+				token:          "370773112-GmHxMAgYyLbNEtIKZeRNFsMKPR9EyMZeS9weJAEb",
+				tokenSecret:    "LswwdoUaIvS8ltyTt5jkRh4J50vUPVVHtR2YPi5kE",
+				nonce:          "kYjzVBB8Y0ZFabxSWbWovY3uYSQ2pTgmZeNu2VS4cg",
+				timestamp:      1318622958,
+			},
+			wantSignature: "hCtSmYh+iHYCEqBWrE7C7hYmtUk=",
+		},
+		{
+			// OAuth Core 1.0 Appendix A.5.
+			name: "oauth core 1.0",
+			args: args{
+				method:         http.MethodGet,
+				url:            "http://photos.example.net/photos?file=vacation.jpg&size=original",
+				consumerKey:    "dpf43f3p2l4k3l03",
+				consumerSecret: "kd94hf93k423kf44",
+				token:          "nnch734d00sl2jdk",
+				tokenSecret:    "pfkkdhi9sl3r4s00",
+				nonce:          "kllo9940pd9333jh",
+				timestamp:      1191242096,
+			},
+			wantSignature: "tR3+Ty81lMeYAr/Fid0kMTYa/WM=",
+		},
+		{
+			// Names that are prefixes of other names, and a repeated name, sorted as RFC 5849 section 3.4.1.3.2.
+			name: "prefix names",
+			args: args{
+				method:         http.MethodGet,
+				url:            "https://api.x.com/2/users?a1=b&a=z&a-b=c&a=y",
+				consumerKey:    "consumer-key",
+				consumerSecret: "consumer-secret",
+				token:          "access-token",
+				tokenSecret:    "access-secret",
+				nonce:          "nonce",
+				timestamp:      1318622958,
+			},
+			wantSignature: "N1vq72tLfXzX0G/Qv40vsb8KiuU=",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			req, err := http.NewRequestWithContext(context.Background(), tt.args.method, tt.args.url, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := gotwtr.OAuth1Authorization(req, tt.args.form, tt.args.consumerKey, tt.args.consumerSecret, tt.args.token, tt.args.tokenSecret, tt.args.nonce, tt.args.timestamp)
+			params := oauth1Params(t, got)
+			if params["oauth_signature"] != tt.wantSignature {
+				t.Errorf("oauth_signature = %v, want %v", params["oauth_signature"], tt.wantSignature)
+			}
+			if params["oauth_token"] != tt.args.token || params["oauth_signature_method"] != "HMAC-SHA1" {
+				t.Errorf("Authorization = %v", got)
+			}
+		})
+	}
+}
+
+func Test_WithOAuth1(t *testing.T) {
+	t.Parallel()
+	client := mockHTTPClient(func(req *http.Request) *http.Response {
+		auth := req.Header.Get("Authorization")
+		params := oauth1Params(t, auth)
+		var form url.Values
+		if req.Header.Get("Content-Type") == "application/x-www-form-urlencoded" {
+			b, _ := io.ReadAll(req.Body)
+			form, _ = url.ParseQuery(string(b))
+		}
+		ts, _ := strconv.ParseInt(params["oauth_timestamp"], 10, 64)
+		want := oauth1Params(t, gotwtr.OAuth1Authorization(req, form, "consumer-key", "consumer-secret", "access-token", "access-secret", params["oauth_nonce"], ts))
+		if params["oauth_signature"] != want["oauth_signature"] {
+			t.Errorf("%s %s: oauth_signature = %v, want %v", req.Method, req.URL, params["oauth_signature"], want["oauth_signature"])
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(strings.NewReader(`{"media_id_string":"1"}`)),
+		}
+	})
+	c := gotwtr.New("",
+		gotwtr.WithHTTPClient(client),
+		gotwtr.WithConsumerKey("consumer-key"),
+		gotwtr.WithConsumerSecret("consumer-secret"),
+		gotwtr.WithOAuth1("access-token", "access-secret"),
+	)
+	// A form-encoded body is part of the signature.
+	if _, err := c.InitializeChunkedUpload(context.Background(), &gotwtr.MediaUploadInitRequest{MediaType: "image/png", TotalBytes: 1}); err != nil {
+		t.Errorf("InitializeChunkedUpload() error = %v", err)
+	}
+	// A multipart body is not.
+	if _, err := c.UploadMedia(context.Background(), strings.NewReader("png"), "image/png"); err != nil {
+		t.Errorf("UploadMedia() error = %v", err)
+	}
+}
+
+// oauth1Params parses the parameters of an OAuth Authorization header.
+func oauth1Params(t *testing.T, header string) map[string]string {
+	t.Helper()
+	if !strings.HasPrefix(header, "OAuth ") {
+		t.Fatalf("Authorization = %q, want OAuth", header)
+	}
+	params := make(map[string]string)
+	for _, kv := range strings.Split(strings.TrimPrefix(header, "OAuth "), ", ") {
+		k, v, _ := strings.Cut(kv, "=")
+		v, err := url.PathUnescape(strings.Trim(v, `"`))
+		if err != nil {
+			t.Fatal(err)
+		}
+		params[k] = v
+	}
+	return params
+}
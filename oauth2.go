@@ -3,33 +3,16 @@ package gotwtr
 import (
 	"context"
 	"encoding/base64"
-	"encoding/json"
 	"errors"
-	"io"
 	"net/http"
 	"net/url"
 	"strings"
 )
 
 type oauth struct {
-	tokenType   string
-	accessToken string
-	errors      []*APIResponseError
-}
-
-func (o *oauth) unmarshalJSON(b io.ReadCloser) error {
-	var d struct {
-		TokenType   string              `json:"token_type,omitempty"`
-		AccessToken string              `json:"access_token,omitempty"`
-		Errors      []*APIResponseError `json:"errors,omitempty"`
-	}
-	if err := json.NewDecoder(b).Decode(&d); err != nil {
-		return err
-	}
-	o.tokenType = d.TokenType
-	o.accessToken = d.AccessToken
-	o.errors = d.Errors
-	return nil
+	TokenType   string              `json:"token_type,omitempty"`
+	AccessToken string              `json:"access_token,omitempty"`
+	Errors      []*APIResponseError `json:"errors,omitempty"`
 }
 
 func generateAppOnlyBearerToken(ctx context.Context, c *client) (bool, error) {
@@ -43,34 +26,19 @@ func generateAppOnlyBearerToken(ctx context.Context, c *client) (bool, error) {
 	}
 	credentials := ck + ":" + cs
 	b64credentials := base64.StdEncoding.EncodeToString([]byte(credentials))
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, generateAppOnlyBearerTokenURL, nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.oauthBaseURL+generateAppOnlyBearerTokenURL, nil)
 	if err != nil {
 		return false, err
 	}
 	req.Header.Set("Authorization", "Basic "+b64credentials)
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded;charset=UTF-8")
 
-	resp, err := c.client.Do(req)
+	o, err := doJSON[oauth](c, req, "generate app only bearer token", http.StatusOK)
 	if err != nil {
 		return false, err
 	}
-	defer func() { _ = resp.Body.Close() }()
-
-	if resp.StatusCode != http.StatusOK {
-		return false, &HTTPError{
-			APIName: "generate app only bearer token",
-			Status:  resp.Status,
-			URL:     req.URL.String(),
-		}
-	}
 
-	var o oauth
-	err = o.unmarshalJSON(resp.Body)
-	if err != nil {
-		return false, err
-	}
-
-	c.bearerToken = o.accessToken
+	c.bearerToken.set(o.AccessToken)
 
 	return true, nil
 }
@@ -81,12 +49,13 @@ type InvalidateTokenResponse struct {
 }
 
 func (c *client) InvalidateToken(ctx context.Context) (*InvalidateTokenResponse, error) {
-	if c.bearerToken == "" {
+	bearerToken := c.bearerToken.get()
+	if bearerToken == "" {
 		return nil, errors.New("bearer token is required for invalidation")
 	}
 
 	form := url.Values{}
-	form.Set("access_token", c.bearerToken)
+	form.Set("access_token", bearerToken)
 
 	ck := c.consumerKey
 	cs := c.consumerSecret
@@ -100,7 +69,7 @@ func (c *client) InvalidateToken(ctx context.Context) (*InvalidateTokenResponse,
 	credentials := ck + ":" + cs
 	b64credentials := base64.StdEncoding.EncodeToString([]byte(credentials))
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, invalidateTokenURL, strings.NewReader(form.Encode()))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.oauthBaseURL+invalidateTokenURL, strings.NewReader(form.Encode()))
 	if err != nil {
 		return nil, err
 	}
@@ -108,27 +77,13 @@ func (c *client) InvalidateToken(ctx context.Context) (*InvalidateTokenResponse,
 	req.Header.Set("Authorization", "Basic "+b64credentials)
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 
-	resp, err := c.client.Do(req)
+	invalidateTokenResponse, err := doJSON[InvalidateTokenResponse](c, req, "invalidate token", http.StatusOK)
 	if err != nil {
-		return nil, err
-	}
-	defer func() { _ = resp.Body.Close() }()
-
-	var invalidateTokenResponse InvalidateTokenResponse
-	if err := json.NewDecoder(resp.Body).Decode(&invalidateTokenResponse); err != nil {
-		return nil, err
-	}
-
-	if resp.StatusCode != http.StatusOK {
-		return &invalidateTokenResponse, &HTTPError{
-			APIName: "invalidate token",
-			Status:  resp.Status,
-			URL:     req.URL.String(),
-		}
+		return invalidateTokenResponse, err
 	}
 
 	// トークンを無効化したのでクライアントからも削除
-	c.bearerToken = ""
+	c.bearerToken.set("")
 
-	return &invalidateTokenResponse, nil
+	return invalidateTokenResponse, nil
 }
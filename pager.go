@@ -0,0 +1,497 @@
+package gotwtr
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrNoMorePages is returned by Pager.Next once every page has been fetched.
+var ErrNoMorePages = errors.New("gotwtr: no more pages")
+
+// Pager walks the pages of a paginated endpoint by copying the next token of each response
+// into the pagination token of the following request.
+type Pager[R any] struct {
+	// MaxItems stops the pager once at least MaxItems items have been fetched. Zero means no limit.
+	// The last page is returned as is, so it may hold more items than the remainder.
+	MaxItems int
+
+	fetch func(ctx context.Context, token string) (*R, error)
+	next  func(*R) (string, int)
+	token string
+	items int
+	done  bool
+	err   error
+}
+
+func newPager[O, R any](opt []*O, token func(*O) *string, fetch func(context.Context, *O) (*R, error), next func(*R) (string, int)) *Pager[R] {
+	var o O
+	p := &Pager[R]{
+		next: next,
+	}
+	switch len(opt) {
+	case 0:
+		// do nothing
+	case 1:
+		if opt[0] != nil {
+			o = *opt[0]
+		}
+	default:
+		p.err = errors.New("pager: only one option is allowed")
+	}
+	p.token = *token(&o)
+	p.fetch = func(ctx context.Context, t string) (*R, error) {
+		*token(&o) = t
+		return fetch(ctx, &o)
+	}
+	return p
+}
+
+// HasNext reports whether another page may be fetched.
+func (p *Pager[R]) HasNext() bool {
+	return !p.done
+}
+
+// Token returns the pagination token of the next page.
+// It can be saved and set on the option of a new pager to resume from that page.
+func (p *Pager[R]) Token() string {
+	return p.token
+}
+
+// Next fetches the next page. It returns ErrNoMorePages once every page has been fetched.
+// A failed page can be fetched again by calling Next again.
+func (p *Pager[R]) Next(ctx context.Context) (*R, error) {
+	if p.err != nil {
+		return nil, p.err
+	}
+	if p.done {
+		return nil, ErrNoMorePages
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	page, err := p.fetch(ctx, p.token)
+	if err != nil {
+		return page, err
+	}
+	token, n := p.next(page)
+	p.token = token
+	p.items += n
+	if token == "" || (p.MaxItems > 0 && p.items >= p.MaxItems) {
+		p.done = true
+	}
+	return page, nil
+}
+
+// All returns an iterator over the remaining pages.
+// Its signature matches iter.Seq2[*R, error], so it can be used with range-over-func.
+// Iteration stops after the first error, including the cancellation of ctx.
+func (p *Pager[R]) All(ctx context.Context) func(yield func(*R, error) bool) {
+	return func(yield func(*R, error) bool) {
+		for p.HasNext() {
+			page, err := p.Next(ctx)
+			if !yield(page, err) || err != nil {
+				return
+			}
+		}
+	}
+}
+
+// NewUserTweetTimelinePager returns a Pager walking every page of UserTweetTimeline.
+// The pager starts from opt.PaginationToken if it is set.
+func NewUserTweetTimelinePager(c Tweets, userID string, opt ...*UserTweetTimelineOption) *Pager[UserTweetTimelineResponse] {
+	return newPager(opt, func(o *UserTweetTimelineOption) *string { return &o.PaginationToken },
+		func(ctx context.Context, o *UserTweetTimelineOption) (*UserTweetTimelineResponse, error) {
+			return c.UserTweetTimeline(ctx, userID, o)
+		},
+		func(r *UserTweetTimelineResponse) (string, int) {
+			if r.Meta == nil {
+				return "", len(r.Tweets)
+			}
+			return r.Meta.NextToken, len(r.Tweets)
+		},
+	)
+}
+
+// NewUserMentionTimelinePager returns a Pager walking every page of UserMentionTimeline.
+// The pager starts from opt.PaginationToken if it is set.
+func NewUserMentionTimelinePager(c Tweets, userID string, opt ...*UserMentionTimelineOption) *Pager[UserMentionTimelineResponse] {
+	return newPager(opt, func(o *UserMentionTimelineOption) *string { return &o.PaginationToken },
+		func(ctx context.Context, o *UserMentionTimelineOption) (*UserMentionTimelineResponse, error) {
+			return c.UserMentionTimeline(ctx, userID, o)
+		},
+		func(r *UserMentionTimelineResponse) (string, int) {
+			if r.Meta == nil {
+				return "", len(r.Tweets)
+			}
+			return r.Meta.NextToken, len(r.Tweets)
+		},
+	)
+}
+
+// NewUserReverseChronologicalTimelinePager returns a Pager walking every page of UserReverseChronologicalTimeline.
+// The pager starts from opt.PaginationToken if it is set.
+func NewUserReverseChronologicalTimelinePager(c Tweets, userID string, opt ...*UserReverseChronologicalTimelineOption) *Pager[UserReverseChronologicalTimelineResponse] {
+	return newPager(opt, func(o *UserReverseChronologicalTimelineOption) *string { return &o.PaginationToken },
+		func(ctx context.Context, o *UserReverseChronologicalTimelineOption) (*UserReverseChronologicalTimelineResponse, error) {
+			return c.UserReverseChronologicalTimeline(ctx, userID, o)
+		},
+		func(r *UserReverseChronologicalTimelineResponse) (string, int) {
+			if r.Meta == nil {
+				return "", len(r.Tweets)
+			}
+			return r.Meta.NextToken, len(r.Tweets)
+		},
+	)
+}
+
+// NewSearchRecentTweetsPager returns a Pager walking every page of SearchRecentTweets.
+// The pager starts from opt.NextToken if it is set.
+func NewSearchRecentTweetsPager(c Tweets, tweet string, opt ...*SearchTweetsOption) *Pager[SearchTweetsResponse] {
+	return newPager(opt, func(o *SearchTweetsOption) *string { return &o.NextToken },
+		func(ctx context.Context, o *SearchTweetsOption) (*SearchTweetsResponse, error) {
+			return c.SearchRecentTweets(ctx, tweet, o)
+		},
+		func(r *SearchTweetsResponse) (string, int) {
+			if r.Meta == nil {
+				return "", len(r.Tweets)
+			}
+			return r.Meta.NextToken, len(r.Tweets)
+		},
+	)
+}
+
+// NewSearchAllTweetsPager returns a Pager walking every page of SearchAllTweets.
+// The pager starts from opt.NextToken if it is set.
+func NewSearchAllTweetsPager(c Tweets, tweet string, opt ...*SearchTweetsOption) *Pager[SearchTweetsResponse] {
+	return newPager(opt, func(o *SearchTweetsOption) *string { return &o.NextToken },
+		func(ctx context.Context, o *SearchTweetsOption) (*SearchTweetsResponse, error) {
+			return c.SearchAllTweets(ctx, tweet, o)
+		},
+		func(r *SearchTweetsResponse) (string, int) {
+			if r.Meta == nil {
+				return "", len(r.Tweets)
+			}
+			return r.Meta.NextToken, len(r.Tweets)
+		},
+	)
+}
+
+// NewCountAllTweetsPager returns a Pager walking every page of CountAllTweets.
+// The pager starts from opt.NextToken if it is set.
+func NewCountAllTweetsPager(c Tweets, tweet string, opt ...*TweetCountsAllOption) *Pager[TweetCountsResponse] {
+	return newPager(opt, func(o *TweetCountsAllOption) *string { return &o.NextToken },
+		func(ctx context.Context, o *TweetCountsAllOption) (*TweetCountsResponse, error) {
+			return c.CountAllTweets(ctx, tweet, o)
+		},
+		func(r *TweetCountsResponse) (string, int) {
+			if r.Meta == nil {
+				return "", len(r.Counts)
+			}
+			return r.Meta.NextToken, len(r.Counts)
+		},
+	)
+}
+
+// NewTweetsUserLikedPager returns a Pager walking every page of TweetsUserLiked.
+// The pager starts from opt.PaginationToken if it is set.
+func NewTweetsUserLikedPager(c Tweets, userID string, opt ...*TweetsUserLikedOption) *Pager[TweetsUserLikedResponse] {
+	return newPager(opt, func(o *TweetsUserLikedOption) *string { return &o.PaginationToken },
+		func(ctx context.Context, o *TweetsUserLikedOption) (*TweetsUserLikedResponse, error) {
+			return c.TweetsUserLiked(ctx, userID, o)
+		},
+		func(r *TweetsUserLikedResponse) (string, int) {
+			if r.Meta == nil {
+				return "", len(r.Tweets)
+			}
+			return r.Meta.NextToken, len(r.Tweets)
+		},
+	)
+}
+
+// NewLookupUserBookmarksPager returns a Pager walking every page of LookupUserBookmarks.
+// The pager starts from opt.PaginationToken if it is set.
+func NewLookupUserBookmarksPager(c Tweets, userID string, opt ...*LookupUserBookmarksOption) *Pager[LookupUserBookmarksResponse] {
+	return newPager(opt, func(o *LookupUserBookmarksOption) *string { return &o.PaginationToken },
+		func(ctx context.Context, o *LookupUserBookmarksOption) (*LookupUserBookmarksResponse, error) {
+			return c.LookupUserBookmarks(ctx, userID, o)
+		},
+		func(r *LookupUserBookmarksResponse) (string, int) {
+			if r.Meta == nil {
+				return "", len(r.Tweets)
+			}
+			return r.Meta.NextToken, len(r.Tweets)
+		},
+	)
+}
+
+// NewQuoteTweetsPager returns a Pager walking every page of QuoteTweets.
+// The pager starts from opt.PaginationToken if it is set.
+func NewQuoteTweetsPager(c Tweets, tweetID string, opt ...*QuoteTweetsOption) *Pager[QuoteTweetsResponse] {
+	return newPager(opt, func(o *QuoteTweetsOption) *string { return &o.PaginationToken },
+		func(ctx context.Context, o *QuoteTweetsOption) (*QuoteTweetsResponse, error) {
+			return c.QuoteTweets(ctx, tweetID, o)
+		},
+		func(r *QuoteTweetsResponse) (string, int) {
+			if r.Meta == nil {
+				return "", len(r.Tweets)
+			}
+			return r.Meta.NextToken, len(r.Tweets)
+		},
+	)
+}
+
+// NewFollowersPager returns a Pager walking every page of Followers.
+// The pager starts from opt.PaginationToken if it is set.
+func NewFollowersPager(c Users, userID string, opt ...*FollowOption) *Pager[FollowersResponse] {
+	return newPager(opt, func(o *FollowOption) *string { return &o.PaginationToken },
+		func(ctx context.Context, o *FollowOption) (*FollowersResponse, error) {
+			return c.Followers(ctx, userID, o)
+		},
+		func(r *FollowersResponse) (string, int) {
+			if r.Meta == nil {
+				return "", len(r.Users)
+			}
+			return r.Meta.NextToken, len(r.Users)
+		},
+	)
+}
+
+// NewFollowingPager returns a Pager walking every page of Following.
+// The pager starts from opt.PaginationToken if it is set.
+func NewFollowingPager(c Users, userID string, opt ...*FollowOption) *Pager[FollowingResponse] {
+	return newPager(opt, func(o *FollowOption) *string { return &o.PaginationToken },
+		func(ctx context.Context, o *FollowOption) (*FollowingResponse, error) {
+			return c.Following(ctx, userID, o)
+		},
+		func(r *FollowingResponse) (string, int) {
+			if r.Meta == nil {
+				return "", len(r.Users)
+			}
+			return r.Meta.NextToken, len(r.Users)
+		},
+	)
+}
+
+// NewBlockingPager returns a Pager walking every page of Blocking.
+// The pager starts from opt.PaginationToken if it is set.
+func NewBlockingPager(c Users, userID string, opt ...*BlockOption) *Pager[BlockingResponse] {
+	return newPager(opt, func(o *BlockOption) *string { return &o.PaginationToken },
+		func(ctx context.Context, o *BlockOption) (*BlockingResponse, error) {
+			return c.Blocking(ctx, userID, o)
+		},
+		func(r *BlockingResponse) (string, int) {
+			if r.Meta == nil {
+				return "", len(r.Users)
+			}
+			return r.Meta.NextToken, len(r.Users)
+		},
+	)
+}
+
+// NewMutingPager returns a Pager walking every page of Muting.
+// The pager starts from opt.PaginationToken if it is set.
+func NewMutingPager(c Users, userID string, opt ...*MuteOption) *Pager[MutingResponse] {
+	return newPager(opt, func(o *MuteOption) *string { return &o.PaginationToken },
+		func(ctx context.Context, o *MuteOption) (*MutingResponse, error) {
+			return c.Muting(ctx, userID, o)
+		},
+		func(r *MutingResponse) (string, int) {
+			if r.Meta == nil {
+				return "", len(r.Users)
+			}
+			return r.Meta.NextToken, len(r.Users)
+		},
+	)
+}
+
+// NewSearchUsersPager returns a Pager walking every page of SearchUsers.
+// The pager starts from opt.PaginationToken if it is set.
+func NewSearchUsersPager(c Users, query string, opt ...*SearchUsersOption) *Pager[SearchUsersResponse] {
+	return newPager(opt, func(o *SearchUsersOption) *string { return &o.PaginationToken },
+		func(ctx context.Context, o *SearchUsersOption) (*SearchUsersResponse, error) {
+			return c.SearchUsers(ctx, query, o)
+		},
+		func(r *SearchUsersResponse) (string, int) {
+			if r.Meta == nil {
+				return "", len(r.Users)
+			}
+			return r.Meta.NextToken, len(r.Users)
+		},
+	)
+}
+
+// NewLookUpAllListsOwnedPager returns a Pager walking every page of LookUpAllListsOwned.
+// The pager starts from opt.PaginationToken if it is set.
+func NewLookUpAllListsOwnedPager(c Lists, userID string, opt ...*AllListsOwnedOption) *Pager[AllListsOwnedResponse] {
+	return newPager(opt, func(o *AllListsOwnedOption) *string { return &o.PaginationToken },
+		func(ctx context.Context, o *AllListsOwnedOption) (*AllListsOwnedResponse, error) {
+			return c.LookUpAllListsOwned(ctx, userID, o)
+		},
+		func(r *AllListsOwnedResponse) (string, int) {
+			if r.Meta == nil {
+				return "", len(r.Lists)
+			}
+			return r.Meta.NextToken, len(r.Lists)
+		},
+	)
+}
+
+// NewLookUpListTweetsPager returns a Pager walking every page of LookUpListTweets.
+// The pager starts from opt.PaginationToken if it is set.
+func NewLookUpListTweetsPager(c Lists, listID string, opt ...*ListTweetsOption) *Pager[ListTweetsResponse] {
+	return newPager(opt, func(o *ListTweetsOption) *string { return &o.PaginationToken },
+		func(ctx context.Context, o *ListTweetsOption) (*ListTweetsResponse, error) {
+			return c.LookUpListTweets(ctx, listID, o)
+		},
+		func(r *ListTweetsResponse) (string, int) {
+			if r.Meta == nil {
+				return "", len(r.Tweets)
+			}
+			return r.Meta.NextToken, len(r.Tweets)
+		},
+	)
+}
+
+// NewListMembersPager returns a Pager walking every page of ListMembers.
+// The pager starts from opt.PaginationToken if it is set.
+func NewListMembersPager(c Lists, listID string, opt ...*ListMembersOption) *Pager[ListMembersResponse] {
+	return newPager(opt, func(o *ListMembersOption) *string { return &o.PaginationToken },
+		func(ctx context.Context, o *ListMembersOption) (*ListMembersResponse, error) {
+			return c.ListMembers(ctx, listID, o)
+		},
+		func(r *ListMembersResponse) (string, int) {
+			if r.Meta == nil {
+				return "", len(r.Users)
+			}
+			return r.Meta.NextToken, len(r.Users)
+		},
+	)
+}
+
+// NewListsSpecifiedUserPager returns a Pager walking every page of ListsSpecifiedUser.
+// The pager starts from opt.PaginationToken if it is set.
+func NewListsSpecifiedUserPager(c Lists, userID string, opt ...*ListsSpecifiedUserOption) *Pager[ListsSpecifiedUserResponse] {
+	return newPager(opt, func(o *ListsSpecifiedUserOption) *string { return &o.PaginationToken },
+		func(ctx context.Context, o *ListsSpecifiedUserOption) (*ListsSpecifiedUserResponse, error) {
+			return c.ListsSpecifiedUser(ctx, userID, o)
+		},
+		func(r *ListsSpecifiedUserResponse) (string, int) {
+			if r.Meta == nil {
+				return "", len(r.Lists)
+			}
+			return r.Meta.NextToken, len(r.Lists)
+		},
+	)
+}
+
+// NewListFollowersPager returns a Pager walking every page of ListFollowers.
+// The pager starts from opt.PaginationToken if it is set.
+func NewListFollowersPager(c Lists, listID string, opt ...*ListFollowersOption) *Pager[ListFollowersResponse] {
+	return newPager(opt, func(o *ListFollowersOption) *string { return &o.PaginationToken },
+		func(ctx context.Context, o *ListFollowersOption) (*ListFollowersResponse, error) {
+			return c.ListFollowers(ctx, listID, o)
+		},
+		func(r *ListFollowersResponse) (string, int) {
+			if r.Meta == nil {
+				return "", len(r.Users)
+			}
+			return r.Meta.NextToken, len(r.Users)
+		},
+	)
+}
+
+// NewAllListsUserFollowsPager returns a Pager walking every page of AllListsUserFollows.
+// The pager starts from opt.PaginationToken if it is set.
+func NewAllListsUserFollowsPager(c Lists, userID string, opt ...*ListFollowsOption) *Pager[AllListsUserFollowsResponse] {
+	return newPager(opt, func(o *ListFollowsOption) *string { return &o.PaginationToken },
+		func(ctx context.Context, o *ListFollowsOption) (*AllListsUserFollowsResponse, error) {
+			return c.AllListsUserFollows(ctx, userID, o)
+		},
+		func(r *AllListsUserFollowsResponse) (string, int) {
+			if r.Meta == nil {
+				return "", len(r.Lists)
+			}
+			return r.Meta.NextToken, len(r.Lists)
+		},
+	)
+}
+
+// NewLookUpAllOneToOneDMPager returns a Pager walking every page of LookUpAllOneToOneDM.
+// The pager starts from opt.PaginationToken if it is set.
+func NewLookUpAllOneToOneDMPager(c DirectMessages, participantID string, opt ...*DirectMessageOption) *Pager[LookUpAllOneToOneDMResponse] {
+	return newPager(opt, func(o *DirectMessageOption) *string { return &o.PaginationToken },
+		func(ctx context.Context, o *DirectMessageOption) (*LookUpAllOneToOneDMResponse, error) {
+			return c.LookUpAllOneToOneDM(ctx, participantID, o)
+		},
+		func(r *LookUpAllOneToOneDMResponse) (string, int) {
+			if r.Meta == nil {
+				return "", len(r.Message)
+			}
+			return r.Meta.NextToken, len(r.Message)
+		},
+	)
+}
+
+// NewLookUpDMPager returns a Pager walking every page of LookUpDM.
+// The pager starts from opt.PaginationToken if it is set.
+func NewLookUpDMPager(c DirectMessages, dmConversationID string, opt ...*DirectMessageOption) *Pager[LookUpDMResponse] {
+	return newPager(opt, func(o *DirectMessageOption) *string { return &o.PaginationToken },
+		func(ctx context.Context, o *DirectMessageOption) (*LookUpDMResponse, error) {
+			return c.LookUpDM(ctx, dmConversationID, o)
+		},
+		func(r *LookUpDMResponse) (string, int) {
+			if r.Meta == nil {
+				return "", len(r.Message)
+			}
+			return r.Meta.NextToken, len(r.Message)
+		},
+	)
+}
+
+// NewLookUpAllDMPager returns a Pager walking every page of LookUpAllDM.
+// The pager starts from opt.PaginationToken if it is set.
+func NewLookUpAllDMPager(c DirectMessages, opt ...*DirectMessageOption) *Pager[LookUpAllDMResponse] {
+	return newPager(opt, func(o *DirectMessageOption) *string { return &o.PaginationToken },
+		func(ctx context.Context, o *DirectMessageOption) (*LookUpAllDMResponse, error) {
+			return c.LookUpAllDM(ctx, o)
+		},
+		func(r *LookUpAllDMResponse) (string, int) {
+			if r.Meta == nil {
+				return "", len(r.Message)
+			}
+			return r.Meta.NextToken, len(r.Message)
+		},
+	)
+}
+
+// NewSearchPostsEligibleForNotesPager returns a Pager walking every page of SearchPostsEligibleForNotes.
+// The pager starts from opt.PaginationToken if it is set.
+func NewSearchPostsEligibleForNotesPager(c CommunityNotes, opt ...*SearchPostsEligibleForNotesOption) *Pager[SearchPostsEligibleForNotesResponse] {
+	return newPager(opt, func(o *SearchPostsEligibleForNotesOption) *string { return &o.PaginationToken },
+		func(ctx context.Context, o *SearchPostsEligibleForNotesOption) (*SearchPostsEligibleForNotesResponse, error) {
+			return c.SearchPostsEligibleForNotes(ctx, o)
+		},
+		func(r *SearchPostsEligibleForNotesResponse) (string, int) {
+			if r.Meta == nil {
+				return "", len(r.Data)
+			}
+			return r.Meta.NextToken, len(r.Data)
+		},
+	)
+}
+
+// NewSearchNotesWrittenPager returns a Pager walking every page of SearchNotesWritten.
+// The pager starts from opt.PaginationToken if it is set.
+func NewSearchNotesWrittenPager(c CommunityNotes, opt ...*SearchNotesWrittenOption) *Pager[SearchNotesWrittenResponse] {
+	return newPager(opt, func(o *SearchNotesWrittenOption) *string { return &o.PaginationToken },
+		func(ctx context.Context, o *SearchNotesWrittenOption) (*SearchNotesWrittenResponse, error) {
+			return c.SearchNotesWritten(ctx, o)
+		},
+		func(r *SearchNotesWrittenResponse) (string, int) {
+			if r.Meta == nil {
+				return "", len(r.Data)
+			}
+			return r.Meta.NextToken, len(r.Data)
+		},
+	)
+}
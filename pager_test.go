@@ -0,0 +1,133 @@
+package gotwtr_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sivchari/gotwtr"
+)
+
+// followersPages serves three pages of two followers each, chained by "p2" and "p3".
+func followersPages(t *testing.T, tokens *[]string) *http.Client {
+	t.Helper()
+	pages := map[string]string{
+		"":   `{"data":[{"id":"1"},{"id":"2"}],"meta":{"result_count":2,"next_token":"p2"}}`,
+		"p2": `{"data":[{"id":"3"},{"id":"4"}],"meta":{"result_count":2,"next_token":"p3"}}`,
+		"p3": `{"data":[{"id":"5"},{"id":"6"}],"meta":{"result_count":2}}`,
+	}
+	return mockHTTPClient(func(req *http.Request) *http.Response {
+		token := req.URL.Query().Get("pagination_token")
+		*tokens = append(*tokens, token)
+		body, ok := pages[token]
+		if !ok {
+			t.Errorf("unexpected pagination token %q", token)
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(strings.NewReader(body)),
+		}
+	})
+}
+
+func Test_Pager(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		opt      *gotwtr.FollowOption
+		maxItems int
+	}
+	tests := []struct {
+		name       string
+		args       args
+		wantIDs    []string
+		wantTokens []string
+		wantNext   string
+	}{
+		{
+			name:       "walk every page",
+			args:       args{opt: &gotwtr.FollowOption{}},
+			wantIDs:    []string{"1", "2", "3", "4", "5", "6"},
+			wantTokens: []string{"", "p2", "p3"},
+			wantNext:   "",
+		},
+		{
+			name:       "stop at max items",
+			args:       args{opt: &gotwtr.FollowOption{}, maxItems: 3},
+			wantIDs:    []string{"1", "2", "3", "4"},
+			wantTokens: []string{"", "p2"},
+			wantNext:   "p3",
+		},
+		{
+			name:       "resume from saved token",
+			args:       args{opt: &gotwtr.FollowOption{PaginationToken: "p2"}},
+			wantIDs:    []string{"3", "4", "5", "6"},
+			wantTokens: []string{"p2", "p3"},
+			wantNext:   "",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var tokens []string
+			c := gotwtr.New("key", gotwtr.WithHTTPClient(followersPages(t, &tokens)))
+			p := gotwtr.NewFollowersPager(c, "user", tt.args.opt)
+			p.MaxItems = tt.args.maxItems
+			var ids []string
+			p.All(context.Background())(func(page *gotwtr.FollowersResponse, err error) bool {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				for _, u := range page.Users {
+					ids = append(ids, u.ID)
+				}
+				return true
+			})
+			if diff := cmp.Diff(tt.wantIDs, ids); diff != "" {
+				t.Errorf("ids mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.wantTokens, tokens); diff != "" {
+				t.Errorf("pagination tokens mismatch (-want +got):\n%s", diff)
+			}
+			if p.Token() != tt.wantNext {
+				t.Errorf("Pager.Token() = %q, want %q", p.Token(), tt.wantNext)
+			}
+			if _, err := p.Next(context.Background()); tt.wantNext == "" && !errors.Is(err, gotwtr.ErrNoMorePages) {
+				t.Errorf("Pager.Next() error = %v, want %v", err, gotwtr.ErrNoMorePages)
+			}
+		})
+	}
+}
+
+func Test_Pager_canceled(t *testing.T) {
+	t.Parallel()
+	var tokens []string
+	c := gotwtr.New("key", gotwtr.WithHTTPClient(followersPages(t, &tokens)))
+	ctx, cancel := context.WithCancel(context.Background())
+	p := gotwtr.NewFollowersPager(c, "user")
+	var pages int
+	var gotErr error
+	p.All(ctx)(func(page *gotwtr.FollowersResponse, err error) bool {
+		if err != nil {
+			gotErr = err
+			return true
+		}
+		pages++
+		cancel()
+		return true
+	})
+	if pages != 1 {
+		t.Errorf("pages = %d, want 1", pages)
+	}
+	if !errors.Is(gotErr, context.Canceled) {
+		t.Errorf("error = %v, want %v", gotErr, context.Canceled)
+	}
+	if len(tokens) != 1 {
+		t.Errorf("requests sent = %d, want 1", len(tokens))
+	}
+}
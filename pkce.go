@@ -0,0 +1,259 @@
+package gotwtr
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	defaultOAuth2AuthURL  = "https://x.com/i/oauth2/authorize"
+	defaultOAuth2TokenURL = defaultBaseURL + oauth2TokenURL
+)
+
+var (
+	// ErrOAuth2StateMismatch is returned when the state of an authorization callback does not match the request.
+	ErrOAuth2StateMismatch = errors.New("gotwtr: oauth2 state mismatch")
+	// ErrOAuth2MissingCode is returned when an authorization callback carries no code.
+	ErrOAuth2MissingCode = errors.New("gotwtr: oauth2 callback has no code")
+)
+
+// OAuth2Config is the configuration of an OAuth 2.0 Authorization Code with PKCE flow,
+// which issues user-context tokens for endpoints such as PostTweet, BookmarkTweet or Me.
+type OAuth2Config struct {
+	// ClientID is the OAuth 2.0 client ID of the app.
+	ClientID string
+	// ClientSecret is the client secret of a confidential client. Leave it empty for a public client.
+	ClientSecret string
+	// RedirectURL is the callback URL registered for the app.
+	RedirectURL string
+	// Scopes are the scopes requested, e.g. "tweet.read", "tweet.write", "users.read" and "offline.access".
+	// "offline.access" is required to be issued a refresh token.
+	Scopes []string
+	// AuthURL is the authorization endpoint. The default is https://x.com/i/oauth2/authorize.
+	AuthURL string
+	// TokenURL is the token endpoint. The default is https://api.x.com/2/oauth2/token.
+	TokenURL string
+	// HTTPClient is used for the token endpoint. The default is http.DefaultClient.
+	HTTPClient *http.Client
+}
+
+// OAuth2Token is a token issued by the token endpoint.
+type OAuth2Token struct {
+	AccessToken  string    `json:"access_token"`
+	RefreshToken string    `json:"refresh_token,omitempty"`
+	TokenType    string    `json:"token_type,omitempty"`
+	Scope        string    `json:"scope,omitempty"`
+	Expiry       time.Time `json:"expiry,omitempty"`
+}
+
+// Valid reports whether the access token is set and not expired.
+func (t *OAuth2Token) Valid() bool {
+	return t != nil && t.AccessToken != "" && (t.Expiry.IsZero() || time.Now().Before(t.Expiry))
+}
+
+// Scopes returns the scopes granted to the token.
+func (t *OAuth2Token) Scopes() []string {
+	return strings.Fields(t.Scope)
+}
+
+// AuthorizationRequest is a pending authorization of a user.
+// Redirect the user to URL, and pass the query of the callback to Exchange.
+type AuthorizationRequest struct {
+	URL          string
+	State        string
+	CodeVerifier string
+	config       *OAuth2Config
+}
+
+// NewAuthorizationRequest starts an authorization with a random state and PKCE code verifier.
+func (c *OAuth2Config) NewAuthorizationRequest() (*AuthorizationRequest, error) {
+	state, err := randomString(32)
+	if err != nil {
+		return nil, fmt.Errorf("new authorization request: %w", err)
+	}
+	verifier, err := GenerateCodeVerifier()
+	if err != nil {
+		return nil, fmt.Errorf("new authorization request: %w", err)
+	}
+	return &AuthorizationRequest{
+		URL:          c.AuthCodeURL(state, CodeChallenge(verifier)),
+		State:        state,
+		CodeVerifier: verifier,
+		config:       c,
+	}, nil
+}
+
+// Exchange verifies the query of the callback and exchanges its code for a token.
+func (r *AuthorizationRequest) Exchange(ctx context.Context, callback url.Values) (*OAuth2Token, error) {
+	code, err := VerifyCallback(callback, r.State)
+	if err != nil {
+		return nil, err
+	}
+	return r.config.Exchange(ctx, code, r.CodeVerifier)
+}
+
+// GenerateCodeVerifier returns a random PKCE code verifier.
+func GenerateCodeVerifier() (string, error) {
+	return randomString(32)
+}
+
+// CodeChallenge returns the S256 PKCE code challenge of the verifier.
+func CodeChallenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+func randomString(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// AuthCodeURL returns the URL of the consent page asking the user for the scopes of the config.
+func (c *OAuth2Config) AuthCodeURL(state, codeChallenge string) string {
+	authURL := c.AuthURL
+	if authURL == "" {
+		authURL = defaultOAuth2AuthURL
+	}
+	q := url.Values{}
+	q.Set("response_type", "code")
+	q.Set("client_id", c.ClientID)
+	q.Set("redirect_uri", c.RedirectURL)
+	q.Set("scope", strings.Join(c.Scopes, " "))
+	q.Set("state", state)
+	q.Set("code_challenge", codeChallenge)
+	q.Set("code_challenge_method", "S256")
+	sep := "?"
+	if strings.Contains(authURL, "?") {
+		sep = "&"
+	}
+	return authURL + sep + q.Encode()
+}
+
+// OAuth2CallbackError is the error the authorization server redirected back with, e.g. access_denied.
+type OAuth2CallbackError struct {
+	Code        string
+	Description string
+}
+
+func (e *OAuth2CallbackError) Error() string {
+	if e.Description != "" {
+		return fmt.Sprintf("gotwtr: oauth2 authorization failed: %s: %s", e.Code, e.Description)
+	}
+	return "gotwtr: oauth2 authorization failed: " + e.Code
+}
+
+// VerifyCallback checks the query of the authorization callback against state and returns its code.
+// The state is checked first, so that a forged callback is never reported as an authorization error.
+func VerifyCallback(callback url.Values, state string) (string, error) {
+	if state == "" || subtle.ConstantTimeCompare([]byte(callback.Get("state")), []byte(state)) != 1 {
+		return "", ErrOAuth2StateMismatch
+	}
+	if code := callback.Get("error"); code != "" {
+		return "", &OAuth2CallbackError{
+			Code:        code,
+			Description: callback.Get("error_description"),
+		}
+	}
+	code := callback.Get("code")
+	if code == "" {
+		return "", ErrOAuth2MissingCode
+	}
+	return code, nil
+}
+
+// Exchange exchanges the authorization code for a token using the PKCE code verifier.
+func (c *OAuth2Config) Exchange(ctx context.Context, code, codeVerifier string) (*OAuth2Token, error) {
+	if code == "" {
+		return nil, errors.New("exchange oauth2 token: code parameter is required")
+	}
+	if codeVerifier == "" {
+		return nil, errors.New("exchange oauth2 token: code verifier parameter is required")
+	}
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", c.RedirectURL)
+	form.Set("code_verifier", codeVerifier)
+	return c.token(ctx, form, "exchange oauth2 token")
+}
+
+// token requests a token from the token endpoint.
+func (c *OAuth2Config) token(ctx context.Context, form url.Values, apiName string) (*OAuth2Token, error) {
+	tokenURL := c.TokenURL
+	if tokenURL == "" {
+		tokenURL = defaultOAuth2TokenURL
+	}
+	if c.ClientSecret == "" {
+		form.Set("client_id", c.ClientID)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("%s new request with ctx: %w", apiName, err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if c.ClientSecret != "" {
+		req.SetBasicAuth(url.QueryEscape(c.ClientID), url.QueryEscape(c.ClientSecret))
+	}
+
+	hc := c.HTTPClient
+	if hc == nil {
+		hc = http.DefaultClient
+	}
+	resp, err := hc.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", apiName, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if !successful(resp.StatusCode) {
+		return nil, oauth2Error(apiName, req, resp)
+	}
+	var t struct {
+		OAuth2Token
+		ExpiresIn int64 `json:"expires_in"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&t); err != nil {
+		return nil, fmt.Errorf("%s decode: %w", apiName, err)
+	}
+	if t.AccessToken == "" {
+		return nil, fmt.Errorf("%s: the response has no access token", apiName)
+	}
+	tok := t.OAuth2Token
+	if t.ExpiresIn > 0 {
+		tok.Expiry = time.Now().Add(time.Duration(t.ExpiresIn) * time.Second)
+	}
+	return &tok, nil
+}
+
+// oauth2Error returns the *HTTPError of an unsuccessful token response.
+// The RFC 6749 error and error_description are reported as the title and detail.
+func oauth2Error(apiName string, req *http.Request, resp *http.Response) error {
+	if _, err := bufferErrorBody(apiName, req, resp); err != nil {
+		return err
+	}
+	err := responseError(apiName, req, resp)
+	var herr *HTTPError
+	if errors.As(err, &herr) && herr.Title == "" && herr.Detail == "" {
+		var e struct {
+			Error       string `json:"error"`
+			Description string `json:"error_description"`
+		}
+		_ = json.Unmarshal([]byte(herr.Body), &e)
+		herr.Title = e.Error
+		herr.Detail = e.Description
+	}
+	return err
+}
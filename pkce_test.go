@@ -0,0 +1,179 @@
+package gotwtr_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sivchari/gotwtr"
+)
+
+func Test_CodeChallenge(t *testing.T) {
+	t.Parallel()
+	// RFC 7636 Appendix B.
+	got := gotwtr.CodeChallenge("dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk")
+	want := "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM"
+	if got != want {
+		t.Errorf("CodeChallenge() = %v, want %v", got, want)
+	}
+}
+
+func Test_OAuth2Config_NewAuthorizationRequest(t *testing.T) {
+	t.Parallel()
+	cfg := &gotwtr.OAuth2Config{
+		ClientID:    "client-id",
+		RedirectURL: "http://127.0.0.1:8080/callback",
+		Scopes:      []string{"tweet.read", "users.read", "offline.access"},
+	}
+	ar, err := cfg.NewAuthorizationRequest()
+	if err != nil {
+		t.Fatalf("NewAuthorizationRequest() error = %v", err)
+	}
+	u, err := url.Parse(ar.URL)
+	if err != nil {
+		t.Fatalf("url.Parse() error = %v", err)
+	}
+	if got := u.Scheme + "://" + u.Host + u.Path; got != "https://x.com/i/oauth2/authorize" {
+		t.Errorf("authorize endpoint = %v", got)
+	}
+	q := u.Query()
+	want := map[string]string{
+		"response_type":         "code",
+		"client_id":             "client-id",
+		"redirect_uri":          "http://127.0.0.1:8080/callback",
+		"scope":                 "tweet.read users.read offline.access",
+		"state":                 ar.State,
+		"code_challenge":        gotwtr.CodeChallenge(ar.CodeVerifier),
+		"code_challenge_method": "S256",
+	}
+	got := make(map[string]string, len(q))
+	for k := range q {
+		got[k] = q.Get(k)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("authorize query mismatch (-want +got):\n%s", diff)
+	}
+	if len(ar.CodeVerifier) < 43 {
+		t.Errorf("code verifier %q is shorter than 43 characters", ar.CodeVerifier)
+	}
+}
+
+func Test_VerifyCallback(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		callback url.Values
+		want     string
+		wantErr  error
+	}{
+		{
+			name:     "ok",
+			callback: url.Values{"state": {"state"}, "code": {"code"}},
+			want:     "code",
+		},
+		{
+			name:     "state mismatch",
+			callback: url.Values{"state": {"other"}, "code": {"code"}},
+			wantErr:  gotwtr.ErrOAuth2StateMismatch,
+		},
+		{
+			name:     "error with wrong state",
+			callback: url.Values{"state": {"other"}, "error": {"access_denied"}},
+			wantErr:  gotwtr.ErrOAuth2StateMismatch,
+		},
+		{
+			name:     "missing code",
+			callback: url.Values{"state": {"state"}},
+			wantErr:  gotwtr.ErrOAuth2MissingCode,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := gotwtr.VerifyCallback(tt.callback, "state")
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("VerifyCallback() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("VerifyCallback() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("access denied", func(t *testing.T) {
+		t.Parallel()
+		_, err := gotwtr.VerifyCallback(url.Values{"error": {"access_denied"}, "state": {"state"}}, "state")
+		var cerr *gotwtr.OAuth2CallbackError
+		if !errors.As(err, &cerr) || cerr.Code != "access_denied" {
+			t.Errorf("VerifyCallback() error = %v, want access_denied", err)
+		}
+	})
+}
+
+func Test_AuthorizationRequest_Exchange(t *testing.T) {
+	t.Parallel()
+	var verifier string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("ParseForm() error = %v", err)
+		}
+		user, pass, ok := r.BasicAuth()
+		switch {
+		case !ok || user != "client-id" || pass != "client-secret":
+			t.Errorf("basic auth = %v:%v", user, pass)
+		case r.PostForm.Get("grant_type") != "authorization_code":
+			t.Errorf("grant_type = %v", r.PostForm.Get("grant_type"))
+		case r.PostForm.Get("code_verifier") != verifier:
+			t.Errorf("code_verifier = %v, want %v", r.PostForm.Get("code_verifier"), verifier)
+		}
+		if r.PostForm.Get("code") != "good" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = io.WriteString(w, `{"error":"invalid_request","error_description":"Value passed for the authorization code was invalid."}`)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `{"token_type":"bearer","expires_in":7200,"access_token":"access","scope":"tweet.read users.read offline.access","refresh_token":"refresh"}`)
+	}))
+	t.Cleanup(srv.Close)
+
+	cfg := &gotwtr.OAuth2Config{
+		ClientID:     "client-id",
+		ClientSecret: "client-secret",
+		RedirectURL:  "http://127.0.0.1:8080/callback",
+		TokenURL:     srv.URL,
+		HTTPClient:   srv.Client(),
+	}
+	ar, err := cfg.NewAuthorizationRequest()
+	if err != nil {
+		t.Fatalf("NewAuthorizationRequest() error = %v", err)
+	}
+	verifier = ar.CodeVerifier
+
+	tok, err := ar.Exchange(context.Background(), url.Values{"state": {ar.State}, "code": {"good"}})
+	if err != nil {
+		t.Fatalf("Exchange() error = %v", err)
+	}
+	if tok.AccessToken != "access" || tok.RefreshToken != "refresh" || !tok.Valid() {
+		t.Errorf("Exchange() = %+v", tok)
+	}
+	if diff := cmp.Diff([]string{"tweet.read", "users.read", "offline.access"}, tok.Scopes()); diff != "" {
+		t.Errorf("Scopes() mismatch (-want +got):\n%s", diff)
+	}
+
+	_, err = ar.Exchange(context.Background(), url.Values{"state": {ar.State}, "code": {"bad"}})
+	var herr *gotwtr.HTTPError
+	if !errors.As(err, &herr) || !errors.Is(err, gotwtr.ErrBadRequest) {
+		t.Fatalf("Exchange() error = %v, want *HTTPError", err)
+	}
+	if herr.Title != "invalid_request" || !strings.Contains(herr.Error(), "authorization code was invalid") {
+		t.Errorf("Exchange() error = %v", herr)
+	}
+}
@@ -0,0 +1,267 @@
+package gotwtr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const defaultQuarantine = 15 * time.Minute
+
+// ErrNoAvailableClient is returned by a Pool whose clients are all quarantined,
+// or sent to the error channel of a stream it can not connect.
+var ErrNoAvailableClient = errors.New("gotwtr: no available client in the pool")
+
+// PoolOption is an option of NewPool.
+type PoolOption func(*Pool)
+
+// WithQuarantine sets how long a client whose credentials were rejected is left out of the pool. The default is 15 minutes.
+func WithQuarantine(d time.Duration) PoolOption {
+	return func(p *Pool) {
+		p.quarantine = d
+	}
+}
+
+// Pool spreads API calls across several clients, e.g. one per app token.
+// Each call is routed to the client with the most remaining rate limit quota for its endpoint.
+// A client rejected with 401 Unauthorized, or 403 Forbidden reporting a client-forbidden or unsupported-authentication problem,
+// is quarantined. A read-only call rejected that way or with 429 is retried on the next best client.
+// Any other call is never replayed, as it would be applied again on behalf of another user,
+// and any other 403 is returned as is.
+// Pool satisfies Twtr, so it can be used wherever a *Client is.
+type Pool struct {
+	clients    []*Client
+	quarantine time.Duration
+	next       atomic.Uint32
+
+	mu          sync.Mutex
+	quarantined map[*Client]time.Time
+}
+
+var _ Twtr = (*Pool)(nil)
+
+// NewPool returns a Pool of the clients, which must not be empty nor hold a nil client.
+func NewPool(clients []*Client, opts ...PoolOption) (*Pool, error) {
+	if len(clients) == 0 {
+		return nil, errors.New("new pool: clients parameter is required")
+	}
+	for i, c := range clients {
+		if c == nil {
+			return nil, fmt.Errorf("new pool: clients[%d] is nil", i)
+		}
+	}
+	p := &Pool{
+		clients:     clients,
+		quarantine:  defaultQuarantine,
+		quarantined: make(map[*Client]time.Time),
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p, nil
+}
+
+// Available returns the number of clients not quarantined.
+func (p *Pool) Available() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	n := 0
+	for _, c := range p.clients {
+		if !p.isQuarantined(c) {
+			n++
+		}
+	}
+	return n
+}
+
+func (p *Pool) isQuarantined(c *Client) bool {
+	until, ok := p.quarantined[c]
+	if !ok {
+		return false
+	}
+	if time.Now().After(until) {
+		delete(p.quarantined, c)
+		return false
+	}
+	return true
+}
+
+// pick returns the available client with the most remaining quota for the operation, skipping the tried ones.
+// A client which has not called the operation yet counts as having full quota.
+// Ties are broken round robin.
+func (p *Pool) pick(operation string, tried map[*Client]bool) (*Client, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	n := len(p.clients)
+	if n == 0 {
+		return nil, ErrNoAvailableClient
+	}
+	start := int(p.next.Add(1)) % n
+	var (
+		best      *Client
+		bestQuota = -1
+	)
+	for i := 0; i < n; i++ {
+		c := p.clients[(start+i)%n]
+		if tried[c] || p.isQuarantined(c) {
+			continue
+		}
+		quota := math.MaxInt
+		if rl, ok := c.RateLimit(operation); ok && time.Now().Before(rl.Reset) {
+			quota = rl.Remaining
+		}
+		if quota > bestQuota {
+			best, bestQuota = c, quota
+		}
+	}
+	if best == nil {
+		return nil, ErrNoAvailableClient
+	}
+	return best, nil
+}
+
+func (p *Pool) quarantineClient(c *Client) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.quarantined[c] = time.Now().Add(p.quarantine)
+}
+
+// rejected reports whether err rejected the call without applying it, and whether the client must be quarantined.
+// Only a rejection of the credentials of the client quarantines it. Any other 403, e.g. for duplicate content
+// or a protected resource, is about the call rather than the client.
+func rejected(err error) (retry, quarantine bool) {
+	switch {
+	case errors.Is(err, ErrUnauthorized),
+		HasProblem(err, ProblemClientForbidden),
+		HasProblem(err, ProblemUnsupportedAuth):
+		return true, true
+	case errors.Is(err, ErrTooManyRequests):
+		return true, false
+	default:
+		return false, false
+	}
+}
+
+// poolDo calls the method on the best client of the pool.
+// A read-only method is retried on the next best client if the call was rejected.
+func poolDo[T any](p *Pool, method string, call func(c *Client) (T, error)) (T, error) {
+	operation := method
+	if op, ok := operationsByMethod[method]; ok {
+		operation = op
+	}
+	tried := make(map[*Client]bool)
+	var (
+		res     T
+		lastErr error
+	)
+	for {
+		c, err := p.pick(operation, tried)
+		if err != nil {
+			if lastErr != nil {
+				return res, lastErr
+			}
+			return res, err
+		}
+		tried[c] = true
+		res, lastErr = call(c)
+		retry, quarantine := rejected(lastErr)
+		if quarantine {
+			p.quarantineClient(c)
+		}
+		if !retry || !readMethods[method] {
+			return res, lastErr
+		}
+	}
+}
+
+// readMethods are the methods of Twtr sending a GET request, which are safe to replay on another client.
+var readMethods = map[string]bool{
+	"LookupUserBookmarks":                true,
+	"RetrieveStreamRules":                true,
+	"UsersLikingTweet":                   true,
+	"TweetsUserLiked":                    true,
+	"QuoteTweets":                        true,
+	"RetweetsLookup":                     true,
+	"SearchAllTweets":                    true,
+	"SearchRecentTweets":                 true,
+	"UserMentionTimeline":                true,
+	"UserReverseChronologicalTimeline":   true,
+	"UserTweetTimeline":                  true,
+	"CountAllTweets":                     true,
+	"CountRecentTweets":                  true,
+	"RetrieveMultipleTweets":             true,
+	"RetrieveSingleTweet":                true,
+	"Blocking":                           true,
+	"Followers":                          true,
+	"Following":                          true,
+	"Muting":                             true,
+	"RetrieveMultipleUsersWithIDs":       true,
+	"RetrieveSingleUserWithID":           true,
+	"RetrieveMultipleUsersWithUserNames": true,
+	"RetrieveSingleUserWithUserName":     true,
+	"Me":                                 true,
+	"SearchUsers":                        true,
+	"SearchSpaces":                       true,
+	"LookUpSpaces":                       true,
+	"LookUpSpace":                        true,
+	"UsersPurchasedSpaceTicket":          true,
+	"SpacesTweets":                       true,
+	"DiscoverSpaces":                     true,
+	"LookUpListTweets":                   true,
+	"ListFollowers":                      true,
+	"AllListsUserFollows":                true,
+	"LookUpList":                         true,
+	"LookUpAllListsOwned":                true,
+	"ListMembers":                        true,
+	"ListsSpecifiedUser":                 true,
+	"PinnedLists":                        true,
+	"ComplianceJobs":                     true,
+	"ComplianceJob":                      true,
+	"LookUpAllOneToOneDM":                true,
+	"LookUpDM":                           true,
+	"LookUpAllDM":                        true,
+	"SearchPostsEligibleForNotes":        true,
+	"SearchNotesWritten":                 true,
+	"TrendsByWOEID":                      true,
+	"CheckUploadStatus":                  true,
+}
+
+// GenerateAppOnlyBearerToken generates the app-only bearer token of every client of the pool.
+func (p *Pool) GenerateAppOnlyBearerToken(ctx context.Context) (bool, error) {
+	for _, c := range p.clients {
+		if ok, err := c.GenerateAppOnlyBearerToken(ctx); !ok || err != nil {
+			return ok, err
+		}
+	}
+	return true, nil
+}
+
+// InvalidateToken invalidates the bearer token of every client of the pool, and returns the response of the last one.
+func (p *Pool) InvalidateToken(ctx context.Context) (*InvalidateTokenResponse, error) {
+	var res *InvalidateTokenResponse
+	for _, c := range p.clients {
+		var err error
+		if res, err = c.InvalidateToken(ctx); err != nil {
+			return res, err
+		}
+	}
+	return res, nil
+}
+
+// poolPick returns the best client of the pool for a streaming method.
+func (p *Pool) poolPick(method string) (*Client, error) {
+	return p.pick(operationsByMethod[method], nil)
+}
+
+// operationsByMethod indexes scopeCatalog by method name.
+var operationsByMethod = func() map[string]string {
+	m := make(map[string]string, len(scopeCatalog))
+	for _, e := range scopeCatalog {
+		m[e.Method] = e.Operation
+	}
+	return m
+}()
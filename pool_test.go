@@ -0,0 +1,276 @@
+package gotwtr_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sivchari/gotwtr"
+)
+
+// poolClient returns a client answering every request with the status and remaining rate limit quota,
+// counting its calls.
+func poolClient(status int, body string, remaining int, calls *int32) *gotwtr.Client {
+	client := mockHTTPClient(func(req *http.Request) *http.Response {
+		atomic.AddInt32(calls, 1)
+		return &http.Response{
+			StatusCode: status,
+			Header: http.Header{
+				"X-Rate-Limit-Limit":     []string{"450"},
+				"X-Rate-Limit-Remaining": []string{strconv.Itoa(remaining)},
+				"X-Rate-Limit-Reset":     []string{strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10)},
+			},
+			Body: io.NopCloser(strings.NewReader(body)),
+		}
+	})
+	return gotwtr.New("key", gotwtr.WithHTTPClient(client))
+}
+
+const poolOK = `{"data":[{"id":"1","text":"hello"}],"meta":{"result_count":1}}`
+
+func Test_NewPool(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		clients []*gotwtr.Client
+		wantErr bool
+	}{
+		{
+			name:    "clients",
+			clients: []*gotwtr.Client{gotwtr.New("key")},
+			wantErr: false,
+		},
+		{
+			name:    "no clients",
+			clients: nil,
+			wantErr: true,
+		},
+		{
+			name:    "nil client",
+			clients: []*gotwtr.Client{gotwtr.New("key"), nil},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			p, err := gotwtr.NewPool(tt.clients)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewPool() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if (p == nil) != tt.wantErr {
+				t.Errorf("NewPool() = %v, want a pool %v", p, !tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_Pool_routesByRemainingQuota(t *testing.T) {
+	t.Parallel()
+	var low, high int32
+	p, err := gotwtr.NewPool([]*gotwtr.Client{
+		poolClient(http.StatusOK, poolOK, 1, &low),
+		poolClient(http.StatusOK, poolOK, 400, &high),
+	})
+	if err != nil {
+		t.Fatalf("NewPool() error = %v", err)
+	}
+	for i := 0; i < 10; i++ {
+		if _, err := p.SearchRecentTweets(context.Background(), "gotwtr"); err != nil {
+			t.Fatalf("SearchRecentTweets() error = %v", err)
+		}
+	}
+	// Each client is tried once before its quota is known.
+	if low != 1 || high != 9 {
+		t.Errorf("calls = %v (low quota), %v (high quota), want 1, 9", low, high)
+	}
+}
+
+func Test_Pool_quarantine(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name          string
+		status        int
+		body          string
+		wantBadCalls  int32
+		wantAvailable int
+	}{
+		{
+			name:          "401 unauthorized",
+			status:        http.StatusUnauthorized,
+			body:          `{"title":"Unauthorized","type":"about:blank","status":401,"detail":"Unauthorized"}`,
+			wantBadCalls:  1,
+			wantAvailable: 1,
+		},
+		{
+			name:          "403 client forbidden",
+			status:        http.StatusForbidden,
+			body:          `{"title":"Client Forbidden","type":"https://api.twitter.com/2/problems/client-forbidden","status":403}`,
+			wantBadCalls:  1,
+			wantAvailable: 1,
+		},
+		{
+			name:          "429 too many requests",
+			status:        http.StatusTooManyRequests,
+			body:          `{"title":"Too Many Requests","type":"about:blank","status":429}`,
+			wantBadCalls:  1,
+			wantAvailable: 2,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var bad, good int32
+			// The bad client is picked at least once, as it has full quota until it is called.
+			p, err := gotwtr.NewPool([]*gotwtr.Client{
+				poolClient(tt.status, tt.body, 0, &bad),
+				poolClient(http.StatusOK, poolOK, 0, &good),
+			})
+			if err != nil {
+				t.Fatalf("NewPool() error = %v", err)
+			}
+			for i := 0; i < 4; i++ {
+				if _, err := p.SearchRecentTweets(context.Background(), "gotwtr"); err != nil {
+					t.Fatalf("SearchRecentTweets() error = %v", err)
+				}
+			}
+			if tt.status != http.StatusTooManyRequests && bad != tt.wantBadCalls {
+				t.Errorf("calls of the rejected client = %v, want %v", bad, tt.wantBadCalls)
+			}
+			if got := p.Available(); got != tt.wantAvailable {
+				t.Errorf("Available() = %v, want %v", got, tt.wantAvailable)
+			}
+		})
+	}
+}
+
+func Test_Pool_allQuarantined(t *testing.T) {
+	t.Parallel()
+	var calls int32
+	body := `{"title":"Unauthorized","type":"about:blank","status":401,"detail":"Unauthorized"}`
+	p, err := gotwtr.NewPool([]*gotwtr.Client{
+		poolClient(http.StatusUnauthorized, body, 0, &calls),
+		poolClient(http.StatusUnauthorized, body, 0, &calls),
+	}, gotwtr.WithQuarantine(time.Hour))
+	if err != nil {
+		t.Fatalf("NewPool() error = %v", err)
+	}
+	if _, err := p.Me(context.Background()); !errors.Is(err, gotwtr.ErrUnauthorized) {
+		t.Errorf("Me() error = %v, want ErrUnauthorized", err)
+	}
+	if _, err := p.Me(context.Background()); !errors.Is(err, gotwtr.ErrNoAvailableClient) {
+		t.Errorf("Me() error = %v, want ErrNoAvailableClient", err)
+	}
+	// A stream is not connected through a quarantined client.
+	errCh := make(chan error, 1)
+	if s := p.ConnectToStream(context.Background(), make(chan gotwtr.ConnectToStreamResponse), errCh); s != nil {
+		s.Stop()
+		t.Error("ConnectToStream() connected with every client quarantined")
+	}
+	if err := <-errCh; !errors.Is(err, gotwtr.ErrNoAvailableClient) {
+		t.Errorf("ConnectToStream() error = %v, want ErrNoAvailableClient", err)
+	}
+	if calls != 2 {
+		t.Errorf("calls = %v, want 2", calls)
+	}
+}
+
+func Test_Pool_resourceForbidden(t *testing.T) {
+	t.Parallel()
+	var calls int32
+	body := `{"errors":[{"type":"https://api.twitter.com/2/problems/not-authorized-for-resource","detail":"Sorry, you are not authorized to see the Tweet with id: [1]."}],"title":"Forbidden","status":403}`
+	p, err := gotwtr.NewPool([]*gotwtr.Client{
+		poolClient(http.StatusForbidden, body, 0, &calls),
+		poolClient(http.StatusForbidden, body, 0, &calls),
+	})
+	if err != nil {
+		t.Fatalf("NewPool() error = %v", err)
+	}
+	if _, err := p.RetrieveSingleTweet(context.Background(), "1"); !errors.Is(err, gotwtr.ErrForbidden) {
+		t.Errorf("RetrieveSingleTweet() error = %v, want ErrForbidden", err)
+	}
+	if calls != 1 || p.Available() != 2 {
+		t.Errorf("calls = %v, Available() = %v, want 1, 2", calls, p.Available())
+	}
+}
+
+func Test_Pool_notRerouted(t *testing.T) {
+	t.Parallel()
+	const (
+		duplicate    = `{"detail":"You are not allowed to create a Tweet with duplicate content.","type":"about:blank","title":"Forbidden","status":403}`
+		unauthorized = `{"title":"Unauthorized","type":"about:blank","status":401,"detail":"Unauthorized"}`
+	)
+	tests := []struct {
+		name          string
+		status        int
+		body          string
+		call          func(p *gotwtr.Pool) error
+		wantErr       error
+		wantAvailable int
+	}{
+		{
+			name:   "403 duplicate content",
+			status: http.StatusForbidden,
+			body:   duplicate,
+			call: func(p *gotwtr.Pool) error {
+				_, err := p.PostTweet(context.Background(), &gotwtr.PostTweetOption{Text: "hello"})
+				return err
+			},
+			wantErr:       gotwtr.ErrForbidden,
+			wantAvailable: 2,
+		},
+		{
+			name:   "401 on a write",
+			status: http.StatusUnauthorized,
+			body:   unauthorized,
+			call: func(p *gotwtr.Pool) error {
+				_, err := p.PostFollowing(context.Background(), "1", "2")
+				return err
+			},
+			wantErr:       gotwtr.ErrUnauthorized,
+			wantAvailable: 1,
+		},
+		{
+			name:   "403 on a read",
+			status: http.StatusForbidden,
+			body:   `{"detail":"Forbidden","type":"about:blank","title":"Forbidden","status":403}`,
+			call: func(p *gotwtr.Pool) error {
+				_, err := p.SearchRecentTweets(context.Background(), "gotwtr")
+				return err
+			},
+			wantErr:       gotwtr.ErrForbidden,
+			wantAvailable: 2,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var calls int32
+			p, err := gotwtr.NewPool([]*gotwtr.Client{
+				poolClient(tt.status, tt.body, 0, &calls),
+				poolClient(tt.status, tt.body, 0, &calls),
+			})
+			if err != nil {
+				t.Fatalf("NewPool() error = %v", err)
+			}
+			if err := tt.call(p); !errors.Is(err, tt.wantErr) {
+				t.Errorf("call error = %v, want %v", err, tt.wantErr)
+			}
+			if calls != 1 {
+				t.Errorf("calls = %v, want 1", calls)
+			}
+			if got := p.Available(); got != tt.wantAvailable {
+				t.Errorf("Available() = %v, want %v", got, tt.wantAvailable)
+			}
+		})
+	}
+}
@@ -0,0 +1,557 @@
+package gotwtr
+
+import (
+	"context"
+	"io"
+)
+
+// The API methods of Pool call the same method of the best client through poolDo.
+// Streams are connected through the best client, and the analytics utilities are computed by the first client.
+
+func (p *Pool) RemoveBookmarkOfTweet(ctx context.Context, userID string, tweetID string) (*RemoveBookmarkOfTweetResponse, error) {
+	return poolDo(p, "RemoveBookmarkOfTweet", func(c *Client) (*RemoveBookmarkOfTweetResponse, error) {
+		return c.RemoveBookmarkOfTweet(ctx, userID, tweetID)
+	})
+}
+
+func (p *Pool) LookupUserBookmarks(ctx context.Context, userID string, opt ...*LookupUserBookmarksOption) (*LookupUserBookmarksResponse, error) {
+	return poolDo(p, "LookupUserBookmarks", func(c *Client) (*LookupUserBookmarksResponse, error) {
+		return c.LookupUserBookmarks(ctx, userID, opt...)
+	})
+}
+
+func (p *Pool) BookmarkTweet(ctx context.Context, userID string, body *BookmarkTweetBody) (*BookmarkTweetResponse, error) {
+	return poolDo(p, "BookmarkTweet", func(c *Client) (*BookmarkTweetResponse, error) {
+		return c.BookmarkTweet(ctx, userID, body)
+	})
+}
+
+func (p *Pool) ConnectToStream(ctx context.Context, ch chan<- ConnectToStreamResponse, errCh chan<- error, opt ...*ConnectToStreamOption) *ConnectToStream {
+	c, err := p.poolPick("ConnectToStream")
+	if err != nil {
+		errCh <- err
+		return nil
+	}
+	return c.ConnectToStream(ctx, ch, errCh, opt...)
+}
+
+func (p *Pool) RetrieveStreamRules(ctx context.Context, opt ...*RetrieveStreamRulesOption) (*RetrieveStreamRulesResponse, error) {
+	return poolDo(p, "RetrieveStreamRules", func(c *Client) (*RetrieveStreamRulesResponse, error) {
+		return c.RetrieveStreamRules(ctx, opt...)
+	})
+}
+
+func (p *Pool) AddOrDeleteRules(ctx context.Context, body *AddOrDeleteJSONBody, opt ...*AddOrDeleteRulesOption) (*AddOrDeleteRulesResponse, error) {
+	return poolDo(p, "AddOrDeleteRules", func(c *Client) (*AddOrDeleteRulesResponse, error) {
+		return c.AddOrDeleteRules(ctx, body, opt...)
+	})
+}
+
+func (p *Pool) HideReplies(ctx context.Context, tweetID string, hidden bool) (*HideRepliesResponse, error) {
+	return poolDo(p, "HideReplies", func(c *Client) (*HideRepliesResponse, error) {
+		return c.HideReplies(ctx, tweetID, hidden)
+	})
+}
+
+func (p *Pool) UndoUsersLikingTweet(ctx context.Context, userID string, tweetID string) (*UndoUsersLikingTweetResponse, error) {
+	return poolDo(p, "UndoUsersLikingTweet", func(c *Client) (*UndoUsersLikingTweetResponse, error) {
+		return c.UndoUsersLikingTweet(ctx, userID, tweetID)
+	})
+}
+
+func (p *Pool) UsersLikingTweet(ctx context.Context, tweetID string, opt ...*UsersLikingTweetOption) (*UsersLikingTweetResponse, error) {
+	return poolDo(p, "UsersLikingTweet", func(c *Client) (*UsersLikingTweetResponse, error) {
+		return c.UsersLikingTweet(ctx, tweetID, opt...)
+	})
+}
+
+func (p *Pool) TweetsUserLiked(ctx context.Context, userID string, opt ...*TweetsUserLikedOption) (*TweetsUserLikedResponse, error) {
+	return poolDo(p, "TweetsUserLiked", func(c *Client) (*TweetsUserLikedResponse, error) {
+		return c.TweetsUserLiked(ctx, userID, opt...)
+	})
+}
+
+func (p *Pool) PostUsersLikingTweet(ctx context.Context, userID string, tweetID string) (*PostUsersLikingTweetResponse, error) {
+	return poolDo(p, "PostUsersLikingTweet", func(c *Client) (*PostUsersLikingTweetResponse, error) {
+		return c.PostUsersLikingTweet(ctx, userID, tweetID)
+	})
+}
+
+func (p *Pool) DeleteTweet(ctx context.Context, tweetID string) (*DeleteTweetResponse, error) {
+	return poolDo(p, "DeleteTweet", func(c *Client) (*DeleteTweetResponse, error) {
+		return c.DeleteTweet(ctx, tweetID)
+	})
+}
+
+func (p *Pool) PostTweet(ctx context.Context, body *PostTweetOption) (*PostTweetResponse, error) {
+	return poolDo(p, "PostTweet", func(c *Client) (*PostTweetResponse, error) {
+		return c.PostTweet(ctx, body)
+	})
+}
+
+func (p *Pool) QuoteTweets(ctx context.Context, tweetID string, opt ...*QuoteTweetsOption) (*QuoteTweetsResponse, error) {
+	return poolDo(p, "QuoteTweets", func(c *Client) (*QuoteTweetsResponse, error) {
+		return c.QuoteTweets(ctx, tweetID, opt...)
+	})
+}
+
+func (p *Pool) UndoRetweet(ctx context.Context, userID string, sourceTweetID string) (*UndoRetweetResponse, error) {
+	return poolDo(p, "UndoRetweet", func(c *Client) (*UndoRetweetResponse, error) {
+		return c.UndoRetweet(ctx, userID, sourceTweetID)
+	})
+}
+
+func (p *Pool) RetweetsLookup(ctx context.Context, tweetID string, opt ...*RetweetsLookupOption) (*RetweetsResponse, error) {
+	return poolDo(p, "RetweetsLookup", func(c *Client) (*RetweetsResponse, error) {
+		return c.RetweetsLookup(ctx, tweetID, opt...)
+	})
+}
+
+func (p *Pool) PostRetweet(ctx context.Context, userID string, tweetID string) (*PostRetweetResponse, error) {
+	return poolDo(p, "PostRetweet", func(c *Client) (*PostRetweetResponse, error) {
+		return c.PostRetweet(ctx, userID, tweetID)
+	})
+}
+
+func (p *Pool) SearchAllTweets(ctx context.Context, tweet string, opt ...*SearchTweetsOption) (*SearchTweetsResponse, error) {
+	return poolDo(p, "SearchAllTweets", func(c *Client) (*SearchTweetsResponse, error) {
+		return c.SearchAllTweets(ctx, tweet, opt...)
+	})
+}
+
+func (p *Pool) SearchRecentTweets(ctx context.Context, tweet string, opt ...*SearchTweetsOption) (*SearchTweetsResponse, error) {
+	return poolDo(p, "SearchRecentTweets", func(c *Client) (*SearchTweetsResponse, error) {
+		return c.SearchRecentTweets(ctx, tweet, opt...)
+	})
+}
+
+func (p *Pool) UserMentionTimeline(ctx context.Context, userID string, opt ...*UserMentionTimelineOption) (*UserMentionTimelineResponse, error) {
+	return poolDo(p, "UserMentionTimeline", func(c *Client) (*UserMentionTimelineResponse, error) {
+		return c.UserMentionTimeline(ctx, userID, opt...)
+	})
+}
+
+func (p *Pool) UserReverseChronologicalTimeline(ctx context.Context, userID string, opt ...*UserReverseChronologicalTimelineOption) (*UserReverseChronologicalTimelineResponse, error) {
+	return poolDo(p, "UserReverseChronologicalTimeline", func(c *Client) (*UserReverseChronologicalTimelineResponse, error) {
+		return c.UserReverseChronologicalTimeline(ctx, userID, opt...)
+	})
+}
+
+func (p *Pool) UserTweetTimeline(ctx context.Context, userID string, opt ...*UserTweetTimelineOption) (*UserTweetTimelineResponse, error) {
+	return poolDo(p, "UserTweetTimeline", func(c *Client) (*UserTweetTimelineResponse, error) {
+		return c.UserTweetTimeline(ctx, userID, opt...)
+	})
+}
+
+func (p *Pool) CountAllTweets(ctx context.Context, tweet string, opt ...*TweetCountsAllOption) (*TweetCountsResponse, error) {
+	return poolDo(p, "CountAllTweets", func(c *Client) (*TweetCountsResponse, error) {
+		return c.CountAllTweets(ctx, tweet, opt...)
+	})
+}
+
+func (p *Pool) CountRecentTweets(ctx context.Context, tweet string, opt ...*TweetCountsOption) (*TweetCountsResponse, error) {
+	return poolDo(p, "CountRecentTweets", func(c *Client) (*TweetCountsResponse, error) {
+		return c.CountRecentTweets(ctx, tweet, opt...)
+	})
+}
+
+func (p *Pool) RetrieveMultipleTweets(ctx context.Context, tweetIDs []string, opt ...*RetriveTweetOption) (*TweetsResponse, error) {
+	return poolDo(p, "RetrieveMultipleTweets", func(c *Client) (*TweetsResponse, error) {
+		return c.RetrieveMultipleTweets(ctx, tweetIDs, opt...)
+	})
+}
+
+func (p *Pool) RetrieveSingleTweet(ctx context.Context, tweetID string, opt ...*RetriveTweetOption) (*TweetResponse, error) {
+	return poolDo(p, "RetrieveSingleTweet", func(c *Client) (*TweetResponse, error) {
+		return c.RetrieveSingleTweet(ctx, tweetID, opt...)
+	})
+}
+
+func (p *Pool) VolumeStreams(ctx context.Context, ch chan<- VolumeStreamsResponse, errCh chan<- error, opt ...*VolumeStreamsOption) *VolumeStreams {
+	c, err := p.poolPick("VolumeStreams")
+	if err != nil {
+		errCh <- err
+		return nil
+	}
+	return c.VolumeStreams(ctx, ch, errCh, opt...)
+}
+
+func (p *Pool) VolumeStreams10(ctx context.Context, ch chan<- VolumeStreamsResponse, errCh chan<- error, opt ...*VolumeStreamsOption) *VolumeStreams {
+	c, err := p.poolPick("VolumeStreams10")
+	if err != nil {
+		errCh <- err
+		return nil
+	}
+	return c.VolumeStreams10(ctx, ch, errCh, opt...)
+}
+
+func (p *Pool) UndoBlocking(ctx context.Context, sourceUserID string, targetUserID string) (*UndoBlockingResponse, error) {
+	return poolDo(p, "UndoBlocking", func(c *Client) (*UndoBlockingResponse, error) {
+		return c.UndoBlocking(ctx, sourceUserID, targetUserID)
+	})
+}
+
+func (p *Pool) Blocking(ctx context.Context, userID string, opt ...*BlockOption) (*BlockingResponse, error) {
+	return poolDo(p, "Blocking", func(c *Client) (*BlockingResponse, error) {
+		return c.Blocking(ctx, userID, opt...)
+	})
+}
+
+func (p *Pool) PostBlocking(ctx context.Context, userID string, targetUserID string) (*PostBlockingResponse, error) {
+	return poolDo(p, "PostBlocking", func(c *Client) (*PostBlockingResponse, error) {
+		return c.PostBlocking(ctx, userID, targetUserID)
+	})
+}
+
+func (p *Pool) UndoFollowing(ctx context.Context, sourceUserID string, targetUserID string) (*UndoFollowingResponse, error) {
+	return poolDo(p, "UndoFollowing", func(c *Client) (*UndoFollowingResponse, error) {
+		return c.UndoFollowing(ctx, sourceUserID, targetUserID)
+	})
+}
+
+func (p *Pool) Followers(ctx context.Context, userID string, opt ...*FollowOption) (*FollowersResponse, error) {
+	return poolDo(p, "Followers", func(c *Client) (*FollowersResponse, error) {
+		return c.Followers(ctx, userID, opt...)
+	})
+}
+
+func (p *Pool) Following(ctx context.Context, userID string, opt ...*FollowOption) (*FollowingResponse, error) {
+	return poolDo(p, "Following", func(c *Client) (*FollowingResponse, error) {
+		return c.Following(ctx, userID, opt...)
+	})
+}
+
+func (p *Pool) PostFollowing(ctx context.Context, userID string, targetUserID string) (*PostFollowingResponse, error) {
+	return poolDo(p, "PostFollowing", func(c *Client) (*PostFollowingResponse, error) {
+		return c.PostFollowing(ctx, userID, targetUserID)
+	})
+}
+
+func (p *Pool) UndoMuting(ctx context.Context, sourceUserID string, targetUserID string) (*UndoMutingResponse, error) {
+	return poolDo(p, "UndoMuting", func(c *Client) (*UndoMutingResponse, error) {
+		return c.UndoMuting(ctx, sourceUserID, targetUserID)
+	})
+}
+
+func (p *Pool) Muting(ctx context.Context, userID string, opt ...*MuteOption) (*MutingResponse, error) {
+	return poolDo(p, "Muting", func(c *Client) (*MutingResponse, error) {
+		return c.Muting(ctx, userID, opt...)
+	})
+}
+
+func (p *Pool) PostMuting(ctx context.Context, userID string, targetUserID string) (*PostMutingResponse, error) {
+	return poolDo(p, "PostMuting", func(c *Client) (*PostMutingResponse, error) {
+		return c.PostMuting(ctx, userID, targetUserID)
+	})
+}
+
+func (p *Pool) RetrieveMultipleUsersWithIDs(ctx context.Context, userIDs []string, opt ...*RetrieveUserOption) (*UsersResponse, error) {
+	return poolDo(p, "RetrieveMultipleUsersWithIDs", func(c *Client) (*UsersResponse, error) {
+		return c.RetrieveMultipleUsersWithIDs(ctx, userIDs, opt...)
+	})
+}
+
+func (p *Pool) RetrieveSingleUserWithID(ctx context.Context, userID string, opt ...*RetrieveUserOption) (*UserResponse, error) {
+	return poolDo(p, "RetrieveSingleUserWithID", func(c *Client) (*UserResponse, error) {
+		return c.RetrieveSingleUserWithID(ctx, userID, opt...)
+	})
+}
+
+func (p *Pool) RetrieveMultipleUsersWithUserNames(ctx context.Context, userNames []string, opt ...*RetrieveUserOption) (*UsersResponse, error) {
+	return poolDo(p, "RetrieveMultipleUsersWithUserNames", func(c *Client) (*UsersResponse, error) {
+		return c.RetrieveMultipleUsersWithUserNames(ctx, userNames, opt...)
+	})
+}
+
+func (p *Pool) RetrieveSingleUserWithUserName(ctx context.Context, userName string, opt ...*RetrieveUserOption) (*UserResponse, error) {
+	return poolDo(p, "RetrieveSingleUserWithUserName", func(c *Client) (*UserResponse, error) {
+		return c.RetrieveSingleUserWithUserName(ctx, userName, opt...)
+	})
+}
+
+func (p *Pool) Me(ctx context.Context, opt ...*MeOption) (*MeResponse, error) {
+	return poolDo(p, "Me", func(c *Client) (*MeResponse, error) {
+		return c.Me(ctx, opt...)
+	})
+}
+
+func (p *Pool) SearchUsers(ctx context.Context, query string, opt ...*SearchUsersOption) (*SearchUsersResponse, error) {
+	return poolDo(p, "SearchUsers", func(c *Client) (*SearchUsersResponse, error) {
+		return c.SearchUsers(ctx, query, opt...)
+	})
+}
+
+func (p *Pool) SearchSpaces(ctx context.Context, searchTerm string, opt ...*SearchSpacesOption) (*SearchSpacesResponse, error) {
+	return poolDo(p, "SearchSpaces", func(c *Client) (*SearchSpacesResponse, error) {
+		return c.SearchSpaces(ctx, searchTerm, opt...)
+	})
+}
+
+func (p *Pool) LookUpSpaces(ctx context.Context, spaceIDs []string, opt ...*SpaceOption) (*SpacesResponse, error) {
+	return poolDo(p, "LookUpSpaces", func(c *Client) (*SpacesResponse, error) {
+		return c.LookUpSpaces(ctx, spaceIDs, opt...)
+	})
+}
+
+func (p *Pool) LookUpSpace(ctx context.Context, spaceID string, opt ...*SpaceOption) (*SpaceResponse, error) {
+	return poolDo(p, "LookUpSpace", func(c *Client) (*SpaceResponse, error) {
+		return c.LookUpSpace(ctx, spaceID, opt...)
+	})
+}
+
+func (p *Pool) UsersPurchasedSpaceTicket(ctx context.Context, spaceID string, opt ...*UsersPurchasedSpaceTicketOption) (*UsersPurchasedSpaceTicketResponse, error) {
+	return poolDo(p, "UsersPurchasedSpaceTicket", func(c *Client) (*UsersPurchasedSpaceTicketResponse, error) {
+		return c.UsersPurchasedSpaceTicket(ctx, spaceID, opt...)
+	})
+}
+
+func (p *Pool) SpacesTweets(ctx context.Context, spaceID string, opt ...*SpacesTweetsOption) (*SpacesTweetsResponse, error) {
+	return poolDo(p, "SpacesTweets", func(c *Client) (*SpacesTweetsResponse, error) {
+		return c.SpacesTweets(ctx, spaceID, opt...)
+	})
+}
+
+func (p *Pool) DiscoverSpaces(ctx context.Context, userIDs []string, opt ...*DiscoverSpacesOption) (*DiscoverSpacesResponse, error) {
+	return poolDo(p, "DiscoverSpaces", func(c *Client) (*DiscoverSpacesResponse, error) {
+		return c.DiscoverSpaces(ctx, userIDs, opt...)
+	})
+}
+
+func (p *Pool) LookUpListTweets(ctx context.Context, listID string, opt ...*ListTweetsOption) (*ListTweetsResponse, error) {
+	return poolDo(p, "LookUpListTweets", func(c *Client) (*ListTweetsResponse, error) {
+		return c.LookUpListTweets(ctx, listID, opt...)
+	})
+}
+
+func (p *Pool) UndoListFollows(ctx context.Context, listID string, userID string) (*UndoListFollowsResponse, error) {
+	return poolDo(p, "UndoListFollows", func(c *Client) (*UndoListFollowsResponse, error) {
+		return c.UndoListFollows(ctx, listID, userID)
+	})
+}
+
+func (p *Pool) ListFollowers(ctx context.Context, listID string, opt ...*ListFollowersOption) (*ListFollowersResponse, error) {
+	return poolDo(p, "ListFollowers", func(c *Client) (*ListFollowersResponse, error) {
+		return c.ListFollowers(ctx, listID, opt...)
+	})
+}
+
+func (p *Pool) AllListsUserFollows(ctx context.Context, userID string, opt ...*ListFollowsOption) (*AllListsUserFollowsResponse, error) {
+	return poolDo(p, "AllListsUserFollows", func(c *Client) (*AllListsUserFollowsResponse, error) {
+		return c.AllListsUserFollows(ctx, userID, opt...)
+	})
+}
+
+func (p *Pool) PostListFollows(ctx context.Context, listID string, userID string) (*PostListFollowsResponse, error) {
+	return poolDo(p, "PostListFollows", func(c *Client) (*PostListFollowsResponse, error) {
+		return c.PostListFollows(ctx, listID, userID)
+	})
+}
+
+func (p *Pool) LookUpList(ctx context.Context, listID string, opt ...*LookUpListOption) (*ListResponse, error) {
+	return poolDo(p, "LookUpList", func(c *Client) (*ListResponse, error) {
+		return c.LookUpList(ctx, listID, opt...)
+	})
+}
+
+func (p *Pool) LookUpAllListsOwned(ctx context.Context, userID string, opt ...*AllListsOwnedOption) (*AllListsOwnedResponse, error) {
+	return poolDo(p, "LookUpAllListsOwned", func(c *Client) (*AllListsOwnedResponse, error) {
+		return c.LookUpAllListsOwned(ctx, userID, opt...)
+	})
+}
+
+func (p *Pool) UndoListMembers(ctx context.Context, listID string, userID string) (*UndoListMembersResponse, error) {
+	return poolDo(p, "UndoListMembers", func(c *Client) (*UndoListMembersResponse, error) {
+		return c.UndoListMembers(ctx, listID, userID)
+	})
+}
+
+func (p *Pool) ListMembers(ctx context.Context, listID string, opt ...*ListMembersOption) (*ListMembersResponse, error) {
+	return poolDo(p, "ListMembers", func(c *Client) (*ListMembersResponse, error) {
+		return c.ListMembers(ctx, listID, opt...)
+	})
+}
+
+func (p *Pool) ListsSpecifiedUser(ctx context.Context, userID string, opt ...*ListsSpecifiedUserOption) (*ListsSpecifiedUserResponse, error) {
+	return poolDo(p, "ListsSpecifiedUser", func(c *Client) (*ListsSpecifiedUserResponse, error) {
+		return c.ListsSpecifiedUser(ctx, userID, opt...)
+	})
+}
+
+func (p *Pool) PostListMembers(ctx context.Context, listID string, userID string) (*PostListMembersResponse, error) {
+	return poolDo(p, "PostListMembers", func(c *Client) (*PostListMembersResponse, error) {
+		return c.PostListMembers(ctx, listID, userID)
+	})
+}
+
+func (p *Pool) DeleteList(ctx context.Context, listID string) (*DeleteListResponse, error) {
+	return poolDo(p, "DeleteList", func(c *Client) (*DeleteListResponse, error) {
+		return c.DeleteList(ctx, listID)
+	})
+}
+
+func (p *Pool) UpdateMetaDataForList(ctx context.Context, listID string, body ...*UpdateMetaDataForListBody) (*UpdateMetaDataForListResponse, error) {
+	return poolDo(p, "UpdateMetaDataForList", func(c *Client) (*UpdateMetaDataForListResponse, error) {
+		return c.UpdateMetaDataForList(ctx, listID, body...)
+	})
+}
+
+func (p *Pool) CreateNewList(ctx context.Context, body *CreateNewListBody) (*CreateNewListResponse, error) {
+	return poolDo(p, "CreateNewList", func(c *Client) (*CreateNewListResponse, error) {
+		return c.CreateNewList(ctx, body)
+	})
+}
+
+func (p *Pool) UndoPinnedLists(ctx context.Context, listID string, userID string) (*UndoPinnedListsResponse, error) {
+	return poolDo(p, "UndoPinnedLists", func(c *Client) (*UndoPinnedListsResponse, error) {
+		return c.UndoPinnedLists(ctx, listID, userID)
+	})
+}
+
+func (p *Pool) PinnedLists(ctx context.Context, userID string, opt ...*PinnedListsOption) (*PinnedListsResponse, error) {
+	return poolDo(p, "PinnedLists", func(c *Client) (*PinnedListsResponse, error) {
+		return c.PinnedLists(ctx, userID, opt...)
+	})
+}
+
+func (p *Pool) PostPinnedLists(ctx context.Context, listID string, userID string) (*PostPinnedListsResponse, error) {
+	return poolDo(p, "PostPinnedLists", func(c *Client) (*PostPinnedListsResponse, error) {
+		return c.PostPinnedLists(ctx, listID, userID)
+	})
+}
+
+func (p *Pool) ComplianceJobs(ctx context.Context, opt *ComplianceJobsOption) (*ComplianceJobsResponse, error) {
+	return poolDo(p, "ComplianceJobs", func(c *Client) (*ComplianceJobsResponse, error) {
+		return c.ComplianceJobs(ctx, opt)
+	})
+}
+
+func (p *Pool) ComplianceJob(ctx context.Context, complianceJobID int) (*ComplianceJobResponse, error) {
+	return poolDo(p, "ComplianceJob", func(c *Client) (*ComplianceJobResponse, error) {
+		return c.ComplianceJob(ctx, complianceJobID)
+	})
+}
+
+func (p *Pool) CreateComplianceJob(ctx context.Context, opt ...*CreateComplianceJobOption) (*CreateComplianceJobResponse, error) {
+	return poolDo(p, "CreateComplianceJob", func(c *Client) (*CreateComplianceJobResponse, error) {
+		return c.CreateComplianceJob(ctx, opt...)
+	})
+}
+
+func (p *Pool) CreateOneToOneDM(ctx context.Context, participantID string, body *CreateOneToOneDMBody) (*CreateOneToOneDMResponse, error) {
+	return poolDo(p, "CreateOneToOneDM", func(c *Client) (*CreateOneToOneDMResponse, error) {
+		return c.CreateOneToOneDM(ctx, participantID, body)
+	})
+}
+
+func (p *Pool) CreateNewGroupDM(ctx context.Context, conversationID string, body *CreateNewGroupDMBody) (*CreateNewGroupDMResponse, error) {
+	return poolDo(p, "CreateNewGroupDM", func(c *Client) (*CreateNewGroupDMResponse, error) {
+		return c.CreateNewGroupDM(ctx, conversationID, body)
+	})
+}
+
+func (p *Pool) PostDM(ctx context.Context, body *PostDMBody) (*PostDMResponse, error) {
+	return poolDo(p, "PostDM", func(c *Client) (*PostDMResponse, error) {
+		return c.PostDM(ctx, body)
+	})
+}
+
+func (p *Pool) LookUpAllOneToOneDM(ctx context.Context, participantID string, opt ...*DirectMessageOption) (*LookUpAllOneToOneDMResponse, error) {
+	return poolDo(p, "LookUpAllOneToOneDM", func(c *Client) (*LookUpAllOneToOneDMResponse, error) {
+		return c.LookUpAllOneToOneDM(ctx, participantID, opt...)
+	})
+}
+
+func (p *Pool) LookUpDM(ctx context.Context, dmConversationID string, opt ...*DirectMessageOption) (*LookUpDMResponse, error) {
+	return poolDo(p, "LookUpDM", func(c *Client) (*LookUpDMResponse, error) {
+		return c.LookUpDM(ctx, dmConversationID, opt...)
+	})
+}
+
+func (p *Pool) LookUpAllDM(ctx context.Context, opt ...*DirectMessageOption) (*LookUpAllDMResponse, error) {
+	return poolDo(p, "LookUpAllDM", func(c *Client) (*LookUpAllDMResponse, error) {
+		return c.LookUpAllDM(ctx, opt...)
+	})
+}
+
+func (p *Pool) PostDMBlocking(ctx context.Context, userID string, targetUserID string) (*PostDMBlockingResponse, error) {
+	return poolDo(p, "PostDMBlocking", func(c *Client) (*PostDMBlockingResponse, error) {
+		return c.PostDMBlocking(ctx, userID, targetUserID)
+	})
+}
+
+func (p *Pool) UndoDMBlocking(ctx context.Context, userID string, targetUserID string) (*UndoDMBlockingResponse, error) {
+	return poolDo(p, "UndoDMBlocking", func(c *Client) (*UndoDMBlockingResponse, error) {
+		return c.UndoDMBlocking(ctx, userID, targetUserID)
+	})
+}
+
+func (p *Pool) SearchPostsEligibleForNotes(ctx context.Context, opt ...*SearchPostsEligibleForNotesOption) (*SearchPostsEligibleForNotesResponse, error) {
+	return poolDo(p, "SearchPostsEligibleForNotes", func(c *Client) (*SearchPostsEligibleForNotesResponse, error) {
+		return c.SearchPostsEligibleForNotes(ctx, opt...)
+	})
+}
+
+func (p *Pool) SearchNotesWritten(ctx context.Context, opt ...*SearchNotesWrittenOption) (*SearchNotesWrittenResponse, error) {
+	return poolDo(p, "SearchNotesWritten", func(c *Client) (*SearchNotesWrittenResponse, error) {
+		return c.SearchNotesWritten(ctx, opt...)
+	})
+}
+
+func (p *Pool) CreateCommunityNote(ctx context.Context, body *CreateCommunityNoteBody) (*CreateCommunityNoteResponse, error) {
+	return poolDo(p, "CreateCommunityNote", func(c *Client) (*CreateCommunityNoteResponse, error) {
+		return c.CreateCommunityNote(ctx, body)
+	})
+}
+
+func (p *Pool) TrendsByWOEID(ctx context.Context, woeid string) (*TrendsByWOEIDResponse, error) {
+	return poolDo(p, "TrendsByWOEID", func(c *Client) (*TrendsByWOEIDResponse, error) {
+		return c.TrendsByWOEID(ctx, woeid)
+	})
+}
+
+func (p *Pool) UploadMedia(ctx context.Context, media io.Reader, mediaType string, opt ...*MediaUploadOption) (*MediaUploadResponse, error) {
+	return poolDo(p, "UploadMedia", func(c *Client) (*MediaUploadResponse, error) {
+		return c.UploadMedia(ctx, media, mediaType, opt...)
+	})
+}
+
+func (p *Pool) InitializeChunkedUpload(ctx context.Context, req *MediaUploadInitRequest) (*MediaUploadResponse, error) {
+	return poolDo(p, "InitializeChunkedUpload", func(c *Client) (*MediaUploadResponse, error) {
+		return c.InitializeChunkedUpload(ctx, req)
+	})
+}
+
+func (p *Pool) AppendChunkedUpload(ctx context.Context, req *MediaUploadAppendRequest) error {
+	_, err := poolDo(p, "AppendChunkedUpload", func(c *Client) (struct{}, error) {
+		return struct{}{}, c.AppendChunkedUpload(ctx, req)
+	})
+	return err
+}
+
+func (p *Pool) FinalizeChunkedUpload(ctx context.Context, req *MediaUploadFinalizeRequest) (*MediaUploadResponse, error) {
+	return poolDo(p, "FinalizeChunkedUpload", func(c *Client) (*MediaUploadResponse, error) {
+		return c.FinalizeChunkedUpload(ctx, req)
+	})
+}
+
+func (p *Pool) CheckUploadStatus(ctx context.Context, req *MediaUploadStatusRequest) (*MediaUploadResponse, error) {
+	return poolDo(p, "CheckUploadStatus", func(c *Client) (*MediaUploadResponse, error) {
+		return c.CheckUploadStatus(ctx, req)
+	})
+}
+
+func (p *Pool) CalculateEngagementRate(metrics *TweetMetrics) float64 {
+	return CalculateEngagementRate(metrics)
+}
+
+func (p *Pool) CalculateEngagementMetrics(metrics *TweetMetrics) *EngagementMetrics {
+	return CalculateEngagementMetrics(metrics)
+}
+
+func (p *Pool) GetTopPerformingTweets(tweets []*Tweet, limit int) []*Tweet {
+	return GetTopPerformingTweets(tweets, limit)
+}
+
+func (p *Pool) CompareMetrics(current, previous *TweetMetrics) *AnalyticsComparison {
+	return CompareMetrics(current, previous)
+}
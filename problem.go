@@ -0,0 +1,202 @@
+package gotwtr
+
+import (
+	"errors"
+	"strings"
+)
+
+// ProblemKind is the kind of problem reported by the API, derived from its type URI,
+// e.g. "https://api.twitter.com/2/problems/resource-not-found" is ProblemResourceNotFound.
+// A ProblemKind can be matched with errors.Is against the errors returned by the client.
+type ProblemKind string
+
+const (
+	ProblemUnknown                  ProblemKind = ""
+	ProblemInvalidRequest           ProblemKind = "invalid-request"
+	ProblemResourceNotFound         ProblemKind = "resource-not-found"
+	ProblemResourceUnavailable      ProblemKind = "resource-unavailable"
+	ProblemNotAuthorizedForResource ProblemKind = "not-authorized-for-resource"
+	ProblemNotAuthorizedForField    ProblemKind = "not-authorized-for-field"
+	ProblemClientForbidden          ProblemKind = "client-forbidden"
+	ProblemUnsupportedAuth          ProblemKind = "unsupported-authentication"
+	ProblemUsageCapped              ProblemKind = "usage-capped"
+	ProblemDisallowedResource       ProblemKind = "disallowed-resource"
+	ProblemDuplicateRules           ProblemKind = "duplicate-rules"
+	ProblemInvalidRules             ProblemKind = "invalid-rules"
+	ProblemRuleCap                  ProblemKind = "rule-cap"
+	ProblemNoncompliantRules        ProblemKind = "noncompliant-rules"
+	ProblemStreamingConnection      ProblemKind = "streaming-connection"
+	ProblemClientDisconnected       ProblemKind = "client-disconnected"
+	ProblemOperationalDisconnect    ProblemKind = "operational-disconnect"
+)
+
+var problemKinds = map[ProblemKind]struct{}{
+	ProblemInvalidRequest:           {},
+	ProblemResourceNotFound:         {},
+	ProblemResourceUnavailable:      {},
+	ProblemNotAuthorizedForResource: {},
+	ProblemNotAuthorizedForField:    {},
+	ProblemClientForbidden:          {},
+	ProblemUnsupportedAuth:          {},
+	ProblemUsageCapped:              {},
+	ProblemDisallowedResource:       {},
+	ProblemDuplicateRules:           {},
+	ProblemInvalidRules:             {},
+	ProblemRuleCap:                  {},
+	ProblemNoncompliantRules:        {},
+	ProblemStreamingConnection:      {},
+	ProblemClientDisconnected:       {},
+	ProblemOperationalDisconnect:    {},
+}
+
+func (k ProblemKind) Error() string {
+	if k == ProblemUnknown {
+		return "gotwtr: unknown problem"
+	}
+	return "gotwtr: " + string(k)
+}
+
+// problemKind returns the kind of the problem type URI.
+func problemKind(typeURI string) ProblemKind {
+	i := strings.LastIndex(typeURI, "/problems/")
+	if i < 0 {
+		return ProblemUnknown
+	}
+	k := ProblemKind(typeURI[i+len("/problems/"):])
+	if _, ok := problemKinds[k]; !ok {
+		return ProblemUnknown
+	}
+	return k
+}
+
+// Kind returns the kind of the problem.
+func (e *APIResponseError) Kind() ProblemKind {
+	return problemKind(e.Type)
+}
+
+// Kind returns the kind of the problem of the response.
+// If the response has no top-level problem type, the kind of its first errors[] entry is returned.
+func (e *HTTPError) Kind() ProblemKind {
+	if k := problemKind(e.Type); k != ProblemUnknown {
+		return k
+	}
+	for _, ae := range e.Errors {
+		if k := ae.Kind(); k != ProblemUnknown {
+			return k
+		}
+	}
+	return ProblemUnknown
+}
+
+func (e *HTTPError) hasProblem(kind ProblemKind) bool {
+	if kind == ProblemUnknown {
+		return false
+	}
+	if problemKind(e.Type) == kind {
+		return true
+	}
+	for _, ae := range e.Errors {
+		if ae.Kind() == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// HasProblem reports whether err is an *HTTPError reporting a problem of the kind.
+func HasProblem(err error, kind ProblemKind) bool {
+	return errors.Is(err, kind)
+}
+
+// IsInvalidRequest reports whether err reports an invalid-request problem.
+func IsInvalidRequest(err error) bool {
+	return HasProblem(err, ProblemInvalidRequest)
+}
+
+// IsResourceNotFound reports whether err reports a resource-not-found problem.
+func IsResourceNotFound(err error) bool {
+	return HasProblem(err, ProblemResourceNotFound)
+}
+
+// IsNotAuthorizedForResource reports whether err reports a not-authorized-for-resource problem.
+func IsNotAuthorizedForResource(err error) bool {
+	return HasProblem(err, ProblemNotAuthorizedForResource)
+}
+
+// IsClientForbidden reports whether err reports a client-forbidden problem.
+func IsClientForbidden(err error) bool {
+	return HasProblem(err, ProblemClientForbidden)
+}
+
+// IsUsageCapped reports whether err reports a usage-capped problem.
+func IsUsageCapped(err error) bool {
+	return HasProblem(err, ProblemUsageCapped)
+}
+
+// ResourceStatus classifies why a requested resource is missing from a partial response.
+type ResourceStatus string
+
+const (
+	// ResourceDeleted means the resource was deleted or never existed.
+	ResourceDeleted ResourceStatus = "deleted"
+	// ResourceSuspended means the resource, or the user who owns it, is suspended.
+	ResourceSuspended ResourceStatus = "suspended"
+	// ResourceNotAuthorized means the client is not authorized to see the resource, e.g. a protected Tweet.
+	ResourceNotAuthorized ResourceStatus = "not-authorized"
+	// ResourceUnavailable means the resource is unavailable for any other reason.
+	ResourceUnavailable ResourceStatus = "unavailable"
+)
+
+// ResourceStatus classifies the errors[] entry of a partial response.
+func (e *APIResponseError) ResourceStatus() ResourceStatus {
+	if strings.Contains(strings.ToLower(e.Detail), "suspended") {
+		return ResourceSuspended
+	}
+	switch e.Kind() {
+	case ProblemResourceNotFound:
+		return ResourceDeleted
+	case ProblemNotAuthorizedForResource:
+		return ResourceNotAuthorized
+	default:
+		return ResourceUnavailable
+	}
+}
+
+// resourceID returns the ID of the resource the errors[] entry is about.
+func (e *APIResponseError) resourceID() string {
+	if e.ResourceID != "" {
+		return e.ResourceID
+	}
+	if v, ok := e.Value.(string); ok {
+		return v
+	}
+	return ""
+}
+
+// missingResources classifies every errors[] entry about a resource by its ID.
+func missingResources(errs []*APIResponseError) map[string]ResourceStatus {
+	m := make(map[string]ResourceStatus)
+	for _, e := range errs {
+		id := e.resourceID()
+		if id == "" {
+			continue
+		}
+		m[id] = e.ResourceStatus()
+	}
+	return m
+}
+
+// MissingIDs returns why each requested Tweet ID is missing from the response, keyed by the ID.
+func (r *TweetsResponse) MissingIDs() map[string]ResourceStatus {
+	return missingResources(r.Errors)
+}
+
+// MissingIDs returns why each requested user ID or user name is missing from the response, keyed by the ID or name.
+func (r *UsersResponse) MissingIDs() map[string]ResourceStatus {
+	return missingResources(r.Errors)
+}
+
+// MissingIDs returns why each requested Space ID is missing from the response, keyed by the ID.
+func (r *SpacesResponse) MissingIDs() map[string]ResourceStatus {
+	return missingResources(r.Errors)
+}
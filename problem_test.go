@@ -0,0 +1,132 @@
+package gotwtr_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sivchari/gotwtr"
+)
+
+func Test_problemKind(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name  string
+		body  string
+		is    func(error) bool
+		kind  gotwtr.ProblemKind
+		other gotwtr.ProblemKind
+	}{
+		{
+			name:  "top-level usage capped",
+			body:  `{"title":"UsageCapExceeded","detail":"Usage cap exceeded: Monthly product cap","type":"https://api.twitter.com/2/problems/usage-capped"}`,
+			is:    gotwtr.IsUsageCapped,
+			kind:  gotwtr.ProblemUsageCapped,
+			other: gotwtr.ProblemClientForbidden,
+		},
+		{
+			name:  "client forbidden",
+			body:  `{"client_id":"1","reason":"client-not-enrolled","title":"Client Forbidden","type":"https://api.twitter.com/2/problems/client-forbidden"}`,
+			is:    gotwtr.IsClientForbidden,
+			kind:  gotwtr.ProblemClientForbidden,
+			other: gotwtr.ProblemUsageCapped,
+		},
+		{
+			name:  "resource not found in errors entry",
+			body:  `{"errors":[{"value":"1","detail":"Could not find tweet with id: [1].","title":"Not Found Error","resource_type":"tweet","parameter":"id","resource_id":"1","type":"https://api.twitter.com/2/problems/resource-not-found"}]}`,
+			is:    gotwtr.IsResourceNotFound,
+			kind:  gotwtr.ProblemResourceNotFound,
+			other: gotwtr.ProblemNotAuthorizedForResource,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			client := mockHTTPClient(func(req *http.Request) *http.Response {
+				return &http.Response{
+					StatusCode: http.StatusForbidden,
+					Body:       io.NopCloser(strings.NewReader(tt.body)),
+				}
+			})
+			c := gotwtr.New("key", gotwtr.WithHTTPClient(client))
+			_, err := c.RetrieveSingleTweet(context.Background(), "1")
+			if !tt.is(err) {
+				t.Errorf("helper(%v) = false", err)
+			}
+			if !errors.Is(err, tt.kind) {
+				t.Errorf("errors.Is(%v, %v) = false", err, tt.kind)
+			}
+			if errors.Is(err, tt.other) {
+				t.Errorf("errors.Is(%v, %v) = true", err, tt.other)
+			}
+			var herr *gotwtr.HTTPError
+			if !errors.As(err, &herr) {
+				t.Fatalf("errors.As(HTTPError) = false: %v", err)
+			}
+			if herr.Kind() != tt.kind {
+				t.Errorf("HTTPError.Kind() = %v, want %v", herr.Kind(), tt.kind)
+			}
+		})
+	}
+}
+
+func Test_missingIDs(t *testing.T) {
+	t.Parallel()
+	body := `{
+		"data": [{"id": "1", "text": "hello"}],
+		"errors": [
+			{
+				"value": "2",
+				"detail": "Could not find tweet with ids: [2].",
+				"title": "Not Found Error",
+				"resource_type": "tweet",
+				"parameter": "ids",
+				"resource_id": "2",
+				"type": "https://api.twitter.com/2/problems/resource-not-found"
+			},
+			{
+				"value": "3",
+				"detail": "Sorry, you are not authorized to see the Tweet with ids: [3].",
+				"title": "Authorization Error",
+				"resource_type": "tweet",
+				"parameter": "ids",
+				"resource_id": "3",
+				"type": "https://api.twitter.com/2/problems/not-authorized-for-resource"
+			},
+			{
+				"value": "4",
+				"detail": "User has been suspended: [4].",
+				"title": "Forbidden",
+				"resource_type": "user",
+				"parameter": "ids",
+				"resource_id": "4",
+				"type": "https://api.twitter.com/2/problems/resource-not-found"
+			}
+		]
+	}`
+	client := mockHTTPClient(func(req *http.Request) *http.Response {
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(strings.NewReader(body)),
+		}
+	})
+	c := gotwtr.New("key", gotwtr.WithHTTPClient(client))
+	got, err := c.RetrieveMultipleTweets(context.Background(), []string{"1", "2", "3", "4"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := map[string]gotwtr.ResourceStatus{
+		"2": gotwtr.ResourceDeleted,
+		"3": gotwtr.ResourceNotAuthorized,
+		"4": gotwtr.ResourceSuspended,
+	}
+	if diff := cmp.Diff(want, got.MissingIDs()); diff != "" {
+		t.Errorf("TweetsResponse.MissingIDs() mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,138 @@
+package gotwtr
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	rateLimitLimitHeader     = "x-rate-limit-limit"
+	rateLimitRemainingHeader = "x-rate-limit-remaining"
+	rateLimitResetHeader     = "x-rate-limit-reset"
+)
+
+// RateLimit is the rate limit state reported by the x-rate-limit-* response headers.
+type RateLimit struct {
+	// Limit is the maximum number of requests allowed in the current window.
+	Limit int
+	// Remaining is the number of requests left in the current window.
+	Remaining int
+	// Reset is the time at which the current window resets.
+	Reset time.Time
+}
+
+// parseRateLimit returns the rate limit state of the response headers,
+// or nil if the response does not carry rate limit headers.
+func parseRateLimit(h http.Header) *RateLimit {
+	if h.Get(rateLimitLimitHeader) == "" && h.Get(rateLimitRemainingHeader) == "" && h.Get(rateLimitResetHeader) == "" {
+		return nil
+	}
+	var rl RateLimit
+	if v, err := strconv.Atoi(h.Get(rateLimitLimitHeader)); err == nil {
+		rl.Limit = v
+	}
+	if v, err := strconv.Atoi(h.Get(rateLimitRemainingHeader)); err == nil {
+		rl.Remaining = v
+	}
+	if v, err := strconv.ParseInt(h.Get(rateLimitResetHeader), 10, 64); err == nil {
+		rl.Reset = time.Unix(v, 0)
+	}
+	return &rl
+}
+
+// RateLimitError is returned when the API responds with 429 Too Many Requests.
+// It unwraps to the *HTTPError of the response.
+type RateLimitError struct {
+	*HTTPError
+	RateLimit *RateLimit
+}
+
+func (e *RateLimitError) Error() string {
+	if e.RateLimit == nil || e.RateLimit.Reset.IsZero() {
+		return e.APIName + ": rate limit exceeded " + e.URL
+	}
+	return fmt.Sprintf("%s: rate limit exceeded, resets at %s %s", e.APIName, e.RateLimit.Reset.Format(time.RFC3339), e.URL)
+}
+
+func (e *RateLimitError) Unwrap() error {
+	return e.HTTPError
+}
+
+// ResetAt returns the time at which the rate limit window resets.
+// It returns the zero time if the response did not report it.
+func (e *RateLimitError) ResetAt() time.Time {
+	if e.RateLimit == nil {
+		return time.Time{}
+	}
+	return e.RateLimit.Reset
+}
+
+// rateLimits records the latest rate limit state per API name.
+type rateLimits struct {
+	mu     sync.Mutex
+	limits map[string]RateLimit
+}
+
+func newRateLimits() *rateLimits {
+	return &rateLimits{
+		limits: make(map[string]RateLimit),
+	}
+}
+
+func (r *rateLimits) set(apiName string, rl *RateLimit) {
+	if rl == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.limits[apiName] = *rl
+}
+
+func (r *rateLimits) get(apiName string) (RateLimit, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	rl, ok := r.limits[apiName]
+	return rl, ok
+}
+
+func (r *rateLimits) all() map[string]RateLimit {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	m := make(map[string]RateLimit, len(r.limits))
+	for k, v := range r.limits {
+		m[k] = v
+	}
+	return m
+}
+
+// wait blocks until a request for apiName fits in the current rate limit window
+// and reserves one request of the remaining quota.
+// It returns ctx.Err() if ctx is done before the window resets.
+func (r *rateLimits) wait(ctx context.Context, apiName string) error {
+	for {
+		r.mu.Lock()
+		rl, ok := r.limits[apiName]
+		if ok && rl.Remaining <= 0 && !rl.Reset.IsZero() && !time.Now().Before(rl.Reset) {
+			// The window has been reset, but the new state is unknown until the next response.
+			rl.Remaining = rl.Limit
+			rl.Reset = time.Time{}
+		}
+		if !ok || rl.Remaining > 0 || rl.Reset.IsZero() {
+			if ok && rl.Remaining > 0 {
+				rl.Remaining--
+				r.limits[apiName] = rl
+			}
+			r.mu.Unlock()
+			return nil
+		}
+		r.mu.Unlock()
+
+		if err := sleep(ctx, time.Until(rl.Reset)); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,168 @@
+package gotwtr_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sivchari/gotwtr"
+)
+
+func Test_rateLimit(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		client *http.Client
+	}
+	tests := []struct {
+		name          string
+		args          args
+		want          gotwtr.RateLimit
+		wantRecorded  bool
+		wantRateLimit bool
+	}{
+		{
+			name: "200 ok with rate limit headers",
+			args: args{
+				client: mockHTTPClient(func(req *http.Request) *http.Response {
+					h := http.Header{}
+					h.Set("x-rate-limit-limit", "450")
+					h.Set("x-rate-limit-remaining", "449")
+					h.Set("x-rate-limit-reset", "1700000000")
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Header:     h,
+						Body:       io.NopCloser(strings.NewReader(`{"data":[]}`)),
+					}
+				}),
+			},
+			want: gotwtr.RateLimit{
+				Limit:     450,
+				Remaining: 449,
+				Reset:     time.Unix(1700000000, 0),
+			},
+			wantRecorded: true,
+		},
+		{
+			name: "200 ok without rate limit headers",
+			args: args{
+				client: mockHTTPClient(func(req *http.Request) *http.Response {
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Body:       io.NopCloser(strings.NewReader(`{"data":[]}`)),
+					}
+				}),
+			},
+			wantRecorded: false,
+		},
+		{
+			name: "429 too many requests",
+			args: args{
+				client: mockHTTPClient(func(req *http.Request) *http.Response {
+					h := http.Header{}
+					h.Set("x-rate-limit-limit", "450")
+					h.Set("x-rate-limit-remaining", "0")
+					h.Set("x-rate-limit-reset", "1700000900")
+					return &http.Response{
+						StatusCode: http.StatusTooManyRequests,
+						Status:     "429 Too Many Requests",
+						Header:     h,
+						Body:       io.NopCloser(strings.NewReader(`{"title":"Too Many Requests","detail":"Too Many Requests","type":"about:blank","status":429}`)),
+					}
+				}),
+			},
+			want: gotwtr.RateLimit{
+				Limit:     450,
+				Remaining: 0,
+				Reset:     time.Unix(1700000900, 0),
+			},
+			wantRecorded:  true,
+			wantRateLimit: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			c := gotwtr.New("key", gotwtr.WithHTTPClient(tt.args.client))
+			_, err := c.SearchRecentTweets(context.Background(), "gotwtr")
+			var rlErr *gotwtr.RateLimitError
+			if got := errors.As(err, &rlErr); got != tt.wantRateLimit {
+				t.Fatalf("errors.As(RateLimitError) = %v, want %v: %v", got, tt.wantRateLimit, err)
+			}
+			if tt.wantRateLimit && !rlErr.ResetAt().Equal(tt.want.Reset) {
+				t.Errorf("RateLimitError.ResetAt() = %v, want %v", rlErr.ResetAt(), tt.want.Reset)
+			}
+			got, ok := c.RateLimit("search recent tweets")
+			if ok != tt.wantRecorded {
+				t.Fatalf("client.RateLimit() recorded = %v, want %v", ok, tt.wantRecorded)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("client.RateLimit() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_waitOnRateLimit(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		reset     time.Duration
+		timeout   time.Duration
+		wantCalls int
+		wantErr   error
+	}{
+		{
+			name:      "wait until ctx is done while quota is used up",
+			reset:     time.Hour,
+			timeout:   50 * time.Millisecond,
+			wantCalls: 1,
+			wantErr:   context.DeadlineExceeded,
+		},
+		{
+			name:      "proceed once the window has been reset",
+			reset:     -time.Second,
+			timeout:   time.Second,
+			wantCalls: 2,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var calls int
+			client := mockHTTPClient(func(req *http.Request) *http.Response {
+				calls++
+				h := http.Header{}
+				h.Set("x-rate-limit-limit", "1")
+				h.Set("x-rate-limit-remaining", "0")
+				h.Set("x-rate-limit-reset", strconv.FormatInt(time.Now().Add(tt.reset).Unix(), 10))
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Header:     h,
+					Body:       io.NopCloser(strings.NewReader(`{"data":[]}`)),
+				}
+			})
+			c := gotwtr.New("key", gotwtr.WithHTTPClient(client), gotwtr.WithWaitOnRateLimit())
+			if _, err := c.UserTweetTimeline(context.Background(), "1"); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), tt.timeout)
+			defer cancel()
+			_, err := c.UserTweetTimeline(ctx, "1")
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("client.UserTweetTimeline() error = %v, want %v", err, tt.wantErr)
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("requests sent = %d, want %d", calls, tt.wantCalls)
+			}
+		})
+	}
+}
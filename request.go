@@ -0,0 +1,238 @@
+package gotwtr
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// maxErrorBodyRead is the maximum size of an unsuccessful response body read into memory.
+const maxErrorBodyRead = 1 << 20
+
+// doJSON performs the API operation apiName through the middlewares and cache of the client
+// and decodes the response body into T.
+// If the response status is not one of statusCodes, the decoded response is returned along with responseError.
+func doJSON[T any](c *client, req *http.Request, apiName string, statusCodes ...int) (*T, error) {
+	invoke := func(ctx context.Context, req *http.Request) (any, error) {
+		resp, err := c.cached(req.WithContext(ctx), apiName)
+		if err != nil {
+			// *HTTPError and *RateLimitError already name the operation.
+			var herr *HTTPError
+			if errors.As(err, &herr) {
+				return nil, err
+			}
+			return nil, fmt.Errorf("%s: %w", apiName, err)
+		}
+		defer func() { _ = resp.Body.Close() }()
+
+		var v T
+		if err := json.NewDecoder(resp.Body).Decode(&v); err != nil && !errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("%s decode: %w", apiName, err)
+		}
+		for _, code := range statusCodes {
+			if resp.StatusCode == code {
+				return &v, nil
+			}
+		}
+		return &v, responseError(apiName, req, resp)
+	}
+	ctx := req.Context()
+	if c.cache != nil {
+		ctx = withCacheStatus(ctx)
+	}
+	res, err := c.intercept(ctx, apiName, req.WithContext(ctx), invoke)
+	v, _ := res.(*T)
+	return v, err
+}
+
+// doStream connects to the streaming operation apiName through the middlewares of the client.
+func (c *client) doStream(req *http.Request, apiName string) (*http.Response, error) {
+	invoke := func(ctx context.Context, req *http.Request) (any, error) {
+		return c.do(req.WithContext(withStream(ctx)), apiName)
+	}
+	res, err := c.intercept(req.Context(), apiName, req, invoke)
+	resp, _ := res.(*http.Response)
+	return resp, err
+}
+
+// do sends req and records the rate limit state reported for apiName.
+// The request is retried according to the retry policy of the client,
+// and retried once more with a new token if the token was rejected with 401 Unauthorized.
+// The body of an unsuccessful response is buffered so that responseError can report it,
+// and a body that is not JSON is reported right away as the error of do.
+func (c *client) do(req *http.Request, apiName string) (*http.Response, error) {
+	rewindable := req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+	attempts := c.retryPolicy.attempts(req.Method)
+	if !rewindable {
+		attempts = 1
+	}
+	ctx := req.Context()
+	sent, renewed := false, false
+	for attempt := 1; ; attempt++ {
+		r := req
+		if sent {
+			var err error
+			if r, err = rewind(req); err != nil {
+				return nil, err
+			}
+		}
+		tok, err := c.token(r)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkScopes(apiName, tok); err != nil {
+			return nil, err
+		}
+		sent = true
+		resp, err := c.send(r, apiName, tok)
+		if err == nil && resp.StatusCode == http.StatusUnauthorized && rewindable && !renewed && c.renewToken(ctx, tok) {
+			// The retry with the new token does not count as an attempt.
+			renewed = true
+			attempt--
+			discard(resp)
+			continue
+		}
+		if attempt >= attempts || !retryable(ctx, resp, err) {
+			if err != nil || successful(resp.StatusCode) {
+				return resp, err
+			}
+			return bufferErrorBody(apiName, req, resp)
+		}
+		d := c.retryPolicy.backoff(attempt, resp)
+		discard(resp)
+		if err := sleep(ctx, d); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// token returns the token to authorize req with.
+// A request already carrying credentials, such as the Basic credentials of the oauth2 endpoints,
+// or signed with OAuth 1.0a needs no token.
+func (c *client) token(req *http.Request) (*OAuth2Token, error) {
+	if req.Header.Get("Authorization") != "" || c.oauth1 != nil {
+		return nil, nil
+	}
+	tok, err := c.tokenSource.Token(req.Context())
+	if err != nil {
+		return nil, fmt.Errorf("token source: %w", err)
+	}
+	if tok == nil {
+		return nil, errors.New("token source: no token")
+	}
+	return tok, nil
+}
+
+// renewToken invalidates the rejected token and reports whether the token source supplies another one.
+func (c *client) renewToken(ctx context.Context, rejected *OAuth2Token) bool {
+	inv, ok := c.tokenSource.(TokenInvalidator)
+	if !ok || rejected == nil {
+		return false
+	}
+	inv.Invalidate(rejected)
+	tok, err := c.tokenSource.Token(ctx)
+	return err == nil && tok != nil && tok.AccessToken != rejected.AccessToken
+}
+
+// send sends a single attempt of req authorized with tok, or signed with OAuth 1.0a.
+// If the client waits on rate limits, send blocks until the quota of apiName is available.
+func (c *client) send(req *http.Request, apiName string, tok *OAuth2Token) (*http.Response, error) {
+	switch {
+	case tok != nil:
+		req = req.Clone(req.Context())
+		req.Header.Set("Authorization", "Bearer "+tok.AccessToken)
+	case c.oauth1 != nil && req.Header.Get("Authorization") == "":
+		req = req.Clone(req.Context())
+		if err := c.signOAuth1(req); err != nil {
+			return nil, fmt.Errorf("oauth1 sign: %w", err)
+		}
+	}
+	if c.waitRateLimit {
+		if err := c.rateLimits.wait(req.Context(), apiName); err != nil {
+			return nil, err
+		}
+	}
+	var body []byte
+	if c.logger != nil && c.logBodies {
+		body = requestBody(req)
+	}
+	start := time.Now()
+	resp, err := c.client.Do(req)
+	c.logRequest(req, apiName, body, resp, err, time.Since(start))
+	if err != nil {
+		return nil, err
+	}
+	c.rateLimits.set(apiName, parseRateLimit(resp.Header))
+	return resp, nil
+}
+
+func successful(statusCode int) bool {
+	return statusCode >= http.StatusOK && statusCode < http.StatusMultipleChoices
+}
+
+// errorBody is the buffered body of an unsuccessful response.
+type errorBody struct {
+	*bytes.Reader
+	raw []byte
+}
+
+func (b *errorBody) Close() error {
+	return nil
+}
+
+func bufferErrorBody(apiName string, req *http.Request, resp *http.Response) (*http.Response, error) {
+	raw, err := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodyRead))
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = &errorBody{
+		Reader: bytes.NewReader(raw),
+		raw:    raw,
+	}
+	if !json.Valid(raw) {
+		return nil, responseError(apiName, req, resp)
+	}
+	return resp, nil
+}
+
+// responseError returns the error for an unexpected response status.
+// A 429 response is reported as *RateLimitError, any other status as *HTTPError.
+func responseError(apiName string, req *http.Request, resp *http.Response) error {
+	herr := &HTTPError{
+		APIName:    apiName,
+		Status:     resp.Status,
+		StatusCode: resp.StatusCode,
+		URL:        req.URL.String(),
+	}
+	if b, ok := resp.Body.(*errorBody); ok {
+		var problem struct {
+			Type   string              `json:"type"`
+			Title  string              `json:"title"`
+			Detail string              `json:"detail"`
+			Errors []*APIResponseError `json:"errors"`
+		}
+		_ = json.Unmarshal(b.raw, &problem)
+		herr.Type = problem.Type
+		herr.Title = problem.Title
+		herr.Detail = problem.Detail
+		herr.Errors = problem.Errors
+		raw := b.raw
+		if len(raw) > maxErrorBodySize {
+			raw = raw[:maxErrorBodySize]
+		}
+		herr.Body = string(raw)
+	}
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return &RateLimitError{
+			HTTPError: herr,
+			RateLimit: parseRateLimit(resp.Header),
+		}
+	}
+	return herr
+}
@@ -0,0 +1,161 @@
+package gotwtr
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+var errRewindBody = errors.New("request body can not be rewound")
+
+const (
+	defaultRetryMinBackoff = 500 * time.Millisecond
+	defaultRetryMaxBackoff = 30 * time.Second
+)
+
+// RetryPolicy configures how the client retries requests that failed transiently.
+// Network errors and 429, 500, 502, 503 and 504 responses are retried.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts including the first one.
+	// A value less than 2 disables retries.
+	MaxAttempts int
+	// MinBackoff is the base delay before the first retry. The default is 500ms.
+	MinBackoff time.Duration
+	// MaxBackoff caps the exponential backoff between attempts. The default is 30s.
+	// It does not cap the delay requested by Retry-After or a rate limit reset.
+	MaxBackoff time.Duration
+	// RetryNonIdempotent allows retrying POST requests such as PostTweet.
+	// A retried POST may be applied more than once.
+	RetryNonIdempotent bool
+}
+
+// WithRetryPolicy sets the retry policy of the client. By default no request is retried.
+func WithRetryPolicy(p RetryPolicy) ClientOption {
+	return func(c *client) {
+		if p.MinBackoff <= 0 {
+			p.MinBackoff = defaultRetryMinBackoff
+		}
+		if p.MaxBackoff <= 0 {
+			p.MaxBackoff = defaultRetryMaxBackoff
+		}
+		c.retryPolicy = &p
+	}
+}
+
+// attempts returns the number of attempts allowed for the request method.
+func (p *RetryPolicy) attempts(method string) int {
+	if p == nil || p.MaxAttempts < 2 {
+		return 1
+	}
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return p.MaxAttempts
+	default:
+		if p.RetryNonIdempotent {
+			return p.MaxAttempts
+		}
+		return 1
+	}
+}
+
+// retryable reports whether the result of an attempt is worth retrying.
+func retryable(ctx context.Context, resp *http.Response, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	if err != nil {
+		return !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests,
+		http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// backoff returns the delay before the next attempt.
+// Retry-After and the rate limit reset of resp take precedence over the exponential backoff.
+func (p *RetryPolicy) backoff(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if d, ok := retryAfter(resp.Header); ok {
+			return d
+		}
+		if resp.StatusCode == http.StatusTooManyRequests {
+			if rl := parseRateLimit(resp.Header); rl != nil && !rl.Reset.IsZero() {
+				if d := time.Until(rl.Reset); d > 0 {
+					return d
+				}
+			}
+		}
+	}
+	d := p.MinBackoff << (attempt - 1)
+	if d <= 0 || d > p.MaxBackoff {
+		d = p.MaxBackoff
+	}
+	// Equal jitter keeps the delay within [d/2, d].
+	half := d / 2
+	return half + time.Duration(rand.Int63n(int64(half)+1))
+}
+
+// retryAfter parses the Retry-After header given in seconds or as an HTTP date.
+func retryAfter(h http.Header) (time.Duration, bool) {
+	v := h.Get("Retry-After")
+	if v == "" {
+		return 0, false
+	}
+	if s, err := strconv.Atoi(v); err == nil && s >= 0 {
+		return time.Duration(s) * time.Second, true
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		if d := time.Until(t); d > 0 {
+			return d, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
+
+// rewind returns a copy of req with a fresh body for another attempt.
+func rewind(req *http.Request) (*http.Request, error) {
+	r := req.Clone(req.Context())
+	if req.Body == nil || req.Body == http.NoBody {
+		return r, nil
+	}
+	if req.GetBody == nil {
+		return nil, errRewindBody
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, err
+	}
+	r.Body = body
+	return r, nil
+}
+
+func discard(resp *http.Response) {
+	if resp == nil {
+		return
+	}
+	_, _ = io.CopyN(io.Discard, resp.Body, 4<<10)
+	_ = resp.Body.Close()
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
@@ -0,0 +1,132 @@
+package gotwtr_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sivchari/gotwtr"
+)
+
+func Test_retryPolicy(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		policy   gotwtr.RetryPolicy
+		statuses []int
+		call     func(c *gotwtr.Client) error
+	}
+	tests := []struct {
+		name      string
+		args      args
+		wantCalls int
+		wantErr   bool
+	}{
+		{
+			name: "retry GET on 503",
+			args: args{
+				policy:   gotwtr.RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond},
+				statuses: []int{http.StatusServiceUnavailable, http.StatusOK},
+				call: func(c *gotwtr.Client) error {
+					_, err := c.RetrieveMultipleTweets(context.Background(), []string{"1"})
+					return err
+				},
+			},
+			wantCalls: 2,
+			wantErr:   false,
+		},
+		{
+			name: "give up after max attempts",
+			args: args{
+				policy:   gotwtr.RetryPolicy{MaxAttempts: 2, MinBackoff: time.Millisecond},
+				statuses: []int{http.StatusBadGateway, http.StatusBadGateway, http.StatusOK},
+				call: func(c *gotwtr.Client) error {
+					_, err := c.RetrieveMultipleTweets(context.Background(), []string{"1"})
+					return err
+				},
+			},
+			wantCalls: 2,
+			wantErr:   true,
+		},
+		{
+			name: "do not retry client errors",
+			args: args{
+				policy:   gotwtr.RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond},
+				statuses: []int{http.StatusBadRequest, http.StatusOK},
+				call: func(c *gotwtr.Client) error {
+					_, err := c.RetrieveMultipleTweets(context.Background(), []string{"1"})
+					return err
+				},
+			},
+			wantCalls: 1,
+			wantErr:   true,
+		},
+		{
+			name: "do not retry POST by default",
+			args: args{
+				policy:   gotwtr.RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond},
+				statuses: []int{http.StatusServiceUnavailable, http.StatusCreated},
+				call: func(c *gotwtr.Client) error {
+					_, err := c.PostTweet(context.Background(), &gotwtr.PostTweetOption{Text: "hello"})
+					return err
+				},
+			},
+			wantCalls: 1,
+			wantErr:   true,
+		},
+		{
+			name: "retry POST with opt-in",
+			args: args{
+				policy:   gotwtr.RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond, RetryNonIdempotent: true},
+				statuses: []int{http.StatusServiceUnavailable, http.StatusCreated},
+				call: func(c *gotwtr.Client) error {
+					_, err := c.PostTweet(context.Background(), &gotwtr.PostTweetOption{Text: "hello"})
+					return err
+				},
+			},
+			wantCalls: 2,
+			wantErr:   false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var (
+				calls  int
+				bodies []string
+			)
+			client := mockHTTPClient(func(req *http.Request) *http.Response {
+				status := tt.args.statuses[calls]
+				calls++
+				if req.Body != nil {
+					b, _ := io.ReadAll(req.Body)
+					bodies = append(bodies, string(b))
+				}
+				h := http.Header{}
+				h.Set("Retry-After", "0")
+				return &http.Response{
+					StatusCode: status,
+					Status:     http.StatusText(status),
+					Header:     h,
+					Body:       io.NopCloser(strings.NewReader(`{}`)),
+				}
+			})
+			c := gotwtr.New("key", gotwtr.WithHTTPClient(client), gotwtr.WithRetryPolicy(tt.args.policy))
+			err := tt.args.call(c)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("requests sent = %d, want %d", calls, tt.wantCalls)
+			}
+			for _, b := range bodies {
+				if b != bodies[0] {
+					t.Errorf("retried request body = %q, want %q", b, bodies[0])
+				}
+			}
+		})
+	}
+}
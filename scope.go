@@ -0,0 +1,263 @@
+package gotwtr
+
+import (
+	"errors"
+	"sort"
+	"strings"
+)
+
+// OAuth 2.0 scopes of user-context tokens.
+const (
+	ScopeTweetRead          = "tweet.read"
+	ScopeTweetWrite         = "tweet.write"
+	ScopeTweetModerateWrite = "tweet.moderate.write"
+	ScopeUsersRead          = "users.read"
+	ScopeFollowsRead        = "follows.read"
+	ScopeFollowsWrite       = "follows.write"
+	ScopeOfflineAccess      = "offline.access"
+	ScopeSpaceRead          = "space.read"
+	ScopeMuteRead           = "mute.read"
+	ScopeMuteWrite          = "mute.write"
+	ScopeLikeRead           = "like.read"
+	ScopeLikeWrite          = "like.write"
+	ScopeListRead           = "list.read"
+	ScopeListWrite          = "list.write"
+	ScopeBlockRead          = "block.read"
+	ScopeBlockWrite         = "block.write"
+	ScopeBookmarkRead       = "bookmark.read"
+	ScopeBookmarkWrite      = "bookmark.write"
+	ScopeDMRead             = "dm.read"
+	ScopeDMWrite            = "dm.write"
+	ScopeMediaWrite         = "media.write"
+)
+
+// EndpointScopes are the scopes a method of Twtr requires of a user-context token.
+type EndpointScopes struct {
+	// Method is the name of the method of Twtr, e.g. "PostTweet".
+	Method string
+	// Operation is the operation name of the method, the same one reported by HTTPError.APIName.
+	Operation string
+	// Scopes are the required scopes. They are empty for app-only endpoints, which do not accept user-context tokens.
+	Scopes []string
+}
+
+// scopes returns tweet.read and users.read, which every user-context endpoint requires, followed by s.
+func scopes(s ...string) []string {
+	return append([]string{ScopeTweetRead, ScopeUsersRead}, s...)
+}
+
+var scopeCatalog = []EndpointScopes{
+	// Bookmarks
+	{"LookupUserBookmarks", "lookup user bookmarks", scopes(ScopeBookmarkRead)},
+	{"BookmarkTweet", "bookmark tweet", scopes(ScopeBookmarkWrite)},
+	{"RemoveBookmarkOfTweet", "remove bookmark of tweet", scopes(ScopeBookmarkWrite)},
+	// Filtered stream
+	{"ConnectToStream", "connect to stream", nil},
+	{"RetrieveStreamRules", "retrieve stream rules", nil},
+	{"AddOrDeleteRules", "add or delete", nil},
+	// Hide replies
+	{"HideReplies", "hide replies", scopes(ScopeTweetModerateWrite)},
+	// Likes
+	{"UsersLikingTweet", "users liking tweet", scopes(ScopeLikeRead)},
+	{"TweetsUserLiked", "tweets user liked", scopes(ScopeLikeRead)},
+	{"PostUsersLikingTweet", "post users liking tweet", scopes(ScopeLikeWrite)},
+	{"UndoUsersLikingTweet", "undo users liking tweet", scopes(ScopeLikeWrite)},
+	// Manage Tweets
+	{"PostTweet", "post tweet", scopes(ScopeTweetWrite)},
+	{"DeleteTweet", "delete tweet", scopes(ScopeTweetWrite)},
+	// Quote tweets
+	{"QuoteTweets", "quote tweets", scopes()},
+	// Retweets
+	{"RetweetsLookup", "retweets lookup", scopes()},
+	{"PostRetweet", "post retweet", scopes(ScopeTweetWrite)},
+	{"UndoRetweet", "undo retweet", scopes(ScopeTweetWrite)},
+	// Search Tweets
+	{"SearchRecentTweets", "search recent tweets", scopes()},
+	{"SearchAllTweets", "search all tweets", nil},
+	// Timelines
+	{"UserMentionTimeline", "user mention timeline", scopes()},
+	{"UserReverseChronologicalTimeline", "user reverse chronological timeline", scopes()},
+	{"UserTweetTimeline", "user tweet timeline", scopes()},
+	// Tweet counts
+	{"CountRecentTweets", "count of recent tweets", nil},
+	{"CountAllTweets", "count of all tweets", nil},
+	// Tweets lookup
+	{"RetrieveMultipleTweets", "retrieve multiple tweets", scopes()},
+	{"RetrieveSingleTweet", "retrieve single tweet", scopes()},
+	// Volume stream
+	{"VolumeStreams", "sampled stream", nil},
+	{"VolumeStreams10", "sampled stream 10%", nil},
+	// Blocks
+	{"Blocking", "blocking", scopes(ScopeBlockRead)},
+	{"PostBlocking", "post blocking", scopes(ScopeBlockWrite)},
+	{"UndoBlocking", "undo blocking", scopes(ScopeBlockWrite)},
+	// Follows
+	{"Followers", "followers", scopes(ScopeFollowsRead)},
+	{"Following", "following", scopes(ScopeFollowsRead)},
+	{"PostFollowing", "post following", scopes(ScopeFollowsWrite)},
+	{"UndoFollowing", "undo following", scopes(ScopeFollowsWrite)},
+	// Mutes
+	{"Muting", "muting", scopes(ScopeMuteRead)},
+	{"PostMuting", "post muting", scopes(ScopeMuteWrite)},
+	{"UndoMuting", "undo muting", scopes(ScopeMuteWrite)},
+	// Users lookup
+	{"RetrieveMultipleUsersWithIDs", "user lookup", scopes()},
+	{"RetrieveSingleUserWithID", "retrieve single user with id", scopes()},
+	{"RetrieveMultipleUsersWithUserNames", "users lookup by usernames", scopes()},
+	{"RetrieveSingleUserWithUserName", "retrieve single user with user name", scopes()},
+	{"Me", "me", scopes()},
+	// User search
+	{"SearchUsers", "search users", scopes()},
+	// Spaces
+	{"SearchSpaces", "search spaces", scopes(ScopeSpaceRead)},
+	{"LookUpSpaces", "look up spaces", scopes(ScopeSpaceRead)},
+	{"LookUpSpace", "space lookup by id", scopes(ScopeSpaceRead)},
+	{"UsersPurchasedSpaceTicket", "users purchased space ticket", scopes(ScopeSpaceRead)},
+	{"SpacesTweets", "spaces tweets", scopes(ScopeSpaceRead)},
+	{"DiscoverSpaces", "discover spaces", scopes(ScopeSpaceRead)},
+	// List Tweets lookup
+	{"LookUpListTweets", "look up list tweets", scopes(ScopeListRead)},
+	// List follows
+	{"ListFollowers", "list followers", scopes(ScopeListRead)},
+	{"AllListsUserFollows", "all lists user follows", scopes(ScopeListRead)},
+	{"PostListFollows", "post list follows", scopes(ScopeListWrite)},
+	{"UndoListFollows", "undo list follows", scopes(ScopeListWrite)},
+	// List lookup
+	{"LookUpList", "look up list", scopes(ScopeListRead)},
+	{"LookUpAllListsOwned", "look up all lists owned", scopes(ScopeListRead)},
+	// List members
+	{"ListMembers", "owned lists lookup by id", scopes(ScopeListRead)},
+	{"ListsSpecifiedUser", "lists specified user", scopes(ScopeListRead)},
+	{"PostListMembers", "post list members", scopes(ScopeListWrite)},
+	{"UndoListMembers", "undo list members", scopes(ScopeListWrite)},
+	// Manage Lists
+	{"CreateNewList", "create new list", scopes(ScopeListWrite)},
+	{"DeleteList", "delete list", scopes(ScopeListWrite)},
+	{"UpdateMetaDataForList", "update meta data for list", scopes(ScopeListWrite)},
+	// Pinned Lists
+	{"PinnedLists", "pinned lists", scopes(ScopeListRead)},
+	{"PostPinnedLists", "post pinned lists", scopes(ScopeListWrite)},
+	{"UndoPinnedLists", "undo pinned lists", scopes(ScopeListWrite)},
+	// Batch compliance
+	{"ComplianceJobs", "compliance jobs", nil},
+	{"ComplianceJob", "compliance job", nil},
+	{"CreateComplianceJob", "create compliance job", nil},
+	// Direct Message
+	{"CreateOneToOneDM", "create one to one DM", scopes(ScopeDMRead, ScopeDMWrite)},
+	{"CreateNewGroupDM", "create new group DM", scopes(ScopeDMRead, ScopeDMWrite)},
+	{"PostDM", "post DM", scopes(ScopeDMRead, ScopeDMWrite)},
+	{"LookUpAllOneToOneDM", "lookup all one to one DM", scopes(ScopeDMRead)},
+	{"LookUpDM", "lookup DM", scopes(ScopeDMRead)},
+	{"LookUpAllDM", "lookup all DM", scopes(ScopeDMRead)},
+	// DM Blocks
+	{"PostDMBlocking", "post dm blocking", scopes(ScopeDMWrite)},
+	{"UndoDMBlocking", "undo dm blocking", scopes(ScopeDMWrite)},
+	// Community Notes
+	{"SearchPostsEligibleForNotes", "search posts eligible for notes", scopes()},
+	{"SearchNotesWritten", "search notes written", scopes()},
+	{"CreateCommunityNote", "create community note", scopes(ScopeTweetWrite)},
+	// Trends
+	{"TrendsByWOEID", "trends by woeid", nil},
+	// Media Upload
+	{"UploadMedia", "upload media", []string{ScopeMediaWrite}},
+	{"InitializeChunkedUpload", "initialize chunked upload", []string{ScopeMediaWrite}},
+	{"AppendChunkedUpload", "append chunked upload", []string{ScopeMediaWrite}},
+	{"FinalizeChunkedUpload", "finalize chunked upload", []string{ScopeMediaWrite}},
+	{"CheckUploadStatus", "check upload status", []string{ScopeMediaWrite}},
+}
+
+// scopesByOperation indexes scopeCatalog by operation name.
+var scopesByOperation = func() map[string]EndpointScopes {
+	m := make(map[string]EndpointScopes, len(scopeCatalog))
+	for _, e := range scopeCatalog {
+		m[e.Operation] = e
+	}
+	return m
+}()
+
+// ScopeCatalog returns the scopes required by every method of Twtr calling the API.
+func ScopeCatalog() []EndpointScopes {
+	c := make([]EndpointScopes, len(scopeCatalog))
+	for i, e := range scopeCatalog {
+		e.Scopes = append([]string(nil), e.Scopes...)
+		c[i] = e
+	}
+	return c
+}
+
+// RequiredScopes returns the scopes the method of Twtr requires, e.g. RequiredScopes("PostTweet").
+// It reports false for an unknown method.
+func RequiredScopes(method string) ([]string, bool) {
+	for _, e := range scopeCatalog {
+		if e.Method == method {
+			return append([]string(nil), e.Scopes...), true
+		}
+	}
+	return nil, false
+}
+
+// ScopesFor returns the sorted union of the scopes required by the methods of Twtr,
+// e.g. to fill OAuth2Config.Scopes. Add ScopeOfflineAccess to be issued a refresh token.
+func ScopesFor(methods ...string) []string {
+	set := make(map[string]struct{})
+	for _, m := range methods {
+		s, _ := RequiredScopes(m)
+		for _, scope := range s {
+			set[scope] = struct{}{}
+		}
+	}
+	scopes := make([]string, 0, len(set))
+	for s := range set {
+		scopes = append(scopes, s)
+	}
+	sort.Strings(scopes)
+	return scopes
+}
+
+// ErrMissingScope is matched by a ScopeError with errors.Is.
+var ErrMissingScope = errors.New("gotwtr: missing scope")
+
+// ScopeError is returned before sending a request whose token was not granted the scopes the method requires.
+type ScopeError struct {
+	Method    string
+	Operation string
+	Missing   []string
+}
+
+func (e *ScopeError) Error() string {
+	return "gotwtr: " + e.Method + " requires scope " + strings.Join(e.Missing, ", ") + ", which the token was not granted"
+}
+
+func (e *ScopeError) Is(target error) bool {
+	return target == ErrMissingScope
+}
+
+// checkScopes fails fast if the token was granted scopes and they lack one the operation requires.
+// Tokens with unknown scopes, such as app-only bearer tokens, are not checked.
+func checkScopes(operation string, tok *OAuth2Token) error {
+	if tok == nil || tok.Scope == "" {
+		return nil
+	}
+	e, ok := scopesByOperation[operation]
+	if !ok {
+		return nil
+	}
+	granted := make(map[string]struct{})
+	for _, s := range tok.Scopes() {
+		granted[s] = struct{}{}
+	}
+	var missing []string
+	for _, s := range e.Scopes {
+		if _, ok := granted[s]; !ok {
+			missing = append(missing, s)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	return &ScopeError{
+		Method:    e.Method,
+		Operation: operation,
+		Missing:   missing,
+	}
+}
@@ -0,0 +1,236 @@
+package gotwtr_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sivchari/gotwtr"
+)
+
+func Test_ScopeCatalog(t *testing.T) {
+	t.Parallel()
+	// Methods of Twtr not calling the API, or not taking a user-context token.
+	skip := map[string]bool{
+		"GenerateAppOnlyBearerToken": true,
+		"InvalidateToken":            true,
+		"CalculateEngagementRate":    true,
+		"CalculateEngagementMetrics": true,
+		"GetTopPerformingTweets":     true,
+		"CompareMetrics":             true,
+	}
+	catalog := make(map[string]bool)
+	for _, e := range gotwtr.ScopeCatalog() {
+		if catalog[e.Method] {
+			t.Errorf("%s is listed twice", e.Method)
+		}
+		catalog[e.Method] = true
+	}
+	twtr := reflect.TypeOf((*gotwtr.Twtr)(nil)).Elem()
+	for i := 0; i < twtr.NumMethod(); i++ {
+		m := twtr.Method(i).Name
+		if !skip[m] && !catalog[m] {
+			t.Errorf("%s is missing from the scope catalog", m)
+		}
+	}
+}
+
+func Test_ScopesFor(t *testing.T) {
+	t.Parallel()
+	got := gotwtr.ScopesFor("PostTweet", "LookupUserBookmarks", "Me", "Unknown")
+	want := []string{"bookmark.read", "tweet.read", "tweet.write", "users.read"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ScopesFor() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func Test_checkScopes(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name        string
+		scope       string
+		wantMissing []string
+		wantSent    bool
+	}{
+		{
+			name:     "granted",
+			scope:    "tweet.read tweet.write users.read",
+			wantSent: true,
+		},
+		{
+			name:        "missing tweet.write",
+			scope:       "tweet.read users.read offline.access",
+			wantMissing: []string{"tweet.write"},
+			wantSent:    false,
+		},
+		{
+			name:     "unknown scopes",
+			scope:    "",
+			wantSent: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			sent := false
+			client := mockHTTPClient(func(req *http.Request) *http.Response {
+				sent = true
+				return &http.Response{
+					StatusCode: http.StatusCreated,
+					Body:       io.NopCloser(strings.NewReader(`{"data":{"id":"1","text":"hello"}}`)),
+				}
+			})
+			c := gotwtr.New("",
+				gotwtr.WithHTTPClient(client),
+				gotwtr.WithTokenSource(gotwtr.StaticTokenSource(&gotwtr.OAuth2Token{AccessToken: "access", Scope: tt.scope})),
+			)
+			_, err := c.PostTweet(context.Background(), &gotwtr.PostTweetOption{Text: "hello"})
+			if sent != tt.wantSent {
+				t.Errorf("sent = %v, want %v", sent, tt.wantSent)
+			}
+			if tt.wantMissing == nil {
+				if err != nil {
+					t.Errorf("PostTweet() error = %v", err)
+				}
+				return
+			}
+			var serr *gotwtr.ScopeError
+			if !errors.As(err, &serr) || !errors.Is(err, gotwtr.ErrMissingScope) {
+				t.Fatalf("PostTweet() error = %v, want *ScopeError", err)
+			}
+			if diff := cmp.Diff(tt.wantMissing, serr.Missing); diff != "" {
+				t.Errorf("Missing mismatch (-want +got):\n%s", diff)
+			}
+			if serr.Method != "PostTweet" || !strings.Contains(err.Error(), "tweet.write") {
+				t.Errorf("PostTweet() error = %v", err)
+			}
+		})
+	}
+}
+
+// catalogArg returns an argument of type typ for a catalog method: a context, a reader, or a value with every field set.
+func catalogArg(ctx context.Context, typ reflect.Type, depth int) reflect.Value {
+	switch {
+	case typ == reflect.TypeOf((*context.Context)(nil)).Elem():
+		return reflect.ValueOf(ctx)
+	case typ == reflect.TypeOf((*io.Reader)(nil)).Elem():
+		return reflect.ValueOf(strings.NewReader("x"))
+	}
+	v := reflect.New(typ).Elem()
+	if depth > 6 {
+		return v
+	}
+	switch typ.Kind() {
+	case reflect.String:
+		v.SetString("1")
+	case reflect.Int, reflect.Int64:
+		v.SetInt(10)
+	case reflect.Slice:
+		v = reflect.MakeSlice(typ, 1, 1)
+		v.Index(0).Set(catalogArg(ctx, typ.Elem(), depth+1))
+	case reflect.Ptr:
+		v = reflect.New(typ.Elem())
+		v.Elem().Set(catalogArg(ctx, typ.Elem(), depth+1))
+	case reflect.Struct:
+		for i := 0; i < typ.NumField(); i++ {
+			if f := v.Field(i); f.CanSet() {
+				f.Set(catalogArg(ctx, typ.Field(i).Type, depth+1))
+			}
+		}
+	}
+	return v
+}
+
+// Test_ScopeCatalog_operations checks that the Operation of every catalog entry is the one the method reports,
+// in HTTPError.APIName and as the key of RateLimits.
+func Test_ScopeCatalog_operations(t *testing.T) {
+	t.Parallel()
+	for _, e := range gotwtr.ScopeCatalog() {
+		e := e
+		t.Run(e.Method, func(t *testing.T) {
+			t.Parallel()
+			client := mockHTTPClient(func(req *http.Request) *http.Response {
+				h := http.Header{}
+				h.Set("Content-Type", "application/json")
+				h.Set("x-rate-limit-limit", "450")
+				h.Set("x-rate-limit-remaining", "449")
+				h.Set("x-rate-limit-reset", "1700000000")
+				return &http.Response{
+					StatusCode: http.StatusNotFound,
+					Header:     h,
+					Body:       io.NopCloser(strings.NewReader(`{"title":"Not Found Error","type":"about:blank","status":404}`)),
+				}
+			})
+			c := gotwtr.New("key",
+				gotwtr.WithHTTPClient(client),
+				gotwtr.WithConsumerKey("key"),
+				gotwtr.WithConsumerSecret("secret"),
+				gotwtr.WithReconnectPolicy(gotwtr.ReconnectPolicy{MaxAttempts: -1}),
+			)
+			m := reflect.ValueOf(c).MethodByName(e.Method)
+			if !m.IsValid() {
+				t.Fatalf("%s is not a method of Client", e.Method)
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			typ := m.Type()
+			args := make([]reflect.Value, typ.NumIn())
+			var errCh chan error
+			for i := range args {
+				switch in := typ.In(i); {
+				case in == reflect.TypeOf((chan<- error)(nil)):
+					errCh = make(chan error, 1)
+					args[i] = reflect.ValueOf((chan<- error)(errCh))
+				case in.Kind() == reflect.Chan:
+					args[i] = reflect.MakeChan(reflect.ChanOf(reflect.BothDir, in.Elem()), 1).Convert(in)
+				default:
+					// Options are passed as a single option with every field set.
+					args[i] = catalogArg(ctx, in, 0)
+				}
+			}
+			var out []reflect.Value
+			if typ.IsVariadic() {
+				out = m.CallSlice(args)
+			} else {
+				out = m.Call(args)
+			}
+
+			var err error
+			if errCh != nil {
+				// A stream reports its error to errCh.
+				select {
+				case err = <-errCh:
+				case <-ctx.Done():
+					t.Fatal("the stream reported no error")
+				}
+				if s, ok := out[0].Interface().(interface{ Stop() }); ok && !out[0].IsNil() {
+					s.Stop()
+				}
+			} else if last := out[len(out)-1]; !last.IsNil() {
+				err, _ = last.Interface().(error)
+			}
+			var herr *gotwtr.HTTPError
+			if !errors.As(err, &herr) {
+				t.Fatalf("%s() error = %v, want *HTTPError", e.Method, err)
+			}
+			if herr.APIName != e.Operation {
+				t.Errorf("HTTPError.APIName = %q, catalog Operation = %q", herr.APIName, e.Operation)
+			}
+			var keys []string
+			for k := range c.RateLimits() {
+				keys = append(keys, k)
+			}
+			if diff := cmp.Diff([]string{e.Operation}, keys); diff != "" {
+				t.Errorf("RateLimits() keys mismatch (-catalog +got):\n%s", diff)
+			}
+		})
+	}
+}
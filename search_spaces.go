@@ -2,7 +2,6 @@ package gotwtr
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
@@ -13,11 +12,10 @@ func searchSpaces(ctx context.Context, c *client, searchTerm string, opt ...*Sea
 		return nil, errors.New("search spaces: searchTerm parameter is required")
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, searchSpacesURL, nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+searchSpacesURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("search spaces new request with ctx: %w", err)
 	}
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.bearerToken))
 
 	var sopt SearchSpacesOption
 	switch len(opt) {
@@ -30,23 +28,5 @@ func searchSpaces(ctx context.Context, c *client, searchTerm string, opt ...*Sea
 	}
 	sopt.addQuery(req, searchTerm)
 
-	resp, err := c.client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("search spaces: %w", err)
-	}
-	defer func() { _ = resp.Body.Close() }()
-
-	var ssr SearchSpacesResponse
-	if err := json.NewDecoder(resp.Body).Decode(&ssr); err != nil {
-		return nil, fmt.Errorf("search spaces: %w", err)
-	}
-	if resp.StatusCode != http.StatusOK {
-		return nil, &HTTPError{
-			APIName: "search spaces",
-			Status:  resp.Status,
-			URL:     req.URL.String(),
-		}
-	}
-
-	return &ssr, nil
+	return doJSON[SearchSpacesResponse](c, req, "search spaces", http.StatusOK)
 }
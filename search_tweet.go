@@ -2,7 +2,6 @@ package gotwtr
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
@@ -16,11 +15,10 @@ func searchRecentTweets(ctx context.Context, c *client, tweet string, opt ...*Se
 		return nil, errors.New("search recent tweets: tweet parameter must be less than or equal to 512 characters")
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, searchRecentTweetsURL, nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+searchRecentTweetsURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("search recent tweets new request with ctx: %w", err)
 	}
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.bearerToken))
 
 	var sopt SearchTweetsOption
 	switch len(opt) {
@@ -44,25 +42,7 @@ func searchRecentTweets(ctx context.Context, c *client, tweet string, opt ...*Se
 	}
 	sopt.addQuery(req, tweet)
 
-	resp, err := c.client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("search recent tweets: %w", err)
-	}
-	defer func() { _ = resp.Body.Close() }()
-
-	var str SearchTweetsResponse
-	if err := json.NewDecoder(resp.Body).Decode(&str); err != nil {
-		return nil, fmt.Errorf("search recent tweets: %w", err)
-	}
-	if resp.StatusCode != http.StatusOK {
-		return &str, &HTTPError{
-			APIName: "search recent tweets",
-			Status:  resp.Status,
-			URL:     req.URL.String(),
-		}
-	}
-
-	return &str, nil
+	return doJSON[SearchTweetsResponse](c, req, "search recent tweets", http.StatusOK)
 }
 
 func searchAllTweets(ctx context.Context, c *client, tweet string, opt ...*SearchTweetsOption) (*SearchTweetsResponse, error) {
@@ -73,11 +53,10 @@ func searchAllTweets(ctx context.Context, c *client, tweet string, opt ...*Searc
 		return nil, errors.New("search all tweets: tweet parameter must be less than or equal to 512 characters")
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, searchAllTweetsURL, nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+searchAllTweetsURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("search all tweets new request with ctx: %w", err)
 	}
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.bearerToken))
 
 	var sopt SearchTweetsOption
 	switch len(opt) {
@@ -101,23 +80,5 @@ func searchAllTweets(ctx context.Context, c *client, tweet string, opt ...*Searc
 	}
 	sopt.addQuery(req, tweet)
 
-	resp, err := c.client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("search all tweets: %w", err)
-	}
-	defer func() { _ = resp.Body.Close() }()
-
-	var str SearchTweetsResponse
-	if err := json.NewDecoder(resp.Body).Decode(&str); err != nil {
-		return nil, fmt.Errorf("search all tweets: %w", err)
-	}
-	if resp.StatusCode != http.StatusOK {
-		return &str, &HTTPError{
-			APIName: "search all tweets",
-			Status:  resp.Status,
-			URL:     req.URL.String(),
-		}
-	}
-
-	return &str, nil
+	return doJSON[SearchTweetsResponse](c, req, "search all tweets", http.StatusOK)
 }
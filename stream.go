@@ -0,0 +1,486 @@
+package gotwtr
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"sync/atomic"
+	"time"
+)
+
+// ErrStreamStalled is the error of a stream connection that received no data, keep-alives included,
+// within the stall timeout of the client.
+var ErrStreamStalled = errors.New("gotwtr: stream stalled")
+
+const (
+	defaultReconnectNetworkBackoff    = 250 * time.Millisecond
+	defaultReconnectMaxNetworkBackoff = 16 * time.Second
+	defaultReconnectHTTPBackoff       = 5 * time.Second
+	defaultReconnectMaxHTTPBackoff    = 320 * time.Second
+	defaultReconnectRateLimitBackoff  = time.Minute
+	defaultStreamStallTimeout         = 90 * time.Second
+)
+
+// ReconnectPolicy configures how ConnectToStream and VolumeStreams reconnect after a disconnect.
+// The defaults follow the schedule recommended by X: the delay grows linearly after network errors,
+// exponentially after 5xx responses, and exponentially from a minute after 429 responses.
+// Any other unsuccessful response, such as 401 or 403, ends the stream.
+type ReconnectPolicy struct {
+	// MaxAttempts is the maximum number of consecutive reconnect attempts.
+	// Zero reconnects forever and a negative value disables reconnecting.
+	MaxAttempts int
+	// NetworkBackoff is added to the delay after every consecutive network error. The default is 250ms.
+	NetworkBackoff time.Duration
+	// MaxNetworkBackoff caps the delay after network errors. The default is 16s.
+	MaxNetworkBackoff time.Duration
+	// HTTPBackoff is the first delay after a 5xx response, doubled on every attempt. The default is 5s.
+	HTTPBackoff time.Duration
+	// MaxHTTPBackoff caps the delay after 5xx and 429 responses. The default is 320s.
+	MaxHTTPBackoff time.Duration
+	// RateLimitBackoff is the first delay after a 429 response, doubled on every attempt. The default is 1m.
+	// The rate limit reset of the response takes precedence if it is later.
+	RateLimitBackoff time.Duration
+	// OnReconnect, if set, is called before every reconnect attempt and after every successful reconnect.
+	OnReconnect func(StreamReconnect)
+}
+
+// StreamReconnect reports a reconnect attempt of a stream, or its success if Connected is true.
+type StreamReconnect struct {
+	// Operation is the streaming operation, e.g. "connect to stream".
+	Operation string
+	// Attempt is the number of consecutive reconnect attempts, starting at 1.
+	Attempt int
+	// Delay is the delay before the attempt. It is zero once connected.
+	Delay time.Duration
+	// Err is the error that ended the previous connection or attempt.
+	Err error
+	// Connected reports whether the attempt succeeded.
+	Connected bool
+}
+
+// WithReconnectPolicy sets the reconnect policy of the streams of the client.
+// By default streams reconnect forever with the schedule described in ReconnectPolicy.
+func WithReconnectPolicy(p ReconnectPolicy) ClientOption {
+	return func(c *client) {
+		c.reconnectPolicy = p.withDefaults()
+	}
+}
+
+// WithStreamStallTimeout sets how long a stream connection may receive nothing before it is torn down and reconnected.
+// The API sends a keep-alive about every 20 seconds, so the default of 90s allows a few of them to be missed.
+// A negative value disables stall detection.
+func WithStreamStallTimeout(d time.Duration) ClientOption {
+	return func(c *client) {
+		c.stallTimeout = d
+	}
+}
+
+func (p ReconnectPolicy) withDefaults() *ReconnectPolicy {
+	if p.NetworkBackoff <= 0 {
+		p.NetworkBackoff = defaultReconnectNetworkBackoff
+	}
+	if p.MaxNetworkBackoff <= 0 {
+		p.MaxNetworkBackoff = defaultReconnectMaxNetworkBackoff
+	}
+	if p.HTTPBackoff <= 0 {
+		p.HTTPBackoff = defaultReconnectHTTPBackoff
+	}
+	if p.MaxHTTPBackoff <= 0 {
+		p.MaxHTTPBackoff = defaultReconnectMaxHTTPBackoff
+	}
+	if p.RateLimitBackoff <= 0 {
+		p.RateLimitBackoff = defaultReconnectRateLimitBackoff
+	}
+	return &p
+}
+
+// backoff returns the delay before the reconnect attempt after err,
+// or false if err ends the stream.
+func (p *ReconnectPolicy) backoff(attempt int, err error) (time.Duration, bool) {
+	if p.MaxAttempts < 0 || (p.MaxAttempts > 0 && attempt > p.MaxAttempts) {
+		return 0, false
+	}
+	var herr *HTTPError
+	if !errors.As(err, &herr) {
+		d := p.NetworkBackoff * time.Duration(attempt)
+		if d <= 0 || d > p.MaxNetworkBackoff {
+			d = p.MaxNetworkBackoff
+		}
+		return d, true
+	}
+	switch {
+	case herr.StatusCode == http.StatusTooManyRequests:
+		d := exponential(p.RateLimitBackoff, attempt, max(p.MaxHTTPBackoff, p.RateLimitBackoff))
+		var rerr *RateLimitError
+		if errors.As(err, &rerr) {
+			if reset := time.Until(rerr.ResetAt()); reset > d {
+				d = reset
+			}
+		}
+		return d, true
+	case herr.StatusCode >= http.StatusInternalServerError:
+		return exponential(p.HTTPBackoff, attempt, p.MaxHTTPBackoff), true
+	default:
+		return 0, false
+	}
+}
+
+func exponential(base time.Duration, attempt int, limit time.Duration) time.Duration {
+	d := base << (attempt - 1)
+	if d <= 0 || d > limit {
+		d = limit
+	}
+	return d
+}
+
+// stream keeps the streaming operation apiName connected until done is closed or the context of req is canceled.
+// read consumes the body of every connection and returns the error that ended it.
+// The time data was last received is stored to last in Unix nanoseconds.
+// An error that ends the stream is sent to errCh.
+func (c *client) stream(req *http.Request, apiName string, done <-chan struct{}, errCh chan<- error, last *atomic.Int64, read func(io.Reader) error) {
+	p := c.reconnectPolicy
+	if p == nil {
+		p = ReconnectPolicy{}.withDefaults()
+	}
+	ctx := req.Context()
+	attempt := 0
+	for {
+		err := c.connect(req, apiName, last, func() {
+			if attempt > 0 {
+				c.reconnected(ctx, p, StreamReconnect{Operation: apiName, Attempt: attempt, Connected: true})
+			}
+			attempt = 0
+		}, read)
+		if stopped(done) {
+			return
+		}
+		if ctx.Err() != nil {
+			sendErr(errCh, done, err)
+			return
+		}
+		attempt++
+		d, ok := p.backoff(attempt, err)
+		if !ok {
+			sendErr(errCh, done, err)
+			return
+		}
+		c.reconnected(ctx, p, StreamReconnect{Operation: apiName, Attempt: attempt, Delay: d, Err: err})
+		if sleep(ctx, d) != nil {
+			if !stopped(done) {
+				sendErr(errCh, done, ctx.Err())
+			}
+			return
+		}
+	}
+}
+
+// connect makes a single connection of the streaming operation apiName and reads it until it ends.
+// connected is called once the connection is established.
+// The connection is torn down with ErrStreamStalled if it receives nothing within the stall timeout of the client.
+func (c *client) connect(req *http.Request, apiName string, last *atomic.Int64, connected func(), read func(io.Reader) error) error {
+	ctx, cancel := context.WithCancelCause(req.Context())
+	defer cancel(nil)
+	r, err := rewind(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	resp, err := c.doStream(r, apiName)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return responseError(apiName, r, resp)
+	}
+	connected()
+	last.Store(time.Now().UnixNano())
+	timeout := c.stallTimeout
+	if timeout == 0 {
+		timeout = defaultStreamStallTimeout
+	}
+	if timeout > 0 {
+		go watchStall(ctx, cancel, last, timeout)
+	}
+	err = read(&activityReader{r: resp.Body, last: last})
+	switch {
+	case errors.Is(context.Cause(ctx), ErrStreamStalled):
+		return fmt.Errorf("%s: no data for %s: %w", apiName, timeout, ErrStreamStalled)
+	case err == nil || errors.Is(err, io.EOF):
+		err = io.ErrUnexpectedEOF
+	}
+	return fmt.Errorf("%s: connection closed: %w", apiName, err)
+}
+
+// watchStall cancels ctx with ErrStreamStalled once nothing was received for timeout since last.
+func watchStall(ctx context.Context, cancel context.CancelCauseFunc, last *atomic.Int64, timeout time.Duration) {
+	t := time.NewTimer(timeout)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			idle := time.Since(time.Unix(0, last.Load()))
+			if idle >= timeout {
+				cancel(ErrStreamStalled)
+				return
+			}
+			t.Reset(timeout - idle)
+		}
+	}
+}
+
+// activityReader stores the time data was last read from r.
+type activityReader struct {
+	r    io.Reader
+	last *atomic.Int64
+}
+
+func (r *activityReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	if n > 0 {
+		r.last.Store(time.Now().UnixNano())
+	}
+	return n, err
+}
+
+// lastActivity returns the time stored by stream, or the zero time if it never connected.
+func lastActivity(last *atomic.Int64) time.Time {
+	n := last.Load()
+	if n == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, n)
+}
+
+// reconnected reports a reconnect attempt, or its success, to the logger and the policy.
+func (c *client) reconnected(ctx context.Context, p *ReconnectPolicy, ev StreamReconnect) {
+	if c.logger != nil {
+		if ev.Connected {
+			c.logger.LogAttrs(ctx, slog.LevelInfo, "gotwtr stream reconnected",
+				slog.String("operation", ev.Operation),
+				slog.Int("attempt", ev.Attempt),
+			)
+		} else {
+			c.logger.LogAttrs(ctx, slog.LevelWarn, "gotwtr stream reconnecting",
+				slog.String("operation", ev.Operation),
+				slog.Int("attempt", ev.Attempt),
+				slog.Duration("delay", ev.Delay),
+				slog.String("error", c.redact(ev.Err.Error())),
+			)
+		}
+	}
+	if p.OnReconnect != nil {
+		p.OnReconnect(ev)
+	}
+}
+
+// sendErr sends err to errCh unless the stream is stopped first.
+func sendErr(errCh chan<- error, done <-chan struct{}, err error) {
+	select {
+	case errCh <- err:
+	case <-done:
+	}
+}
+
+// ErrStreamDisconnected is matched with errors.Is by a StreamError that announces the stream is being disconnected.
+var ErrStreamDisconnected = errors.New("gotwtr: stream disconnected")
+
+// StreamEventKind is the kind of a message received from a stream.
+type StreamEventKind int
+
+const (
+	// StreamKeepAlive is the blank line the API sends to keep an idle connection open.
+	StreamKeepAlive StreamEventKind = iota + 1
+	// StreamData is a message with data, sent to the channel of the stream.
+	StreamData
+	// StreamErrorMessage is an in-band message with only errors, sent to the error channel as *StreamError.
+	StreamErrorMessage
+	// StreamDisconnectMessage is an in-band message announcing the disconnect of the stream,
+	// sent to the error channel as *StreamError. The stream reconnects afterwards.
+	StreamDisconnectMessage
+	// StreamDecodeError is a message that could not be decoded. The stream reconnects afterwards.
+	StreamDecodeError
+)
+
+func (k StreamEventKind) String() string {
+	switch k {
+	case StreamKeepAlive:
+		return "keep-alive"
+	case StreamData:
+		return "data"
+	case StreamErrorMessage:
+		return "error"
+	case StreamDisconnectMessage:
+		return "disconnect"
+	case StreamDecodeError:
+		return "decode error"
+	default:
+		return "unknown"
+	}
+}
+
+// StreamEvent reports a message received from a stream.
+type StreamEvent struct {
+	// Operation is the streaming operation, e.g. "connect to stream".
+	Operation string
+	Kind      StreamEventKind
+	// Err is the *StreamError of an error or disconnect message, or the error of a message that could not be decoded.
+	Err error
+}
+
+// WithStreamEvents sets a function called with every message received by the streams of the client,
+// keep-alives included. It is called from the goroutine reading the stream and must not block.
+func WithStreamEvents(fn func(StreamEvent)) ClientOption {
+	return func(c *client) {
+		c.streamEvents = fn
+	}
+}
+
+// StreamError is an error message received in-band from a stream, e.g. an operational disconnect.
+// It matches ErrStreamDisconnected with errors.Is if it announces a disconnect,
+// and the ProblemKind of any of its errors.
+type StreamError struct {
+	APIName string
+	// Disconnect reports whether the message announces the disconnect of the stream.
+	Disconnect bool
+	Errors     []*APIResponseError
+}
+
+func (e *StreamError) Error() string {
+	msg := e.APIName + ": stream error"
+	if e.Disconnect {
+		msg = e.APIName + ": stream disconnected"
+	}
+	if len(e.Errors) > 0 {
+		switch ae := e.Errors[0]; {
+		case ae.Detail != "":
+			msg += ": " + ae.Detail
+		case ae.Title != "":
+			msg += ": " + ae.Title
+		case ae.Message != "":
+			msg += ": " + ae.Message
+		}
+	}
+	return msg
+}
+
+// Is reports whether target is ErrStreamDisconnected and e announces a disconnect,
+// or whether e reports a problem of the kind target if it is a ProblemKind.
+func (e *StreamError) Is(target error) bool {
+	if k, ok := target.(ProblemKind); ok {
+		for _, ae := range e.Errors {
+			if k != ProblemUnknown && ae.Kind() == k {
+				return true
+			}
+		}
+		return false
+	}
+	return target == ErrStreamDisconnected && e.Disconnect
+}
+
+// disconnect reports whether the errors of an in-band message announce the disconnect of the stream.
+func disconnect(errs []*APIResponseError) bool {
+	for _, e := range errs {
+		switch {
+		case e.DisconnectType != "",
+			e.Kind() == ProblemOperationalDisconnect,
+			e.Kind() == ProblemClientDisconnected,
+			e.Title == string(ProblemOperationalDisconnect):
+			return true
+		}
+	}
+	return false
+}
+
+// readStream reads the messages of a stream connection delimited by "\r\n" until it ends or done is closed.
+// A message may span several lines. A blank line between messages is a keep-alive.
+// Data is sent to ch and in-band errors to errCh. Messages with neither data nor errors are skipped.
+// A message that can not be decoded ends the connection, as does a disconnect message.
+func readStream[T any](c *client, apiName string, body io.Reader, done <-chan struct{}, ch chan<- T, errCh chan<- error) error {
+	br := bufio.NewReader(body)
+	var buf []byte
+	for {
+		line, err := br.ReadBytes('\n')
+		if len(buf) == 0 && len(bytes.TrimSpace(line)) == 0 {
+			if err != nil {
+				return err
+			}
+			c.streamEvent(StreamEvent{Operation: apiName, Kind: StreamKeepAlive})
+			continue
+		}
+		buf = append(buf, line...)
+		if err == nil && !complete(buf) {
+			continue
+		}
+		stop, derr := readMessage(c, apiName, bytes.TrimSpace(buf), done, ch, errCh)
+		if stop {
+			return derr
+		}
+		if err != nil {
+			return err
+		}
+		buf = buf[:0]
+	}
+}
+
+// complete reports whether b holds a whole JSON value, or a malformed one that no further line can complete.
+func complete(b []byte) bool {
+	var raw json.RawMessage
+	return !errors.Is(json.NewDecoder(bytes.NewReader(b)).Decode(&raw), io.ErrUnexpectedEOF)
+}
+
+// readMessage dispatches a single message of a stream, and reports whether it ends the connection with err.
+func readMessage[T any](c *client, apiName string, line []byte, done <-chan struct{}, ch chan<- T, errCh chan<- error) (bool, error) {
+	var msg struct {
+		Data   json.RawMessage     `json:"data"`
+		Errors []*APIResponseError `json:"errors"`
+	}
+	var v T
+	err := json.Unmarshal(line, &msg)
+	if err == nil && len(msg.Data) > 0 && !bytes.Equal(msg.Data, []byte("null")) {
+		err = json.Unmarshal(line, &v)
+	}
+	switch {
+	case err != nil:
+		err = fmt.Errorf("%s: decode message: %w", apiName, err)
+		c.streamEvent(StreamEvent{Operation: apiName, Kind: StreamDecodeError, Err: err})
+		sendErr(errCh, done, err)
+		return true, err
+	case len(msg.Data) > 0 && !bytes.Equal(msg.Data, []byte("null")):
+		c.streamEvent(StreamEvent{Operation: apiName, Kind: StreamData})
+		select {
+		case ch <- v:
+			return false, nil
+		case <-done:
+			return true, nil
+		}
+	case len(msg.Errors) > 0:
+		serr := &StreamError{
+			APIName:    apiName,
+			Disconnect: disconnect(msg.Errors),
+			Errors:     msg.Errors,
+		}
+		kind := StreamErrorMessage
+		if serr.Disconnect {
+			kind = StreamDisconnectMessage
+		}
+		c.streamEvent(StreamEvent{Operation: apiName, Kind: kind, Err: serr})
+		sendErr(errCh, done, serr)
+		return serr.Disconnect, serr
+	default:
+		return false, nil
+	}
+}
+
+func (c *client) streamEvent(ev StreamEvent) {
+	if c.streamEvents != nil {
+		c.streamEvents(ev)
+	}
+}
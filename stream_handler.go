@@ -0,0 +1,292 @@
+package gotwtr
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const defaultStreamBufferSize = 512
+
+// BackpressurePolicy decides what a StreamConsumer does with a message that arrives while its buffer is full.
+type BackpressurePolicy int
+
+const (
+	// BackpressureBlock makes the stream wait until the handler frees space in the buffer.
+	// The API disconnects a stream that is read too slowly, so a handler that falls behind for long loses the connection.
+	BackpressureBlock BackpressurePolicy = iota
+	// BackpressureDropOldest drops the oldest buffered message to make room for the new one.
+	BackpressureDropOldest
+	// BackpressureDropNewest drops the new message.
+	BackpressureDropNewest
+)
+
+// StreamHandlerOption configures a StreamConsumer. A nil option uses the defaults.
+type StreamHandlerOption struct {
+	// BufferSize is the number of messages buffered between the stream and the handler. The default is 512.
+	BufferSize int
+	// Backpressure is the policy applied when the buffer is full. The default is BackpressureBlock.
+	Backpressure BackpressurePolicy
+	// DrainTimeout is how long Stop lets the handler work through the buffered messages.
+	// The messages left afterwards are discarded and the context of the handler is canceled.
+	// Zero discards the buffered messages right away.
+	DrainTimeout time.Duration
+	// OnError, if set, is called with every error of the stream and every error returned by the handler.
+	// It is called from the goroutines of the consumer and must not block.
+	OnError func(error)
+}
+
+// StreamStats counts the messages of a StreamConsumer.
+type StreamStats struct {
+	// Received is the number of messages received from the stream.
+	Received uint64
+	// Handled is the number of messages passed to the handler, including the failed ones.
+	Handled uint64
+	// Failed is the number of messages the handler returned an error for.
+	Failed uint64
+	// DroppedOldest is the number of buffered messages dropped by BackpressureDropOldest.
+	DroppedOldest uint64
+	// DroppedNewest is the number of new messages dropped by BackpressureDropNewest.
+	DroppedNewest uint64
+	// Discarded is the number of messages discarded by Stop.
+	Discarded uint64
+}
+
+// Dropped returns the number of messages that never reached the handler.
+func (s StreamStats) Dropped() uint64 {
+	return s.DroppedOldest + s.DroppedNewest + s.Discarded
+}
+
+// streamConn is the connection of a stream consumed by a StreamConsumer.
+type streamConn interface {
+	Stop()
+	LastActivity() time.Time
+}
+
+// StreamConsumer passes the messages of a stream to a handler through a bounded buffer,
+// so that a slow handler neither stalls the stream beyond its backpressure policy nor blocks Stop beyond the drain timeout.
+type StreamConsumer[T any] struct {
+	handler func(context.Context, *T) error
+	opt     StreamHandlerOption
+	ctx     context.Context
+	cancel  context.CancelFunc
+	stream  streamConn
+	ch      chan T
+	errCh   chan error
+	done    chan struct{}
+	wg      sync.WaitGroup
+	once    sync.Once
+
+	mu       sync.Mutex
+	cond     *sync.Cond
+	buf      []T // ring buffer of n messages starting at head
+	head     int
+	n        int
+	stopping bool
+
+	received      atomic.Uint64
+	handled       atomic.Uint64
+	failed        atomic.Uint64
+	droppedOldest atomic.Uint64
+	droppedNewest atomic.Uint64
+	discarded     atomic.Uint64
+}
+
+// HandleConnectToStream connects to the filtered stream like ConnectToStream and calls handler with every message.
+func HandleConnectToStream(ctx context.Context, c Tweets, handler func(context.Context, *ConnectToStreamResponse) error, hopt *StreamHandlerOption, opt ...*ConnectToStreamOption) *StreamConsumer[ConnectToStreamResponse] {
+	return handleStream(ctx, handler, hopt, func(ch chan<- ConnectToStreamResponse, errCh chan<- error) streamConn {
+		if s := c.ConnectToStream(ctx, ch, errCh, opt...); s != nil {
+			return s
+		}
+		return nil
+	})
+}
+
+// HandleVolumeStreams connects to the 1% sampled stream like VolumeStreams and calls handler with every message.
+func HandleVolumeStreams(ctx context.Context, c Tweets, handler func(context.Context, *VolumeStreamsResponse) error, hopt *StreamHandlerOption, opt ...*VolumeStreamsOption) *StreamConsumer[VolumeStreamsResponse] {
+	return handleStream(ctx, handler, hopt, func(ch chan<- VolumeStreamsResponse, errCh chan<- error) streamConn {
+		if s := c.VolumeStreams(ctx, ch, errCh, opt...); s != nil {
+			return s
+		}
+		return nil
+	})
+}
+
+// HandleVolumeStreams10 connects to the 10% sampled stream like VolumeStreams10 and calls handler with every message.
+func HandleVolumeStreams10(ctx context.Context, c Tweets, handler func(context.Context, *VolumeStreamsResponse) error, hopt *StreamHandlerOption, opt ...*VolumeStreamsOption) *StreamConsumer[VolumeStreamsResponse] {
+	return handleStream(ctx, handler, hopt, func(ch chan<- VolumeStreamsResponse, errCh chan<- error) streamConn {
+		if s := c.VolumeStreams10(ctx, ch, errCh, opt...); s != nil {
+			return s
+		}
+		return nil
+	})
+}
+
+func handleStream[T any](ctx context.Context, handler func(context.Context, *T) error, hopt *StreamHandlerOption, connect func(chan<- T, chan<- error) streamConn) *StreamConsumer[T] {
+	var opt StreamHandlerOption
+	if hopt != nil {
+		opt = *hopt
+	}
+	if opt.BufferSize <= 0 {
+		opt.BufferSize = defaultStreamBufferSize
+	}
+	s := &StreamConsumer[T]{
+		handler: handler,
+		opt:     opt,
+		ch:      make(chan T),
+		errCh:   make(chan error),
+		done:    make(chan struct{}),
+		buf:     make([]T, opt.BufferSize),
+	}
+	s.ctx, s.cancel = context.WithCancel(ctx)
+	s.cond = sync.NewCond(&s.mu)
+	s.wg.Add(2)
+	go s.receive()
+	go s.work()
+	// The stream may report an invalid option to errCh before it returns, so it is connected once receive runs.
+	s.stream = connect(s.ch, s.errCh)
+	return s
+}
+
+// receive moves the messages of the stream into the buffer until the consumer is stopped.
+func (s *StreamConsumer[T]) receive() {
+	defer s.wg.Done()
+	for {
+		select {
+		case v := <-s.ch:
+			s.received.Add(1)
+			s.push(v)
+		case err := <-s.errCh:
+			s.reportError(err)
+		case <-s.done:
+			return
+		}
+	}
+}
+
+// push adds v to the buffer according to the backpressure policy.
+func (s *StreamConsumer[T]) push(v T) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for s.n == len(s.buf) && !s.stopping {
+		switch s.opt.Backpressure {
+		case BackpressureDropOldest:
+			s.pop()
+			s.droppedOldest.Add(1)
+		case BackpressureDropNewest:
+			s.droppedNewest.Add(1)
+			return
+		default:
+			s.cond.Wait()
+		}
+	}
+	if s.stopping {
+		s.discarded.Add(1)
+		return
+	}
+	s.buf[(s.head+s.n)%len(s.buf)] = v
+	s.n++
+	s.cond.Broadcast()
+}
+
+// pop removes the oldest message from the buffer. s.mu must be held.
+func (s *StreamConsumer[T]) pop() T {
+	var zero T
+	v := s.buf[s.head]
+	s.buf[s.head] = zero
+	s.head = (s.head + 1) % len(s.buf)
+	s.n--
+	return v
+}
+
+// work calls the handler with the buffered messages until the consumer is stopped and the buffer is empty.
+func (s *StreamConsumer[T]) work() {
+	defer s.wg.Done()
+	for {
+		s.mu.Lock()
+		for s.n == 0 && !s.stopping {
+			s.cond.Wait()
+		}
+		if s.n == 0 {
+			s.mu.Unlock()
+			return
+		}
+		v := s.pop()
+		s.cond.Broadcast()
+		s.mu.Unlock()
+
+		s.handled.Add(1)
+		if err := s.handler(s.ctx, &v); err != nil {
+			s.failed.Add(1)
+			s.reportError(err)
+		}
+	}
+}
+
+func (s *StreamConsumer[T]) reportError(err error) {
+	if s.opt.OnError != nil {
+		s.opt.OnError(err)
+	}
+}
+
+// Stop closes the stream and lets the handler work through the buffered messages within the drain timeout.
+// The messages left afterwards are discarded and the context passed to the handler is canceled.
+// Stop returns once the handler has returned, so the handler must return once its context is canceled.
+func (s *StreamConsumer[T]) Stop() {
+	s.once.Do(func() {
+		if s.stream != nil {
+			s.stream.Stop()
+		}
+		close(s.done)
+		s.mu.Lock()
+		s.stopping = true
+		s.cond.Broadcast()
+		s.mu.Unlock()
+
+		discard := func() {
+			s.mu.Lock()
+			for s.n > 0 {
+				s.pop()
+				s.discarded.Add(1)
+			}
+			s.mu.Unlock()
+			s.cancel()
+		}
+		if s.opt.DrainTimeout > 0 {
+			t := time.AfterFunc(s.opt.DrainTimeout, discard)
+			defer t.Stop()
+		} else {
+			discard()
+		}
+		s.wg.Wait()
+		s.cancel()
+	})
+}
+
+// Stats returns the message counters of the consumer.
+func (s *StreamConsumer[T]) Stats() StreamStats {
+	return StreamStats{
+		Received:      s.received.Load(),
+		Handled:       s.handled.Load(),
+		Failed:        s.failed.Load(),
+		DroppedOldest: s.droppedOldest.Load(),
+		DroppedNewest: s.droppedNewest.Load(),
+		Discarded:     s.discarded.Load(),
+	}
+}
+
+// Buffered returns the number of messages waiting for the handler.
+func (s *StreamConsumer[T]) Buffered() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.n
+}
+
+// LastActivity returns the time the stream last received data, keep-alives included.
+func (s *StreamConsumer[T]) LastActivity() time.Time {
+	if s.stream == nil {
+		return time.Time{}
+	}
+	return s.stream.LastActivity()
+}
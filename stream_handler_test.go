@@ -0,0 +1,200 @@
+package gotwtr_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sivchari/gotwtr"
+)
+
+// streamOf returns a client whose filtered stream sends a message for each ID and then stays open.
+// The messages after the first one are sent once next is closed.
+func streamOf(next <-chan struct{}, ids ...string) *gotwtr.Client {
+	var lines []string
+	for _, id := range ids {
+		lines = append(lines, fmt.Sprintf(`{"data":{"id":%q,"text":"hello"}}`+"\r\n", id))
+	}
+	return gotwtr.New("key", gotwtr.WithHTTPClient(mockHTTPClient(func(req *http.Request) *http.Response {
+		pr, pw := io.Pipe()
+		go func() {
+			for i, line := range lines {
+				if i == 1 {
+					<-next
+				}
+				if _, err := io.WriteString(pw, line); err != nil {
+					return
+				}
+			}
+			<-req.Context().Done()
+			_ = pw.CloseWithError(req.Context().Err())
+		}()
+		return &http.Response{StatusCode: http.StatusOK, Body: pr}
+	})), gotwtr.WithReconnectPolicy(gotwtr.ReconnectPolicy{MaxAttempts: -1}))
+}
+
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// handlerRecorder records the messages passed to a handler, which blocks until it is released.
+type handlerRecorder struct {
+	mu      sync.Mutex
+	ids     []string
+	release chan struct{}
+}
+
+func newHandlerRecorder() *handlerRecorder {
+	return &handlerRecorder{release: make(chan struct{})}
+}
+
+func (r *handlerRecorder) handle(ctx context.Context, resp *gotwtr.ConnectToStreamResponse) error {
+	r.mu.Lock()
+	r.ids = append(r.ids, resp.Tweet.ID)
+	r.mu.Unlock()
+	select {
+	case <-r.release:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (r *handlerRecorder) get() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.ids...)
+}
+
+func Test_HandleConnectToStream_backpressure(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		policy    gotwtr.BackpressurePolicy
+		wantIDs   []string
+		wantStats gotwtr.StreamStats
+	}{
+		{
+			name:      "drop newest",
+			policy:    gotwtr.BackpressureDropNewest,
+			wantIDs:   []string{"1", "2", "3"},
+			wantStats: gotwtr.StreamStats{Received: 5, Handled: 3, DroppedNewest: 2},
+		},
+		{
+			name:      "drop oldest",
+			policy:    gotwtr.BackpressureDropOldest,
+			wantIDs:   []string{"1", "4", "5"},
+			wantStats: gotwtr.StreamStats{Received: 5, Handled: 3, DroppedOldest: 2},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			rec := newHandlerRecorder()
+			next := make(chan struct{})
+			consumer := gotwtr.HandleConnectToStream(context.Background(), streamOf(next, "1", "2", "3", "4", "5"), rec.handle, &gotwtr.StreamHandlerOption{
+				BufferSize:   2,
+				Backpressure: tt.policy,
+				DrainTimeout: 5 * time.Second,
+			})
+			waitFor(t, "the first message", func() bool { return len(rec.get()) == 1 })
+			close(next)
+			waitFor(t, "the messages", func() bool { return consumer.Stats().Received == 5 })
+			if got := consumer.Buffered(); got != 2 {
+				t.Errorf("Buffered() = %d, want 2", got)
+			}
+			close(rec.release)
+			consumer.Stop()
+			if diff := cmp.Diff(tt.wantIDs, rec.get()); diff != "" {
+				t.Errorf("handled messages mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.wantStats, consumer.Stats()); diff != "" {
+				t.Errorf("Stats() mismatch (-want +got):\n%s", diff)
+			}
+			if got := consumer.Stats().Dropped(); got != 2 {
+				t.Errorf("Stats().Dropped() = %d, want 2", got)
+			}
+		})
+	}
+}
+
+func Test_HandleConnectToStream_stop(t *testing.T) {
+	t.Parallel()
+	rec := newHandlerRecorder()
+	next := make(chan struct{})
+	close(next)
+	var mu sync.Mutex
+	var errs []error
+	consumer := gotwtr.HandleConnectToStream(context.Background(), streamOf(next, "1", "2", "3", "4", "5"), rec.handle, &gotwtr.StreamHandlerOption{
+		BufferSize:   2,
+		DrainTimeout: 10 * time.Millisecond,
+		OnError: func(err error) {
+			mu.Lock()
+			defer mu.Unlock()
+			errs = append(errs, err)
+		},
+	})
+	// The handler holds the first message and the buffer the next two, so the stream is blocked.
+	waitFor(t, "a full buffer", func() bool { return consumer.Buffered() == 2 })
+
+	stopped := make(chan struct{})
+	go func() {
+		consumer.Stop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Stop() did not return with a blocked handler")
+	}
+	if diff := cmp.Diff([]string{"1"}, rec.get()); diff != "" {
+		t.Errorf("handled messages mismatch (-want +got):\n%s", diff)
+	}
+	stats := consumer.Stats()
+	if stats.Handled != 1 || stats.Failed != 1 || stats.Discarded != stats.Received-1 {
+		t.Errorf("Stats() = %+v, want 1 failed message and the rest discarded", stats)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if len(errs) != 1 || !errors.Is(errs[0], context.Canceled) {
+		t.Errorf("OnError() errors = %v, want the canceled handler", errs)
+	}
+}
+
+func Test_HandleVolumeStreams(t *testing.T) {
+	t.Parallel()
+	client := gotwtr.New("key", gotwtr.WithHTTPClient(mockHTTPClient(func(req *http.Request) *http.Response {
+		return &http.Response{StatusCode: http.StatusOK, Body: openBody(req, `{"data":{"id":"1","text":"a"}}`+"\r\n"+`{"data":{"id":"2","text":"b"}}`)}
+	})))
+	var mu sync.Mutex
+	var ids []string
+	consumer := gotwtr.HandleVolumeStreams(context.Background(), client, func(ctx context.Context, resp *gotwtr.VolumeStreamsResponse) error {
+		mu.Lock()
+		defer mu.Unlock()
+		ids = append(ids, resp.Tweet.ID)
+		return nil
+	}, nil)
+	waitFor(t, "the messages", func() bool { return consumer.Stats().Handled == 2 })
+	consumer.Stop()
+	if diff := cmp.Diff([]string{"1", "2"}, ids); diff != "" {
+		t.Errorf("handled messages mismatch (-want +got):\n%s", diff)
+	}
+	if consumer.LastActivity().IsZero() {
+		t.Error("LastActivity() is zero after the stream connected")
+	}
+}
@@ -0,0 +1,117 @@
+package gotwtr
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+)
+
+// StreamRouter dispatches the messages of the filtered stream to handlers registered per rule tag,
+// so that several consumers can share the single filtered stream connection of an app.
+// Its HandleMessage method is a handler for HandleConnectToStream.
+type StreamRouter struct {
+	mu       sync.RWMutex
+	routes   []*streamRoute
+	fallback func(context.Context, *ConnectToStreamResponse) error
+}
+
+type streamRoute struct {
+	tag     string
+	prefix  bool
+	handler func(context.Context, *ConnectToStreamResponse) error
+}
+
+func (r *streamRoute) match(tag string) bool {
+	if r.prefix {
+		return strings.HasPrefix(tag, r.tag)
+	}
+	return tag == r.tag
+}
+
+// NewStreamRouter returns a StreamRouter without handlers.
+func NewStreamRouter() *StreamRouter {
+	return &StreamRouter{}
+}
+
+// Handle registers handler for the messages matching a rule tagged tag.
+// Several handlers may be registered for the same tag, and each of them receives the message.
+func (r *StreamRouter) Handle(tag string, handler func(context.Context, *ConnectToStreamResponse) error) {
+	r.add(&streamRoute{tag: tag, handler: handler})
+}
+
+// HandlePrefix registers handler for the messages matching a rule whose tag starts with prefix, e.g. "team-a:".
+func (r *StreamRouter) HandlePrefix(prefix string, handler func(context.Context, *ConnectToStreamResponse) error) {
+	r.add(&streamRoute{tag: prefix, prefix: true, handler: handler})
+}
+
+// HandleFallback registers handler for the messages that match no registered tag.
+func (r *StreamRouter) HandleFallback(handler func(context.Context, *ConnectToStreamResponse) error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.fallback = handler
+}
+
+func (r *StreamRouter) add(route *streamRoute) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.routes = append(r.routes, route)
+}
+
+// HandleMessage calls every handler registered for a tag of the matching rules of resp concurrently,
+// and waits until all of them have returned. A handler is called once per message even if several of its rules matched,
+// and receives a shallow copy of resp whose MatchingRules holds only those rules. The Tweet and Includes are shared.
+// A message that matches no registered tag is passed to the fallback handler, if any.
+// The errors returned by the handlers are joined.
+func (r *StreamRouter) HandleMessage(ctx context.Context, resp *ConnectToStreamResponse) error {
+	r.mu.RLock()
+	routes := r.routes
+	fallback := r.fallback
+	r.mu.RUnlock()
+
+	type dispatch struct {
+		route *streamRoute
+		rules []*MatchingRule
+	}
+	var ds []*dispatch
+	for _, route := range routes {
+		var rules []*MatchingRule
+		for _, rule := range resp.MatchingRules {
+			if rule != nil && route.match(rule.Tag) {
+				rules = append(rules, rule)
+			}
+		}
+		if len(rules) > 0 {
+			ds = append(ds, &dispatch{route: route, rules: rules})
+		}
+	}
+	switch len(ds) {
+	case 0:
+		if fallback == nil {
+			return nil
+		}
+		return fallback(ctx, resp)
+	case 1:
+		return ds[0].route.handler(ctx, routed(resp, ds[0].rules))
+	}
+
+	errs := make([]error, len(ds))
+	var wg sync.WaitGroup
+	for i, d := range ds {
+		i, d := i, d
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = d.route.handler(ctx, routed(resp, d.rules))
+		}()
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+// routed returns a copy of resp matching only rules.
+func routed(resp *ConnectToStreamResponse, rules []*MatchingRule) *ConnectToStreamResponse {
+	v := *resp
+	v.MatchingRules = rules
+	return &v
+}
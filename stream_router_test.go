@@ -0,0 +1,142 @@
+package gotwtr_test
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sivchari/gotwtr"
+)
+
+func Test_StreamRouter_HandleMessage(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name  string
+		rules []*gotwtr.MatchingRule
+		want  map[string][]string
+	}{
+		{
+			name:  "exact tag",
+			rules: []*gotwtr.MatchingRule{{ID: "1", Tag: "cats"}},
+			want:  map[string][]string{"cats": {"1"}, "cats-audit": {"1"}},
+		},
+		{
+			name:  "tag prefix",
+			rules: []*gotwtr.MatchingRule{{ID: "2", Tag: "team-a:dogs"}, {ID: "3", Tag: "team-a:birds"}},
+			want:  map[string][]string{"team-a": {"2", "3"}},
+		},
+		{
+			name:  "fan-out",
+			rules: []*gotwtr.MatchingRule{{ID: "1", Tag: "cats"}, {ID: "2", Tag: "team-a:dogs"}, {ID: "4", Tag: "unknown"}},
+			want:  map[string][]string{"cats": {"1"}, "cats-audit": {"1"}, "team-a": {"2"}},
+		},
+		{
+			name:  "fallback",
+			rules: []*gotwtr.MatchingRule{{ID: "4", Tag: "unknown"}},
+			want:  map[string][]string{"fallback": {"4"}},
+		},
+		{
+			name: "no matching rules",
+			want: map[string][]string{"fallback": nil},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var mu sync.Mutex
+			got := make(map[string][]string)
+			record := func(name string) func(context.Context, *gotwtr.ConnectToStreamResponse) error {
+				return func(ctx context.Context, resp *gotwtr.ConnectToStreamResponse) error {
+					mu.Lock()
+					defer mu.Unlock()
+					var ids []string
+					for _, rule := range resp.MatchingRules {
+						ids = append(ids, rule.ID)
+					}
+					got[name] = ids
+					return nil
+				}
+			}
+			router := gotwtr.NewStreamRouter()
+			router.Handle("cats", record("cats"))
+			router.Handle("cats", record("cats-audit"))
+			router.HandlePrefix("team-a:", record("team-a"))
+			router.HandleFallback(record("fallback"))
+
+			resp := &gotwtr.ConnectToStreamResponse{Tweet: &gotwtr.Tweet{ID: "100"}, MatchingRules: tt.rules}
+			if err := router.HandleMessage(context.Background(), resp); err != nil {
+				t.Fatalf("StreamRouter.HandleMessage() error = %v", err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("StreamRouter.HandleMessage() mismatch (-want +got):\n%s", diff)
+			}
+			if len(resp.MatchingRules) != len(tt.rules) {
+				t.Errorf("StreamRouter.HandleMessage() modified the matching rules of the message")
+			}
+		})
+	}
+}
+
+func Test_StreamRouter_concurrent(t *testing.T) {
+	t.Parallel()
+	errA := errors.New("a failed")
+	errB := errors.New("b failed")
+	// Each handler waits for the other to start, which only succeeds if they run concurrently.
+	var started sync.WaitGroup
+	started.Add(2)
+	handler := func(err error) func(context.Context, *gotwtr.ConnectToStreamResponse) error {
+		return func(ctx context.Context, resp *gotwtr.ConnectToStreamResponse) error {
+			started.Done()
+			waited := make(chan struct{})
+			go func() {
+				started.Wait()
+				close(waited)
+			}()
+			select {
+			case <-waited:
+				return err
+			case <-time.After(5 * time.Second):
+				return errors.New("handlers did not run concurrently")
+			}
+		}
+	}
+	router := gotwtr.NewStreamRouter()
+	router.Handle("a", handler(errA))
+	router.Handle("b", handler(errB))
+
+	err := router.HandleMessage(context.Background(), &gotwtr.ConnectToStreamResponse{
+		Tweet:         &gotwtr.Tweet{ID: "1"},
+		MatchingRules: []*gotwtr.MatchingRule{{ID: "1", Tag: "a"}, {ID: "2", Tag: "b"}},
+	})
+	if !errors.Is(err, errA) || !errors.Is(err, errB) {
+		t.Errorf("StreamRouter.HandleMessage() error = %v, want both handler errors", err)
+	}
+}
+
+func Test_StreamRouter_withConsumer(t *testing.T) {
+	t.Parallel()
+	next := make(chan struct{})
+	close(next)
+	var mu sync.Mutex
+	var got []string
+	router := gotwtr.NewStreamRouter()
+	router.HandleFallback(func(ctx context.Context, resp *gotwtr.ConnectToStreamResponse) error {
+		mu.Lock()
+		defer mu.Unlock()
+		got = append(got, resp.Tweet.ID)
+		return nil
+	})
+	consumer := gotwtr.HandleConnectToStream(context.Background(), streamOf(next, "1", "2", "3"), router.HandleMessage, nil)
+	waitFor(t, "the messages", func() bool { return consumer.Stats().Handled == 3 })
+	consumer.Stop()
+	sort.Strings(got)
+	if diff := cmp.Diff([]string{"1", "2", "3"}, got); diff != "" {
+		t.Errorf("routed messages mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,518 @@
+package gotwtr_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	"github.com/sivchari/gotwtr"
+)
+
+// idleBody is the body of a stream connection that stays open until its request is canceled.
+type idleBody struct {
+	ctx context.Context
+}
+
+func (b idleBody) Read([]byte) (int, error) {
+	<-b.ctx.Done()
+	return 0, b.ctx.Err()
+}
+
+func (b idleBody) Close() error {
+	return nil
+}
+
+// openBody is the body of a stream connection that sends the message s and then stays open until req is canceled.
+func openBody(req *http.Request, s string) io.ReadCloser {
+	return io.NopCloser(io.MultiReader(strings.NewReader(s+"\r\n"), idleBody{ctx: req.Context()}))
+}
+
+// brokenBody is the body of a stream connection that sends body and then fails with a network error.
+type brokenBody struct {
+	r io.Reader
+}
+
+func (b brokenBody) Read(p []byte) (int, error) {
+	n, err := b.r.Read(p)
+	if err == io.EOF {
+		return n, io.ErrUnexpectedEOF
+	}
+	return n, err
+}
+
+func (b brokenBody) Close() error {
+	return nil
+}
+
+type reconnectRecorder struct {
+	mu     sync.Mutex
+	events []gotwtr.StreamReconnect
+}
+
+func (r *reconnectRecorder) record(ev gotwtr.StreamReconnect) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, ev)
+}
+
+func (r *reconnectRecorder) get() []gotwtr.StreamReconnect {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]gotwtr.StreamReconnect(nil), r.events...)
+}
+
+func Test_ConnectToStream_reconnect(t *testing.T) {
+	t.Parallel()
+	const body = `{"data":{"id":"1","text":"hello"}}`
+	tests := []struct {
+		name      string
+		responses []func(*http.Request) *http.Response
+		want      []gotwtr.StreamReconnect
+	}{
+		{
+			name: "503 on connect",
+			responses: []func(*http.Request) *http.Response{
+				func(*http.Request) *http.Response {
+					return &http.Response{
+						StatusCode: http.StatusServiceUnavailable,
+						Body:       io.NopCloser(strings.NewReader(`{"title":"Service Unavailable"}`)),
+					}
+				},
+				func(req *http.Request) *http.Response {
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Body:       openBody(req, body),
+					}
+				},
+			},
+			want: []gotwtr.StreamReconnect{
+				{Operation: "connect to stream", Attempt: 1, Delay: 2 * time.Millisecond},
+				{Operation: "connect to stream", Attempt: 1, Connected: true},
+			},
+		},
+		{
+			name: "429 on connect",
+			responses: []func(*http.Request) *http.Response{
+				func(*http.Request) *http.Response {
+					return &http.Response{
+						StatusCode: http.StatusTooManyRequests,
+						Body:       io.NopCloser(strings.NewReader(`{"title":"ConnectionException","connection_issue":"TooManyConnections"}`)),
+					}
+				},
+				func(*http.Request) *http.Response {
+					return &http.Response{
+						StatusCode: http.StatusTooManyRequests,
+						Body:       io.NopCloser(strings.NewReader(`{"title":"ConnectionException","connection_issue":"TooManyConnections"}`)),
+					}
+				},
+				func(req *http.Request) *http.Response {
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Body:       openBody(req, body),
+					}
+				},
+			},
+			want: []gotwtr.StreamReconnect{
+				{Operation: "connect to stream", Attempt: 1, Delay: 3 * time.Millisecond},
+				{Operation: "connect to stream", Attempt: 2, Delay: 6 * time.Millisecond},
+				{Operation: "connect to stream", Attempt: 2, Connected: true},
+			},
+		},
+		{
+			name: "connection dropped",
+			responses: []func(*http.Request) *http.Response{
+				func(*http.Request) *http.Response {
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Body:       brokenBody{r: strings.NewReader(`{"data":{"id":"0","text":"before"}}` + "\r\n")},
+					}
+				},
+				func(req *http.Request) *http.Response {
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Body:       openBody(req, body),
+					}
+				},
+			},
+			want: []gotwtr.StreamReconnect{
+				{Operation: "connect to stream", Attempt: 1, Delay: time.Millisecond},
+				{Operation: "connect to stream", Attempt: 1, Connected: true},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var calls atomic.Int32
+			client := mockHTTPClient(func(req *http.Request) *http.Response {
+				n := int(calls.Add(1))
+				if n > len(tt.responses) {
+					t.Errorf("unexpected connection %d", n)
+					return &http.Response{StatusCode: http.StatusOK, Body: idleBody{ctx: req.Context()}}
+				}
+				return tt.responses[n-1](req)
+			})
+			var rec reconnectRecorder
+			c := gotwtr.New("key", gotwtr.WithHTTPClient(client), gotwtr.WithReconnectPolicy(gotwtr.ReconnectPolicy{
+				NetworkBackoff:   time.Millisecond,
+				HTTPBackoff:      2 * time.Millisecond,
+				RateLimitBackoff: 3 * time.Millisecond,
+				OnReconnect:      rec.record,
+			}))
+			ch := make(chan gotwtr.ConnectToStreamResponse, 10)
+			errCh := make(chan error, 1)
+			stream := c.ConnectToStream(context.Background(), ch, errCh)
+			defer stream.Stop()
+			for {
+				select {
+				case got := <-ch:
+					if got.Tweet.ID != "1" {
+						continue
+					}
+					if diff := cmp.Diff(tt.want, rec.get(), cmpopts.IgnoreFields(gotwtr.StreamReconnect{}, "Err")); diff != "" {
+						t.Errorf("OnReconnect mismatch (-want +got):\n%s", diff)
+					}
+					for _, ev := range rec.get() {
+						if (ev.Err == nil) != ev.Connected {
+							t.Errorf("OnReconnect event %+v, want an error only for attempts", ev)
+						}
+					}
+					return
+				case err := <-errCh:
+					t.Fatalf("client.ConnectToStream() error = %v", err)
+				case <-time.After(5 * time.Second):
+					t.Fatal("client.ConnectToStream() did not reconnect")
+				}
+			}
+		})
+	}
+}
+
+func Test_ConnectToStream_reconnectEnds(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		status    int
+		policy    gotwtr.ReconnectPolicy
+		wantErr   error
+		wantCalls int32
+	}{
+		{
+			name:      "401 is not retried",
+			status:    http.StatusUnauthorized,
+			policy:    gotwtr.ReconnectPolicy{HTTPBackoff: time.Millisecond},
+			wantErr:   gotwtr.ErrUnauthorized,
+			wantCalls: 1,
+		},
+		{
+			name:      "attempts exhausted",
+			status:    http.StatusServiceUnavailable,
+			policy:    gotwtr.ReconnectPolicy{MaxAttempts: 2, HTTPBackoff: time.Millisecond},
+			wantErr:   gotwtr.ErrServerError,
+			wantCalls: 3,
+		},
+		{
+			name:      "reconnect disabled",
+			status:    http.StatusServiceUnavailable,
+			policy:    gotwtr.ReconnectPolicy{MaxAttempts: -1},
+			wantErr:   gotwtr.ErrServerError,
+			wantCalls: 1,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var calls atomic.Int32
+			client := mockHTTPClient(func(*http.Request) *http.Response {
+				calls.Add(1)
+				return &http.Response{
+					StatusCode: tt.status,
+					Body:       io.NopCloser(strings.NewReader(`{"title":"error"}`)),
+				}
+			})
+			c := gotwtr.New("key", gotwtr.WithHTTPClient(client), gotwtr.WithReconnectPolicy(tt.policy))
+			ch := make(chan gotwtr.ConnectToStreamResponse)
+			errCh := make(chan error)
+			stream := c.ConnectToStream(context.Background(), ch, errCh)
+			defer stream.Stop()
+			select {
+			case <-ch:
+				t.Fatal("client.ConnectToStream() sent a message")
+			case err := <-errCh:
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("client.ConnectToStream() error = %v, want %v", err, tt.wantErr)
+				}
+			case <-time.After(5 * time.Second):
+				t.Fatal("client.ConnectToStream() did not end")
+			}
+			if got := calls.Load(); got != tt.wantCalls {
+				t.Errorf("connections = %d, want %d", got, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func Test_VolumeStreams_stopWhileReconnecting(t *testing.T) {
+	t.Parallel()
+	connected := make(chan struct{}, 1)
+	client := mockHTTPClient(func(*http.Request) *http.Response {
+		connected <- struct{}{}
+		return &http.Response{
+			StatusCode: http.StatusServiceUnavailable,
+			Body:       io.NopCloser(strings.NewReader(`{"title":"Service Unavailable"}`)),
+		}
+	})
+	c := gotwtr.New("key", gotwtr.WithHTTPClient(client), gotwtr.WithReconnectPolicy(gotwtr.ReconnectPolicy{HTTPBackoff: time.Hour}))
+	ch := make(chan gotwtr.VolumeStreamsResponse)
+	errCh := make(chan error)
+	stream := c.VolumeStreams(context.Background(), ch, errCh)
+	<-connected
+
+	stopped := make(chan struct{})
+	go func() {
+		stream.Stop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case err := <-errCh:
+		t.Fatalf("client.VolumeStreams() error = %v after Stop", err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("Stop() did not return while waiting to reconnect")
+	}
+}
+
+// keepAliveBody is the body of a stream connection that sends a keep-alive every interval until req is canceled.
+func keepAliveBody(req *http.Request, interval time.Duration) io.ReadCloser {
+	pr, pw := io.Pipe()
+	go func() {
+		t := time.NewTicker(interval)
+		defer t.Stop()
+		for {
+			select {
+			case <-req.Context().Done():
+				_ = pw.CloseWithError(req.Context().Err())
+				return
+			case <-t.C:
+				if _, err := io.WriteString(pw, "\r\n"); err != nil {
+					return
+				}
+			}
+		}
+	}()
+	return pr
+}
+
+func Test_ConnectToStream_stall(t *testing.T) {
+	t.Parallel()
+	var calls atomic.Int32
+	client := mockHTTPClient(func(req *http.Request) *http.Response {
+		if calls.Add(1) == 1 {
+			return &http.Response{StatusCode: http.StatusOK, Body: openBody(req, `{"data":{"id":"0","text":"before"}}`)}
+		}
+		return &http.Response{StatusCode: http.StatusOK, Body: openBody(req, `{"data":{"id":"1","text":"after"}}`)}
+	})
+	var rec reconnectRecorder
+	c := gotwtr.New("key", gotwtr.WithHTTPClient(client), gotwtr.WithStreamStallTimeout(50*time.Millisecond), gotwtr.WithReconnectPolicy(gotwtr.ReconnectPolicy{
+		NetworkBackoff: time.Millisecond,
+		OnReconnect:    rec.record,
+	}))
+	ch := make(chan gotwtr.ConnectToStreamResponse, 10)
+	errCh := make(chan error, 1)
+	start := time.Now()
+	stream := c.ConnectToStream(context.Background(), ch, errCh)
+	defer stream.Stop()
+	for {
+		select {
+		case got := <-ch:
+			if got.Tweet.ID != "1" {
+				continue
+			}
+			events := rec.get()
+			if len(events) == 0 || !errors.Is(events[0].Err, gotwtr.ErrStreamStalled) {
+				t.Errorf("OnReconnect events = %+v, want a reconnect after ErrStreamStalled", events)
+			}
+			if last := stream.LastActivity(); last.Before(start) {
+				t.Errorf("LastActivity() = %v, want after %v", last, start)
+			}
+			return
+		case err := <-errCh:
+			t.Fatalf("client.ConnectToStream() error = %v", err)
+		case <-time.After(5 * time.Second):
+			t.Fatal("client.ConnectToStream() did not reconnect the stalled stream")
+		}
+	}
+}
+
+func Test_VolumeStreams_keepAlive(t *testing.T) {
+	t.Parallel()
+	var calls atomic.Int32
+	client := mockHTTPClient(func(req *http.Request) *http.Response {
+		calls.Add(1)
+		return &http.Response{StatusCode: http.StatusOK, Body: keepAliveBody(req, 10*time.Millisecond)}
+	})
+	c := gotwtr.New("key", gotwtr.WithHTTPClient(client), gotwtr.WithStreamStallTimeout(100*time.Millisecond))
+	ch := make(chan gotwtr.VolumeStreamsResponse)
+	errCh := make(chan error, 1)
+	stream := c.VolumeStreams(context.Background(), ch, errCh)
+	time.Sleep(300 * time.Millisecond)
+	if idle := time.Since(stream.LastActivity()); idle > 100*time.Millisecond {
+		t.Errorf("LastActivity() is %s ago, want the last keep-alive", idle)
+	}
+	stream.Stop()
+	if got := calls.Load(); got != 1 {
+		t.Errorf("connections = %d, want 1", got)
+	}
+}
+
+type eventRecorder struct {
+	mu    sync.Mutex
+	kinds []gotwtr.StreamEventKind
+}
+
+func (r *eventRecorder) record(ev gotwtr.StreamEvent) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.kinds = append(r.kinds, ev.Kind)
+}
+
+func (r *eventRecorder) get() []gotwtr.StreamEventKind {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]gotwtr.StreamEventKind(nil), r.kinds...)
+}
+
+func Test_ConnectToStream_messages(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name       string
+		body       string
+		wantIDs    []string
+		wantErrs   []error
+		wantKinds  []gotwtr.StreamEventKind
+		wantReconn bool
+	}{
+		{
+			name:    "keep-alives and data",
+			body:    "\r\n" + `{"data":{"id":"1","text":"a"}}` + "\r\n\r\n\r\n" + `{"data":{"id":"2","text":"b"},"matching_rules":[{"id":"10","tag":"t"}]}`,
+			wantIDs: []string{"1", "2"},
+			wantKinds: []gotwtr.StreamEventKind{
+				gotwtr.StreamKeepAlive, gotwtr.StreamData, gotwtr.StreamKeepAlive, gotwtr.StreamKeepAlive, gotwtr.StreamData,
+			},
+		},
+		{
+			name:     "error message",
+			body:     `{"errors":[{"title":"Invalid Request","detail":"One or more parameters to your request was invalid.","type":"https://api.twitter.com/2/problems/invalid-request"}]}` + "\r\n" + `{"data":{"id":"1","text":"a"}}`,
+			wantIDs:  []string{"1"},
+			wantErrs: []error{gotwtr.ProblemInvalidRequest},
+			wantKinds: []gotwtr.StreamEventKind{
+				gotwtr.StreamErrorMessage, gotwtr.StreamData,
+			},
+		},
+		{
+			name:     "operational disconnect",
+			body:     `{"data":{"id":"1","text":"a"}}` + "\r\n" + `{"errors":[{"title":"operational-disconnect","disconnect_type":"UpstreamOperationalDisconnect","detail":"This stream has been disconnected upstream for operational reasons.","type":"https://api.twitter.com/2/problems/operational-disconnect"}]}`,
+			wantIDs:  []string{"1"},
+			wantErrs: []error{gotwtr.ErrStreamDisconnected},
+			wantKinds: []gotwtr.StreamEventKind{
+				gotwtr.StreamData, gotwtr.StreamDisconnectMessage,
+			},
+			wantReconn: true,
+		},
+		{
+			name:     "decode error",
+			body:     `{"data":{"id":"1","text":"a"}}` + "\r\n" + `{"data":{"id":2}}` + "\r\n" + `{"data":{"id":"3","text":"c"}}`,
+			wantIDs:  []string{"1"},
+			wantErrs: []error{new(json.UnmarshalTypeError)},
+			wantKinds: []gotwtr.StreamEventKind{
+				gotwtr.StreamData, gotwtr.StreamDecodeError,
+			},
+			wantReconn: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var calls atomic.Int32
+			client := mockHTTPClient(func(req *http.Request) *http.Response {
+				if calls.Add(1) == 1 {
+					return &http.Response{StatusCode: http.StatusOK, Body: openBody(req, tt.body)}
+				}
+				return &http.Response{StatusCode: http.StatusOK, Body: idleBody{ctx: req.Context()}}
+			})
+			var events eventRecorder
+			reconnected := make(chan struct{})
+			var once sync.Once
+			c := gotwtr.New("key", gotwtr.WithHTTPClient(client), gotwtr.WithStreamEvents(events.record), gotwtr.WithReconnectPolicy(gotwtr.ReconnectPolicy{
+				NetworkBackoff: time.Millisecond,
+				OnReconnect: func(ev gotwtr.StreamReconnect) {
+					if ev.Connected {
+						once.Do(func() { close(reconnected) })
+					}
+				},
+			}))
+			ch := make(chan gotwtr.ConnectToStreamResponse, 10)
+			errCh := make(chan error, 10)
+			stream := c.ConnectToStream(context.Background(), ch, errCh)
+			defer stream.Stop()
+
+			var gotIDs []string
+			var gotErrs []error
+			timeout := time.After(5 * time.Second)
+			for len(gotIDs) < len(tt.wantIDs) || len(gotErrs) < len(tt.wantErrs) {
+				select {
+				case got := <-ch:
+					gotIDs = append(gotIDs, got.Tweet.ID)
+				case err := <-errCh:
+					gotErrs = append(gotErrs, err)
+				case <-timeout:
+					t.Fatalf("client.ConnectToStream() got ids %v and errors %v", gotIDs, gotErrs)
+				}
+			}
+			if tt.wantReconn {
+				select {
+				case <-reconnected:
+				case <-timeout:
+					t.Fatal("client.ConnectToStream() did not reconnect")
+				}
+			}
+			if diff := cmp.Diff(tt.wantIDs, gotIDs); diff != "" {
+				t.Errorf("client.ConnectToStream() messages mismatch (-want +got):\n%s", diff)
+			}
+			for i, want := range tt.wantErrs {
+				if target, ok := want.(*json.UnmarshalTypeError); ok {
+					if !errors.As(gotErrs[i], &target) {
+						t.Errorf("client.ConnectToStream() error = %v, want %T", gotErrs[i], want)
+					}
+					continue
+				}
+				if !errors.Is(gotErrs[i], want) {
+					t.Errorf("client.ConnectToStream() error = %v, want %v", gotErrs[i], want)
+				}
+			}
+			if diff := cmp.Diff(tt.wantKinds, events.get()); diff != "" {
+				t.Errorf("stream events mismatch (-want +got):\n%s", diff)
+			}
+			select {
+			case got := <-ch:
+				t.Errorf("client.ConnectToStream() sent an extra message %+v", got)
+			case err := <-errCh:
+				t.Errorf("client.ConnectToStream() sent an extra error %v", err)
+			default:
+			}
+		})
+	}
+}
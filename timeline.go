@@ -2,7 +2,6 @@ package gotwtr
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
@@ -12,13 +11,12 @@ func userTweetTimeline(ctx context.Context, c *client, userID string, opt ...*Us
 	if userID == "" {
 		return nil, errors.New("user tweet timeline: id parameter is required")
 	}
-	ep := fmt.Sprintf(userTweetTimelineURL, userID)
+	ep := c.baseURL + fmt.Sprintf(userTweetTimelineURL, userID)
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ep, nil)
 	if err != nil {
 		return nil, fmt.Errorf("user tweet timeline new request with ctx: %w", err)
 	}
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.bearerToken))
 
 	var uopt UserTweetTimelineOption
 	switch len(opt) {
@@ -42,38 +40,19 @@ func userTweetTimeline(ctx context.Context, c *client, userID string, opt ...*Us
 	}
 	uopt.addQuery(req)
 
-	resp, err := c.client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("user tweet timeline response: %w", err)
-	}
-	defer func() { _ = resp.Body.Close() }()
-
-	var timelines UserTweetTimelineResponse
-	if err := json.NewDecoder(resp.Body).Decode(&timelines); err != nil {
-		return nil, fmt.Errorf("user tweet timeline decode: %w", err)
-	}
-	if resp.StatusCode != http.StatusOK {
-		return &timelines, &HTTPError{
-			APIName: "user tweet timeline",
-			Status:  resp.Status,
-			URL:     req.URL.String(),
-		}
-	}
-
-	return &timelines, nil
+	return doJSON[UserTweetTimelineResponse](c, req, "user tweet timeline", http.StatusOK)
 }
 
 func userMentionTimeline(ctx context.Context, c *client, userID string, opt ...*UserMentionTimelineOption) (*UserMentionTimelineResponse, error) {
 	if userID == "" {
 		return nil, errors.New("user mention timeline: userID parameter is required")
 	}
-	ep := fmt.Sprintf(userMentionTimelineURL, userID)
+	ep := c.baseURL + fmt.Sprintf(userMentionTimelineURL, userID)
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ep, nil)
 	if err != nil {
 		return nil, fmt.Errorf("user mention timeline new request with ctx: %w", err)
 	}
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.bearerToken))
 
 	var uopt UserMentionTimelineOption
 	switch len(opt) {
@@ -97,38 +76,19 @@ func userMentionTimeline(ctx context.Context, c *client, userID string, opt ...*
 	}
 	uopt.addQuery(req)
 
-	resp, err := c.client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("user mention timeline response: %w", err)
-	}
-	defer func() { _ = resp.Body.Close() }()
-
-	var timelines UserMentionTimelineResponse
-	if err := json.NewDecoder(resp.Body).Decode(&timelines); err != nil {
-		return nil, fmt.Errorf("user mention timeline decode: %w", err)
-	}
-	if resp.StatusCode != http.StatusOK {
-		return &timelines, &HTTPError{
-			APIName: "user mention timeline",
-			Status:  resp.Status,
-			URL:     req.URL.String(),
-		}
-	}
-
-	return &timelines, nil
+	return doJSON[UserMentionTimelineResponse](c, req, "user mention timeline", http.StatusOK)
 }
 
 func userReverseChronologicalTimeline(ctx context.Context, c *client, userID string, opt ...*UserReverseChronologicalTimelineOption) (*UserReverseChronologicalTimelineResponse, error) {
 	if userID == "" {
 		return nil, errors.New("user reverse chronological timeline: id parameter is required")
 	}
-	ep := fmt.Sprintf(userReverseChronologicalTimelineURL, userID)
+	ep := c.baseURL + fmt.Sprintf(userReverseChronologicalTimelineURL, userID)
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ep, nil)
 	if err != nil {
 		return nil, fmt.Errorf("user reverse chronological timeline new request with ctx: %w", err)
 	}
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.bearerToken))
 
 	var uopt UserReverseChronologicalTimelineOption
 	switch len(opt) {
@@ -152,23 +112,5 @@ func userReverseChronologicalTimeline(ctx context.Context, c *client, userID str
 	}
 	uopt.addQuery(req)
 
-	resp, err := c.client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("user reverse chronological timeline response: %w", err)
-	}
-	defer func() { _ = resp.Body.Close() }()
-
-	var timelines UserReverseChronologicalTimelineResponse
-	if err := json.NewDecoder(resp.Body).Decode(&timelines); err != nil {
-		return nil, fmt.Errorf("user reverse chronological timeline decode: %w", err)
-	}
-	if resp.StatusCode != http.StatusOK {
-		return &timelines, &HTTPError{
-			APIName: "user reverse chronological timeline",
-			Status:  resp.Status,
-			URL:     req.URL.String(),
-		}
-	}
-
-	return &timelines, nil
+	return doJSON[UserReverseChronologicalTimelineResponse](c, req, "user reverse chronological timeline", http.StatusOK)
 }
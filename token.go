@@ -0,0 +1,183 @@
+package gotwtr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// tokenExpiryDelta is how long before its expiry a token is refreshed.
+const tokenExpiryDelta = time.Minute
+
+// ErrTokenExpired is returned by a TokenSource whose token expired and can not be refreshed.
+var ErrTokenExpired = errors.New("gotwtr: token expired and has no refresh token")
+
+// TokenSource supplies the access token sent with each request of the client.
+// Implementations must be safe for concurrent use.
+type TokenSource interface {
+	Token(ctx context.Context) (*OAuth2Token, error)
+}
+
+// TokenInvalidator is implemented by a TokenSource able to replace a token the API rejected.
+// When a request is rejected with 401 Unauthorized, the client invalidates its token
+// and retries the request once if the TokenSource supplies another one.
+type TokenInvalidator interface {
+	Invalidate(tok *OAuth2Token)
+}
+
+// WithTokenSource sets the source of the access token sent with each request,
+// replacing the bearer token given to New.
+func WithTokenSource(ts TokenSource) ClientOption {
+	return func(c *client) {
+		c.tokenSource = ts
+	}
+}
+
+// StaticTokenSource returns a TokenSource that always supplies tok.
+func StaticTokenSource(tok *OAuth2Token) TokenSource {
+	return staticTokenSource{tok: tok}
+}
+
+type staticTokenSource struct {
+	tok *OAuth2Token
+}
+
+func (s staticTokenSource) Token(context.Context) (*OAuth2Token, error) {
+	return s.tok, nil
+}
+
+// bearerTokenSource is the app-only bearer token of the client.
+// It is replaced by GenerateAppOnlyBearerToken and InvalidateToken while requests may be in flight.
+type bearerTokenSource struct {
+	mu    sync.RWMutex
+	token string
+}
+
+func (b *bearerTokenSource) get() string {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.token
+}
+
+func (b *bearerTokenSource) set(token string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.token = token
+}
+
+func (b *bearerTokenSource) Token(context.Context) (*OAuth2Token, error) {
+	return &OAuth2Token{
+		AccessToken: b.get(),
+		TokenType:   "bearer",
+	}, nil
+}
+
+// RefreshTokenSource supplies an OAuth 2.0 user token, refreshing it with the refresh_token grant
+// shortly before it expires or after the API rejected it.
+// Concurrent callers share a single refresh.
+type RefreshTokenSource struct {
+	config *OAuth2Config
+	// sem guards tok and stale. It is a channel so that waiting for a refresh honors the context.
+	sem   chan struct{}
+	tok   *OAuth2Token
+	stale bool
+	// store persists refreshed tokens of the account. unsaved is set while the latest token failed to be saved.
+	store   TokenStore
+	account string
+	unsaved bool
+
+	// OnSaveError, if set, is called with the error of a refreshed token that could not be saved to the store.
+	// The token is supplied anyway, and saving it is retried by the next call of Token.
+	// It is called while the source is locked and must not call its methods. Set it before the source is used.
+	OnSaveError func(error)
+}
+
+// TokenSource returns a TokenSource supplying tok and refreshing it with the config.
+// The token must have a refresh token, i.e. be issued with the "offline.access" scope, to outlive its expiry.
+func (c *OAuth2Config) TokenSource(tok *OAuth2Token) *RefreshTokenSource {
+	return &RefreshTokenSource{
+		config: c,
+		sem:    make(chan struct{}, 1),
+		tok:    tok,
+	}
+}
+
+// Token returns the current token, refreshing it first if it is about to expire.
+// A refreshed token that can not be saved to the store of the source is supplied anyway, see OnSaveError.
+func (s *RefreshTokenSource) Token(ctx context.Context) (*OAuth2Token, error) {
+	select {
+	case s.sem <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	defer func() { <-s.sem }()
+
+	if s.tok == nil {
+		return nil, errors.New("refresh token source: no token")
+	}
+	if !s.stale && s.tok.validFor(tokenExpiryDelta) {
+		s.save(ctx)
+		return s.tok, nil
+	}
+	if s.tok.RefreshToken == "" {
+		if s.tok.Valid() {
+			return s.tok, nil
+		}
+		return nil, ErrTokenExpired
+	}
+	tok, err := s.config.Refresh(ctx, s.tok.RefreshToken)
+	if err != nil {
+		return nil, err
+	}
+	if tok.RefreshToken == "" {
+		tok.RefreshToken = s.tok.RefreshToken
+	}
+	s.tok = tok
+	s.stale = false
+	s.unsaved = s.store != nil
+	s.save(ctx)
+	return tok, nil
+}
+
+// save saves the refreshed token to the store.
+// The refresh token may be rotated by the refresh, so a failed save is reported and retried by the next call.
+func (s *RefreshTokenSource) save(ctx context.Context) {
+	if !s.unsaved {
+		return
+	}
+	if err := s.store.Save(ctx, s.account, s.tok); err != nil {
+		if s.OnSaveError != nil {
+			s.OnSaveError(fmt.Errorf("refresh token source: %w", err))
+		}
+		return
+	}
+	s.unsaved = false
+}
+
+// Invalidate marks tok to be refreshed by the next call of Token.
+// A token other than the current one, e.g. one already refreshed by another goroutine, is ignored.
+func (s *RefreshTokenSource) Invalidate(tok *OAuth2Token) {
+	s.sem <- struct{}{}
+	defer func() { <-s.sem }()
+	if tok != nil && s.tok != nil && s.tok.AccessToken == tok.AccessToken {
+		s.stale = true
+	}
+}
+
+func (t *OAuth2Token) validFor(d time.Duration) bool {
+	return t != nil && t.AccessToken != "" && (t.Expiry.IsZero() || time.Now().Add(d).Before(t.Expiry))
+}
+
+// Refresh exchanges the refresh token for a new token.
+func (c *OAuth2Config) Refresh(ctx context.Context, refreshToken string) (*OAuth2Token, error) {
+	if refreshToken == "" {
+		return nil, errors.New("refresh oauth2 token: refresh token parameter is required")
+	}
+	form := url.Values{}
+	form.Set("grant_type", "refresh_token")
+	form.Set("refresh_token", refreshToken)
+	return c.token(ctx, form, "refresh oauth2 token")
+}
@@ -0,0 +1,150 @@
+package gotwtr
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// ErrTokenNotFound is returned by a TokenStore holding no token for the account.
+var ErrTokenNotFound = errors.New("gotwtr: token not found")
+
+// TokenStore persists the OAuth 2.0 tokens of accounts, keyed by an account key chosen by the caller,
+// e.g. the user name. Implementations must be safe for concurrent use.
+type TokenStore interface {
+	// Load returns the token of the account, or ErrTokenNotFound.
+	Load(ctx context.Context, account string) (*OAuth2Token, error)
+	// Save stores the token of the account, replacing the previous one.
+	Save(ctx context.Context, account string, tok *OAuth2Token) error
+	// Delete removes the token of the account. Deleting a missing token is not an error.
+	Delete(ctx context.Context, account string) error
+}
+
+// FileTokenStore is a TokenStore keeping one file per account in a directory,
+// encrypted at rest with AES-GCM.
+type FileTokenStore struct {
+	dir  string
+	aead cipher.AEAD
+	mu   sync.Mutex
+}
+
+// NewFileTokenStore returns a FileTokenStore in dir, which is created if missing.
+// The key must be 16, 24 or 32 bytes to select AES-128, AES-192 or AES-256.
+func NewFileTokenStore(dir string, key []byte) (*FileTokenStore, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("new file token store: %w", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("new file token store: %w", err)
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("new file token store: %w", err)
+	}
+	return &FileTokenStore{
+		dir:  dir,
+		aead: aead,
+	}, nil
+}
+
+// path returns the file of the account. The account key is hashed so that any string is a safe file name.
+func (s *FileTokenStore) path(account string) string {
+	sum := sha256.Sum256([]byte(account))
+	return filepath.Join(s.dir, hex.EncodeToString(sum[:])+".token")
+}
+
+// Load returns the token of the account.
+func (s *FileTokenStore) Load(_ context.Context, account string) (*OAuth2Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	b, err := os.ReadFile(s.path(account))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrTokenNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("load token: %w", err)
+	}
+	n := s.aead.NonceSize()
+	if len(b) < n {
+		return nil, errors.New("load token: file is corrupted")
+	}
+	// The account key is authenticated so that a file can not be swapped for another account's.
+	plain, err := s.aead.Open(nil, b[:n], b[n:], []byte(account))
+	if err != nil {
+		return nil, fmt.Errorf("load token: %w", err)
+	}
+	var tok OAuth2Token
+	if err := json.Unmarshal(plain, &tok); err != nil {
+		return nil, fmt.Errorf("load token: %w", err)
+	}
+	return &tok, nil
+}
+
+// Save stores the token of the account. The file is replaced atomically.
+func (s *FileTokenStore) Save(_ context.Context, account string, tok *OAuth2Token) error {
+	if tok == nil {
+		return errors.New("save token: token parameter is required")
+	}
+	plain, err := json.Marshal(tok)
+	if err != nil {
+		return fmt.Errorf("save token: %w", err)
+	}
+	nonce := make([]byte, s.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return fmt.Errorf("save token: %w", err)
+	}
+	b := s.aead.Seal(nonce, nonce, plain, []byte(account))
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	f, err := os.CreateTemp(s.dir, ".token-*")
+	if err != nil {
+		return fmt.Errorf("save token: %w", err)
+	}
+	defer func() { _ = os.Remove(f.Name()) }()
+	if _, err := f.Write(b); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("save token: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("save token: %w", err)
+	}
+	if err := os.Rename(f.Name(), s.path(account)); err != nil {
+		return fmt.Errorf("save token: %w", err)
+	}
+	return nil
+}
+
+// Delete removes the token of the account.
+func (s *FileTokenStore) Delete(_ context.Context, account string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := os.Remove(s.path(account)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("delete token: %w", err)
+	}
+	return nil
+}
+
+// StoredTokenSource returns a TokenSource supplying the token of the account loaded from the store.
+// Every refreshed token is saved back to the store, so that a rotated refresh token survives restarts.
+func (c *OAuth2Config) StoredTokenSource(ctx context.Context, store TokenStore, account string) (*RefreshTokenSource, error) {
+	tok, err := store.Load(ctx, account)
+	if err != nil {
+		return nil, err
+	}
+	ts := c.TokenSource(tok)
+	ts.store = store
+	ts.account = account
+	return ts, nil
+}
@@ -0,0 +1,195 @@
+package gotwtr_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sivchari/gotwtr"
+)
+
+func Test_FileTokenStore(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	dir := t.TempDir()
+	key := bytes.Repeat([]byte{1}, 32)
+	store, err := gotwtr.NewFileTokenStore(dir, key)
+	if err != nil {
+		t.Fatalf("NewFileTokenStore() error = %v", err)
+	}
+
+	if _, err := store.Load(ctx, "gotwtr"); !errors.Is(err, gotwtr.ErrTokenNotFound) {
+		t.Errorf("Load() error = %v, want ErrTokenNotFound", err)
+	}
+
+	want := &gotwtr.OAuth2Token{
+		AccessToken:  "access",
+		RefreshToken: "refresh",
+		TokenType:    "bearer",
+		Scope:        "tweet.read offline.access",
+		Expiry:       time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	if err := store.Save(ctx, "gotwtr", want); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	got, err := store.Load(ctx, "gotwtr")
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Load() mismatch (-want +got):\n%s", diff)
+	}
+
+	files, err := filepath.Glob(filepath.Join(dir, "*"))
+	if err != nil || len(files) != 1 {
+		t.Fatalf("files = %v, %v, want one file", files, err)
+	}
+	b, err := os.ReadFile(files[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(b, []byte("refresh")) {
+		t.Errorf("token file is not encrypted: %q", b)
+	}
+	if fi, err := os.Stat(files[0]); err != nil || fi.Mode().Perm() != 0o600 {
+		t.Errorf("token file mode = %v, %v, want 0600", fi.Mode().Perm(), err)
+	}
+
+	other, err := gotwtr.NewFileTokenStore(dir, bytes.Repeat([]byte{2}, 32))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := other.Load(ctx, "gotwtr"); err == nil {
+		t.Error("Load() with another key must fail")
+	}
+
+	if err := store.Delete(ctx, "gotwtr"); err != nil {
+		t.Errorf("Delete() error = %v", err)
+	}
+	if err := store.Delete(ctx, "gotwtr"); err != nil {
+		t.Errorf("Delete() of a missing token error = %v", err)
+	}
+	if _, err := store.Load(ctx, "gotwtr"); !errors.Is(err, gotwtr.ErrTokenNotFound) {
+		t.Errorf("Load() after Delete() error = %v, want ErrTokenNotFound", err)
+	}
+}
+
+func Test_NewFileTokenStore_invalidKey(t *testing.T) {
+	t.Parallel()
+	if _, err := gotwtr.NewFileTokenStore(t.TempDir(), []byte("short")); err == nil {
+		t.Error("NewFileTokenStore() with a 5 byte key must fail")
+	}
+}
+
+func Test_OAuth2Config_StoredTokenSource(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	store, err := gotwtr.NewFileTokenStore(t.TempDir(), bytes.Repeat([]byte{1}, 16))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Save(ctx, "gotwtr", &gotwtr.OAuth2Token{
+		AccessToken:  "access-0",
+		RefreshToken: "refresh",
+		Expiry:       time.Now().Add(-time.Minute),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	var refreshes int32
+	cfg := newTokenServer(t, &refreshes)
+	ts, err := cfg.StoredTokenSource(ctx, store, "gotwtr")
+	if err != nil {
+		t.Fatalf("StoredTokenSource() error = %v", err)
+	}
+	tok, err := ts.Token(ctx)
+	if err != nil {
+		t.Fatalf("Token() error = %v", err)
+	}
+	saved, err := store.Load(ctx, "gotwtr")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tok.AccessToken != "access-1" || saved.AccessToken != "access-1" || saved.RefreshToken != "refresh" {
+		t.Errorf("Token() = %+v, saved %+v, want access-1", tok, saved)
+	}
+
+	if _, err := cfg.StoredTokenSource(ctx, store, "unknown"); !errors.Is(err, gotwtr.ErrTokenNotFound) {
+		t.Errorf("StoredTokenSource() error = %v, want ErrTokenNotFound", err)
+	}
+}
+
+// failingTokenStore holds a single token and fails the first fails calls of Save.
+type failingTokenStore struct {
+	tok   *gotwtr.OAuth2Token
+	fails int
+}
+
+func (s *failingTokenStore) Load(context.Context, string) (*gotwtr.OAuth2Token, error) {
+	return s.tok, nil
+}
+
+func (s *failingTokenStore) Save(_ context.Context, _ string, tok *gotwtr.OAuth2Token) error {
+	if s.fails > 0 {
+		s.fails--
+		return errors.New("disk full")
+	}
+	s.tok = tok
+	return nil
+}
+
+func (s *failingTokenStore) Delete(context.Context, string) error {
+	s.tok = nil
+	return nil
+}
+
+func Test_OAuth2Config_StoredTokenSource_saveError(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	store := &failingTokenStore{
+		tok: &gotwtr.OAuth2Token{
+			AccessToken:  "access-0",
+			RefreshToken: "refresh",
+			Expiry:       time.Now().Add(-time.Minute),
+		},
+		fails: 1,
+	}
+	var refreshes int32
+	cfg := newTokenServer(t, &refreshes)
+	ts, err := cfg.StoredTokenSource(ctx, store, "gotwtr")
+	if err != nil {
+		t.Fatalf("StoredTokenSource() error = %v", err)
+	}
+	var saveErrs []error
+	ts.OnSaveError = func(err error) {
+		saveErrs = append(saveErrs, err)
+	}
+
+	tok, err := ts.Token(ctx)
+	if err != nil {
+		t.Fatalf("Token() error = %v", err)
+	}
+	if tok.AccessToken != "access-1" {
+		t.Errorf("Token() = %+v, want access-1", tok)
+	}
+	if len(saveErrs) != 1 || store.tok.AccessToken != "access-0" {
+		t.Errorf("OnSaveError() errors = %v, saved %+v, want a failed save", saveErrs, store.tok)
+	}
+
+	// The next call saves the token without refreshing it again.
+	tok, err = ts.Token(ctx)
+	if err != nil {
+		t.Fatalf("Token() error = %v", err)
+	}
+	if tok.AccessToken != "access-1" || store.tok.AccessToken != "access-1" || len(saveErrs) != 1 {
+		t.Errorf("Token() = %+v, saved %+v, errors %v, want access-1 saved", tok, store.tok, saveErrs)
+	}
+	if refreshes != 1 {
+		t.Errorf("refreshes = %d, want 1", refreshes)
+	}
+}
@@ -0,0 +1,177 @@
+package gotwtr_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sivchari/gotwtr"
+)
+
+// newTokenServer returns a fake token endpoint issuing "access-<n>" for the refresh_token grant.
+func newTokenServer(t *testing.T, refreshes *int32) *gotwtr.OAuth2Config {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("ParseForm() error = %v", err)
+		}
+		if r.PostForm.Get("grant_type") != "refresh_token" || r.PostForm.Get("refresh_token") != "refresh" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = io.WriteString(w, `{"error":"invalid_request"}`)
+			return
+		}
+		n := atomic.AddInt32(refreshes, 1)
+		_, _ = fmt.Fprintf(w, `{"token_type":"bearer","expires_in":7200,"access_token":"access-%d"}`, n)
+	}))
+	t.Cleanup(srv.Close)
+	return &gotwtr.OAuth2Config{
+		ClientID:   "client-id",
+		TokenURL:   srv.URL,
+		HTTPClient: srv.Client(),
+	}
+}
+
+func Test_RefreshTokenSource(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name          string
+		token         *gotwtr.OAuth2Token
+		want          string
+		wantRefreshes int32
+		wantErr       error
+	}{
+		{
+			name:          "valid token",
+			token:         &gotwtr.OAuth2Token{AccessToken: "access-0", RefreshToken: "refresh", Expiry: time.Now().Add(time.Hour)},
+			want:          "access-0",
+			wantRefreshes: 0,
+		},
+		{
+			name:          "about to expire",
+			token:         &gotwtr.OAuth2Token{AccessToken: "access-0", RefreshToken: "refresh", Expiry: time.Now().Add(10 * time.Second)},
+			want:          "access-1",
+			wantRefreshes: 1,
+		},
+		{
+			name:          "expired without refresh token",
+			token:         &gotwtr.OAuth2Token{AccessToken: "access-0", Expiry: time.Now().Add(-time.Second)},
+			wantRefreshes: 0,
+			wantErr:       gotwtr.ErrTokenExpired,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var refreshes int32
+			ts := newTokenServer(t, &refreshes).TokenSource(tt.token)
+
+			// Concurrent callers share a single refresh.
+			var wg sync.WaitGroup
+			for i := 0; i < 8; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					got, err := ts.Token(context.Background())
+					if !errors.Is(err, tt.wantErr) {
+						t.Errorf("Token() error = %v, wantErr %v", err, tt.wantErr)
+						return
+					}
+					if err == nil && (got.AccessToken != tt.want || got.RefreshToken != "refresh") {
+						t.Errorf("Token() = %+v, want %v", got, tt.want)
+					}
+				}()
+			}
+			wg.Wait()
+			if got := atomic.LoadInt32(&refreshes); got != tt.wantRefreshes {
+				t.Errorf("refreshes = %v, want %v", got, tt.wantRefreshes)
+			}
+		})
+	}
+}
+
+func Test_WithTokenSource(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name          string
+		accepted      string
+		wantAuth      []string
+		wantRefreshes int32
+		wantErr       bool
+	}{
+		{
+			name:          "valid token",
+			accepted:      "access-0",
+			wantAuth:      []string{"Bearer access-0"},
+			wantRefreshes: 0,
+			wantErr:       false,
+		},
+		{
+			name:          "401 refreshes the token and retries once",
+			accepted:      "access-1",
+			wantAuth:      []string{"Bearer access-0", "Bearer access-1"},
+			wantRefreshes: 1,
+			wantErr:       false,
+		},
+		{
+			name:          "401 after the refresh",
+			accepted:      "",
+			wantAuth:      []string{"Bearer access-0", "Bearer access-1"},
+			wantRefreshes: 1,
+			wantErr:       true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var (
+				refreshes int32
+				mu        sync.Mutex
+				auth      []string
+			)
+			ts := newTokenServer(t, &refreshes).TokenSource(&gotwtr.OAuth2Token{
+				AccessToken:  "access-0",
+				RefreshToken: "refresh",
+				Expiry:       time.Now().Add(time.Hour),
+			})
+			client := mockHTTPClient(func(req *http.Request) *http.Response {
+				mu.Lock()
+				auth = append(auth, req.Header.Get("Authorization"))
+				mu.Unlock()
+				if req.Header.Get("Authorization") != "Bearer "+tt.accepted {
+					return &http.Response{
+						StatusCode: http.StatusUnauthorized,
+						Body:       io.NopCloser(strings.NewReader(`{"title":"Unauthorized","type":"about:blank","status":401,"detail":"Unauthorized"}`)),
+					}
+				}
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(strings.NewReader(`{"data":{"id":"1","name":"gotwtr","username":"gotwtr"}}`)),
+				}
+			})
+			c := gotwtr.New("", gotwtr.WithHTTPClient(client), gotwtr.WithTokenSource(ts))
+			_, err := c.Me(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Me() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr && !errors.Is(err, gotwtr.ErrUnauthorized) {
+				t.Errorf("Me() error = %v, want ErrUnauthorized", err)
+			}
+			if strings.Join(auth, ",") != strings.Join(tt.wantAuth, ",") {
+				t.Errorf("Authorization = %v, want %v", auth, tt.wantAuth)
+			}
+			if got := atomic.LoadInt32(&refreshes); got != tt.wantRefreshes {
+				t.Errorf("refreshes = %v, want %v", got, tt.wantRefreshes)
+			}
+		})
+	}
+}
@@ -2,7 +2,6 @@ package gotwtr
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
@@ -13,34 +12,12 @@ func trendsByWOEID(ctx context.Context, c *client, woeid string) (*TrendsByWOEID
 		return nil, errors.New("trends by woeid: woeid parameter is required")
 	}
 
-	ep := fmt.Sprintf(trendsByWOEIDURL, woeid)
+	ep := c.baseURL + fmt.Sprintf(trendsByWOEIDURL, woeid)
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ep, nil)
 	if err != nil {
 		return nil, fmt.Errorf("trends by woeid new request with ctx: %w", err)
 	}
 
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.bearerToken))
-
-	resp, err := c.client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("trends by woeid response: %w", err)
-	}
-
-	defer func() { _ = resp.Body.Close() }()
-
-	var tr TrendsByWOEIDResponse
-	if err := json.NewDecoder(resp.Body).Decode(&tr); err != nil {
-		return nil, fmt.Errorf("trends by woeid decode: %w", err)
-	}
-
-	if resp.StatusCode != http.StatusOK {
-		return &tr, &HTTPError{
-			APIName: "trends by woeid",
-			Status:  resp.Status,
-			URL:     req.URL.String(),
-		}
-	}
-
-	return &tr, nil
+	return doJSON[TrendsByWOEIDResponse](c, req, "trends by woeid", http.StatusOK)
 }
\ No newline at end of file
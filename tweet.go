@@ -1,8 +1,9 @@
 package gotwtr
 
 import (
-	"net/http"
+	"context"
 	"sync"
+	"sync/atomic"
 )
 
 type TweetField string
@@ -325,9 +326,10 @@ type AddOrDeleteMetaSummary struct {
 }
 
 type ConnectToStreamResponse struct {
-	Tweet         *Tweet          `json:"data"`
-	Includes      *TweetIncludes  `json:"includes,omitempty"`
-	MatchingRules []*MatchingRule `json:"matching_rules"`
+	Tweet         *Tweet              `json:"data"`
+	Includes      *TweetIncludes      `json:"includes,omitempty"`
+	MatchingRules []*MatchingRule     `json:"matching_rules"`
+	Errors        []*APIResponseError `json:"errors,omitempty"`
 }
 
 type MatchingRule struct {
@@ -336,11 +338,13 @@ type MatchingRule struct {
 }
 
 type ConnectToStream struct {
-	client *http.Client
+	client *client
 	errCh  chan<- error
 	ch     chan<- ConnectToStreamResponse
 	done   chan struct{}
+	cancel context.CancelFunc
 	wg     *sync.WaitGroup
+	last   atomic.Int64
 }
 
 type PostRetweetResponse struct {
@@ -368,11 +372,13 @@ type VolumeStreamsResponse struct {
 }
 
 type VolumeStreams struct {
-	client *http.Client
+	client *client
 	errCh  chan<- error
 	ch     chan<- VolumeStreamsResponse
 	done   chan struct{}
+	cancel context.CancelFunc
 	wg     *sync.WaitGroup
+	last   atomic.Int64
 }
 
 type LookUpUsersWhoLikedWithheld struct {
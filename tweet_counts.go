@@ -2,7 +2,6 @@ package gotwtr
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
@@ -13,11 +12,10 @@ func countRecentTweets(ctx context.Context, c *client, tweet string, opt ...*Twe
 		return nil, errors.New("count of recent tweets: tweet parameter is required")
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, countsRecentTweetsURL, nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+countsRecentTweetsURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("count of recent tweets new request with ctx: %w", err)
 	}
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.bearerToken))
 
 	var topt TweetCountsOption
 	switch len(opt) {
@@ -30,25 +28,7 @@ func countRecentTweets(ctx context.Context, c *client, tweet string, opt ...*Twe
 	}
 	topt.addQuery(req, tweet)
 
-	resp, err := c.client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("count of recent tweets: %w", err)
-	}
-	defer func() { _ = resp.Body.Close() }()
-
-	var tcr TweetCountsResponse
-	if err := json.NewDecoder(resp.Body).Decode(&tcr); err != nil {
-		return nil, fmt.Errorf("count of recent tweets: %w", err)
-	}
-	if resp.StatusCode != http.StatusOK {
-		return &tcr, &HTTPError{
-			APIName: "count of recent tweets",
-			Status:  resp.Status,
-			URL:     req.URL.String(),
-		}
-	}
-
-	return &tcr, nil
+	return doJSON[TweetCountsResponse](c, req, "count of recent tweets", http.StatusOK)
 }
 
 func countAllTweets(ctx context.Context, c *client, tweet string, opt ...*TweetCountsAllOption) (*TweetCountsResponse, error) {
@@ -56,11 +36,10 @@ func countAllTweets(ctx context.Context, c *client, tweet string, opt ...*TweetC
 		return nil, errors.New("count of all tweets: tweet parameter is required")
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, countsAllTweetsURL, nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+countsAllTweetsURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("count of all tweets new request with ctx: %w", err)
 	}
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.bearerToken))
 
 	var topt TweetCountsAllOption
 	switch len(opt) {
@@ -73,22 +52,5 @@ func countAllTweets(ctx context.Context, c *client, tweet string, opt ...*TweetC
 	}
 	topt.addQuery(req, tweet)
 
-	resp, err := c.client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("count of all tweets: %w", err)
-	}
-	defer func() { _ = resp.Body.Close() }()
-
-	var tcr TweetCountsResponse
-	if err := json.NewDecoder(resp.Body).Decode(&tcr); err != nil {
-		return nil, fmt.Errorf("count of all tweets: %w", err)
-	}
-	if resp.StatusCode != http.StatusOK {
-		return &tcr, &HTTPError{
-			APIName: "count of all tweets",
-			Status:  resp.Status,
-			URL:     req.URL.String(),
-		}
-	}
-	return &tcr, nil
+	return doJSON[TweetCountsResponse](c, req, "count of all tweets", http.StatusOK)
 }
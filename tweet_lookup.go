@@ -2,7 +2,6 @@ package gotwtr
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
@@ -16,7 +15,7 @@ func retrieveMultipleTweets(ctx context.Context, c *client, tweetIDs []string, o
 		return nil, errors.New("retrieve multiple tweets: tweet ids parameter must be less than or equal to 100")
 	default:
 	}
-	ep := retrieveMultipleTweetsURL
+	ep := c.baseURL + retrieveMultipleTweetsURL
 	for i, tid := range tweetIDs {
 		if i+1 < len(tweetIDs) {
 			ep += fmt.Sprintf("%s,", tid)
@@ -29,7 +28,6 @@ func retrieveMultipleTweets(ctx context.Context, c *client, tweetIDs []string, o
 	if err != nil {
 		return nil, fmt.Errorf("retrieve multiple tweets new request with ctx: %w", err)
 	}
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.bearerToken))
 
 	var ropt RetriveTweetOption
 	switch len(opt) {
@@ -42,40 +40,20 @@ func retrieveMultipleTweets(ctx context.Context, c *client, tweetIDs []string, o
 	}
 	ropt.addQuery(req)
 
-	resp, err := c.client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("retrieve multiple tweets response: %w", err)
-	}
-	defer func() { _ = resp.Body.Close() }()
-
-	var tweet TweetsResponse
-	if err := json.NewDecoder(resp.Body).Decode(&tweet); err != nil {
-		return nil, fmt.Errorf("retrieve multiple tweets: %w", err)
-	}
-	if resp.StatusCode != http.StatusOK {
-		return &tweet, &HTTPError{
-			APIName: "retrieve multiple tweets",
-			Status:  resp.Status,
-			URL:     req.URL.String(),
-		}
-	}
-
-	return &tweet, nil
+	return doJSON[TweetsResponse](c, req, "retrieve multiple tweets", http.StatusOK)
 }
 
 func retrieveSingleTweet(ctx context.Context, c *client, tweetID string, opt ...*RetriveTweetOption) (*TweetResponse, error) {
 	if tweetID == "" {
 		return nil, errors.New("retrieve single tweet: tweet id parameter is required")
 	}
-	ep := fmt.Sprintf(retrieveSingleTweetURL, tweetID)
+	ep := c.baseURL + fmt.Sprintf(retrieveSingleTweetURL, tweetID)
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ep, nil)
 	if err != nil {
 		return nil, fmt.Errorf("retrieve single tweet new request with ctx: %w", err)
 	}
 
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.bearerToken))
-
 	var ropt RetriveTweetOption
 	switch len(opt) {
 	case 0:
@@ -87,26 +65,7 @@ func retrieveSingleTweet(ctx context.Context, c *client, tweetID string, opt ...
 	}
 	ropt.addQuery(req)
 
-	resp, err := c.client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("retrieve single tweet response: %w", err)
-	}
-
-	defer func() { _ = resp.Body.Close() }()
-
-	var tweet TweetResponse
-	if err := json.NewDecoder(resp.Body).Decode(&tweet); err != nil {
-		return nil, fmt.Errorf("retrieve single tweet: %w", err)
-	}
-	if resp.StatusCode != http.StatusOK {
-		return &tweet, &HTTPError{
-			APIName: "retrieve single tweet",
-			Status:  resp.Status,
-			URL:     req.URL.String(),
-		}
-	}
-
-	return &tweet, nil
+	return doJSON[TweetResponse](c, req, "retrieve single tweet", http.StatusOK)
 }
 
 func quoteTweets(ctx context.Context, c *client, tweetID string, opt ...*QuoteTweetsOption) (*QuoteTweetsResponse, error) {
@@ -114,12 +73,11 @@ func quoteTweets(ctx context.Context, c *client, tweetID string, opt ...*QuoteTw
 		return nil, errors.New("quote tweets: tweet id parameter is required")
 	}
 
-	ep := fmt.Sprintf(quoteTweetsURL, tweetID)
+	ep := c.baseURL + fmt.Sprintf(quoteTweetsURL, tweetID)
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ep, nil)
 	if err != nil {
 		return nil, fmt.Errorf("quote tweets new request with ctx: %w", err)
 	}
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.bearerToken))
 
 	var qopt QuoteTweetsOption
 	switch len(opt) {
@@ -132,23 +90,5 @@ func quoteTweets(ctx context.Context, c *client, tweetID string, opt ...*QuoteTw
 	}
 	qopt.addQuery(req)
 
-	resp, err := c.client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("quote tweets response: %w", err)
-	}
-	defer func() { _ = resp.Body.Close() }()
-
-	var qtr QuoteTweetsResponse
-	if err := json.NewDecoder(resp.Body).Decode(&qtr); err != nil {
-		return nil, fmt.Errorf("quote tweets decode: %w", err)
-	}
-	if resp.StatusCode != http.StatusOK {
-		return &qtr, &HTTPError{
-			APIName: "quote tweets",
-			Status:  resp.Status,
-			URL:     req.URL.String(),
-		}
-	}
-
-	return &qtr, nil
+	return doJSON[QuoteTweetsResponse](c, req, "quote tweets", http.StatusOK)
 }
@@ -2,7 +2,6 @@ package gotwtr
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
@@ -17,13 +16,12 @@ func retrieveMultipleUsersWithIDs(ctx context.Context, c *client, userIDs []stri
 		return nil, errors.New("retrieve multiple users with ids: ids parameter must be less than or equal to 100")
 	default:
 	}
-	ep := retrieveMultipleUsersWithIDsURL + strings.Join(userIDs, ",")
+	ep := c.baseURL + retrieveMultipleUsersWithIDsURL + strings.Join(userIDs, ",")
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ep, nil)
 	if err != nil {
 		return nil, fmt.Errorf("retrieve multiple users with ids new request with ctx: %w", err)
 	}
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.bearerToken))
 
 	var ropt RetrieveUserOption
 	switch len(opt) {
@@ -36,40 +34,20 @@ func retrieveMultipleUsersWithIDs(ctx context.Context, c *client, userIDs []stri
 	}
 	ropt.addQuery(req)
 
-	resp, err := c.client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("retrieve multiple users with ids response: %w", err)
-	}
-	defer func() { _ = resp.Body.Close() }()
-
-	var ur UsersResponse
-	if err := json.NewDecoder(resp.Body).Decode(&ur); err != nil {
-		return nil, fmt.Errorf("retrieve multiple users with ids decode: %w", err)
-	}
-	if resp.StatusCode != http.StatusOK {
-		return &ur, &HTTPError{
-			APIName: "user lookup",
-			Status:  resp.Status,
-			URL:     req.URL.String(),
-		}
-	}
-
-	return &ur, nil
+	return doJSON[UsersResponse](c, req, "user lookup", http.StatusOK)
 }
 
 func retrieveSingleUserWithID(ctx context.Context, c *client, userID string, opt ...*RetrieveUserOption) (*UserResponse, error) {
 	if userID == "" {
 		return nil, errors.New("retrieve single user with id: user id is required")
 	}
-	ep := fmt.Sprintf(retrieveSingleUserWithIDURL, userID)
+	ep := c.baseURL + fmt.Sprintf(retrieveSingleUserWithIDURL, userID)
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ep, nil)
 	if err != nil {
 		return nil, fmt.Errorf("retrieve single user with id new request with ctx: %w", err)
 	}
 
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.bearerToken))
-
 	var ropt RetrieveUserOption
 	switch len(opt) {
 	case 0:
@@ -81,26 +59,7 @@ func retrieveSingleUserWithID(ctx context.Context, c *client, userID string, opt
 	}
 	ropt.addQuery(req)
 
-	resp, err := c.client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("retrieve single user with id response: %w", err)
-	}
-
-	defer func() { _ = resp.Body.Close() }()
-
-	var ur UserResponse
-	if err := json.NewDecoder(resp.Body).Decode(&ur); err != nil {
-		return nil, fmt.Errorf("retrieve single user with id decode: %w", err)
-	}
-	if resp.StatusCode != http.StatusOK {
-		return &ur, &HTTPError{
-			APIName: "retrieve single user with id",
-			Status:  resp.Status,
-			URL:     req.URL.String(),
-		}
-	}
-
-	return &ur, nil
+	return doJSON[UserResponse](c, req, "retrieve single user with id", http.StatusOK)
 }
 
 func retrieveMultipleUsersWithUserNames(ctx context.Context, c *client, userNames []string, opt ...*RetrieveUserOption) (*UsersResponse, error) {
@@ -111,15 +70,13 @@ func retrieveMultipleUsersWithUserNames(ctx context.Context, c *client, userName
 		return nil, errors.New("retrieve multiple users with user names: user names parameter must be less than or equal to 100")
 	default:
 	}
-	ep := retrieveMultipleUsersWithUserNamesURL + strings.Join(userNames, ",")
+	ep := c.baseURL + retrieveMultipleUsersWithUserNamesURL + strings.Join(userNames, ",")
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ep, nil)
 	if err != nil {
 		return nil, fmt.Errorf("retrieve multiple users with user names new request with ctx: %w", err)
 	}
 
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.bearerToken))
-
 	var ropt RetrieveUserOption
 	switch len(opt) {
 	case 0:
@@ -131,41 +88,20 @@ func retrieveMultipleUsersWithUserNames(ctx context.Context, c *client, userName
 	}
 	ropt.addQuery(req)
 
-	resp, err := c.client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("retrieve multiple users with user names response: %w", err)
-	}
-
-	defer func() { _ = resp.Body.Close() }()
-
-	var ur UsersResponse
-	if err := json.NewDecoder(resp.Body).Decode(&ur); err != nil {
-		return nil, fmt.Errorf("retrieve multiple users with user names decode: %w", err)
-	}
-	if resp.StatusCode != http.StatusOK {
-		return &ur, &HTTPError{
-			APIName: "users lookup by usernames",
-			Status:  resp.Status,
-			URL:     req.URL.String(),
-		}
-	}
-
-	return &ur, nil
+	return doJSON[UsersResponse](c, req, "users lookup by usernames", http.StatusOK)
 }
 
 func retrieveSingleUserWithUserName(ctx context.Context, c *client, userName string, opt ...*RetrieveUserOption) (*UserResponse, error) {
 	if userName == "" {
 		return nil, errors.New("retrieve single user with user name: user name is required")
 	}
-	ep := fmt.Sprintf(retrieveSingleUserWithUserNameURL, userName)
+	ep := c.baseURL + fmt.Sprintf(retrieveSingleUserWithUserNameURL, userName)
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ep, nil)
 	if err != nil {
 		return nil, fmt.Errorf("retrieve single user with user name new request with ctx: %w", err)
 	}
 
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.bearerToken))
-
 	var ropt RetrieveUserOption
 	switch len(opt) {
 	case 0:
@@ -177,26 +113,7 @@ func retrieveSingleUserWithUserName(ctx context.Context, c *client, userName str
 	}
 	ropt.addQuery(req)
 
-	resp, err := c.client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("retrieve single user with user name response: %w", err)
-	}
-
-	defer func() { _ = resp.Body.Close() }()
-
-	var ur UserResponse
-	if err := json.NewDecoder(resp.Body).Decode(&ur); err != nil {
-		return nil, fmt.Errorf("retrieve single user with user name decode: %w", err)
-	}
-	if resp.StatusCode != http.StatusOK {
-		return &ur, &HTTPError{
-			APIName: "retrieve single user with user name",
-			Status:  resp.Status,
-			URL:     req.URL.String(),
-		}
-	}
-
-	return &ur, nil
+	return doJSON[UserResponse](c, req, "retrieve single user with user name", http.StatusOK)
 }
 
 func searchUsers(ctx context.Context, c *client, query string, opt ...*SearchUsersOption) (*SearchUsersResponse, error) {
@@ -204,11 +121,10 @@ func searchUsers(ctx context.Context, c *client, query string, opt ...*SearchUse
 		return nil, errors.New("search users: query parameter is required")
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, searchUsersURL, nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+searchUsersURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("search users new request with ctx: %w", err)
 	}
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.bearerToken))
 
 	q := req.URL.Query()
 	q.Add("query", query)
@@ -225,23 +141,5 @@ func searchUsers(ctx context.Context, c *client, query string, opt ...*SearchUse
 	}
 	sopt.addQuery(req)
 
-	resp, err := c.client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("search users response: %w", err)
-	}
-	defer func() { _ = resp.Body.Close() }()
-
-	var sur SearchUsersResponse
-	if err := json.NewDecoder(resp.Body).Decode(&sur); err != nil {
-		return nil, fmt.Errorf("search users decode: %w", err)
-	}
-	if resp.StatusCode != http.StatusOK {
-		return &sur, &HTTPError{
-			APIName: "search users",
-			Status:  resp.Status,
-			URL:     req.URL.String(),
-		}
-	}
-
-	return &sur, nil
+	return doJSON[SearchUsersResponse](c, req, "search users", http.StatusOK)
 }
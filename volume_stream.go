@@ -2,12 +2,12 @@ package gotwtr
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"sync"
+	"time"
 )
 
 func stopped(done <-chan struct{}) bool {
@@ -19,48 +19,35 @@ func stopped(done <-chan struct{}) bool {
 	}
 }
 
+// Stop closes the connection of the stream and waits until its goroutine has ended.
 func (s *VolumeStreams) Stop() {
 	close(s.done)
+	s.cancel()
 	s.wg.Wait()
 }
 
-func (s *VolumeStreams) retry(req *http.Request) {
+// LastActivity returns the time the stream last received data, keep-alives included.
+// It returns the zero time until the stream is connected.
+func (s *VolumeStreams) LastActivity() time.Time {
+	return lastActivity(&s.last)
+}
+
+// retry connects to the stream, and reconnects according to the reconnect policy of the client until the stream is stopped.
+func (s *VolumeStreams) retry(req *http.Request, apiName string) {
 	defer s.wg.Done()
-	resp, err := s.client.Do(req)
-	if err != nil {
-		s.errCh <- err
-		return
-	}
-	defer func() { _ = resp.Body.Close() }()
-	if resp.StatusCode != http.StatusOK {
-		s.errCh <- &HTTPError{
-			APIName: "sampled stream",
-			Status:  resp.Status,
-			URL:     req.URL.String(),
-		}
-		return
-	}
-	dec := json.NewDecoder(resp.Body)
-	for !stopped(s.done) {
-		var v VolumeStreamsResponse
-		err := dec.Decode(&v)
-		if err != nil {
-			if err == io.EOF {
-				break
-			}
-			s.errCh <- err
-		}
-		s.ch <- v
-	}
+	s.client.stream(req, apiName, s.done, s.errCh, &s.last, func(body io.Reader) error {
+		return readStream(s.client, apiName, body, s.done, s.ch, s.errCh)
+	})
 }
 
 func volumeStreams(ctx context.Context, c *client, ch chan<- VolumeStreamsResponse, errCh chan<- error, opt ...*VolumeStreamsOption) *VolumeStreams {
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, volumeStreamsURL, nil)
+	ctx, cancel := context.WithCancel(ctx)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+volumeStreamsURL, nil)
 	if err != nil {
+		cancel()
 		errCh <- fmt.Errorf("sampled stream new request with ctx: %w", err)
 		return nil
 	}
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.bearerToken))
 
 	var vopt VolumeStreamsOption
 	switch len(opt) {
@@ -69,30 +56,33 @@ func volumeStreams(ctx context.Context, c *client, ch chan<- VolumeStreamsRespon
 	case 1:
 		vopt = *opt[0]
 	default:
+		cancel()
 		errCh <- errors.New("sampled stream: only one option is allowed")
 		return nil
 	}
 	vopt.addQuery(req)
 
 	vs := &VolumeStreams{
-		client: c.client,
+		client: c,
 		errCh:  errCh,
 		ch:     ch,
 		done:   make(chan struct{}),
+		cancel: cancel,
 		wg:     &sync.WaitGroup{},
 	}
 	vs.wg.Add(1)
-	go vs.retry(req)
+	go vs.retry(req, "sampled stream")
 	return vs
 }
 
 func volumeStreams10(ctx context.Context, c *client, ch chan<- VolumeStreamsResponse, errCh chan<- error, opt ...*VolumeStreamsOption) *VolumeStreams {
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, volumeStreams10URL, nil)
+	ctx, cancel := context.WithCancel(ctx)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+volumeStreams10URL, nil)
 	if err != nil {
+		cancel()
 		errCh <- fmt.Errorf("sampled stream 10%% new request with ctx: %w", err)
 		return nil
 	}
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.bearerToken))
 
 	var vopt VolumeStreamsOption
 	switch len(opt) {
@@ -101,19 +91,21 @@ func volumeStreams10(ctx context.Context, c *client, ch chan<- VolumeStreamsResp
 	case 1:
 		vopt = *opt[0]
 	default:
+		cancel()
 		errCh <- errors.New("sampled stream 10%: only one option is allowed")
 		return nil
 	}
 	vopt.addQuery(req)
 
 	vs := &VolumeStreams{
-		client: c.client,
+		client: c,
 		errCh:  errCh,
 		ch:     ch,
 		done:   make(chan struct{}),
+		cancel: cancel,
 		wg:     &sync.WaitGroup{},
 	}
 	vs.wg.Add(1)
-	go vs.retry(req)
+	go vs.retry(req, "sampled stream 10%")
 	return vs
 }